@@ -1,8 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"saas-backend/config"
 	"saas-backend/database"
@@ -35,9 +43,26 @@ func main() {
 	orgRepo := repository.NewOrganizationRepository(db)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
 	taskRepo := repository.NewTaskRepository(db)
+	taskDepRepo := repository.NewTaskDependencyRepository(db)
 	issueRepo := repository.NewIssueRepository(db)
+	watcherRepo := repository.NewWatcherRepository(db)
+	historyRepo := repository.NewHistoryRepository(db)
 	auditLogRepo := repository.NewAuditLogRepository(db)
 	documentRepo := repository.NewDocumentRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	workflowRepo := repository.NewWorkflowRepository(db)
+	recurringTaskRepo := repository.NewRecurringTaskRepository(db)
+	taskTemplateRepo := repository.NewTaskTemplateRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	customFieldRepo := repository.NewCustomFieldRepository(db)
+	worklogRepo := repository.NewWorklogRepository(db)
+	sprintRepo := repository.NewSprintRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	savedViewRepo := repository.NewSavedViewRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	approvalRepo := repository.NewApprovalRepository(db)
+	issueLinkRepo := repository.NewIssueLinkRepository(db)
 
 	// Initialize Gemini service (optional - will not fail if API key is missing)
 	var geminiService *service.GeminiService
@@ -79,12 +104,49 @@ func main() {
 	}
 
 	// Initialize services
-	authService := service.NewAuthService(userRepo, orgRepo, refreshTokenRepo, cfg)
-	taskService := service.NewTaskService(taskRepo, auditLogRepo, geminiService, langChainSvc, ragIndexer)
-	issueService := service.NewIssueService(issueRepo, auditLogRepo, geminiService, ragIndexer)
-	reportService := service.NewReportService(taskRepo, issueRepo, auditLogRepo, geminiService)
+	workflowService := service.NewWorkflowService(workflowRepo, auditLogRepo)
+	authService := service.NewAuthService(userRepo, orgRepo, refreshTokenRepo, workflowService, cfg)
+	projectService := service.NewProjectService(projectRepo, userRepo, auditLogRepo)
+	customFieldService := service.NewCustomFieldService(customFieldRepo, userRepo, auditLogRepo)
+	taskService := service.NewTaskService(taskRepo, taskDepRepo, watcherRepo, historyRepo, approvalRepo, issueLinkRepo, auditLogRepo, workflowService, projectService, customFieldService, geminiService, langChainSvc, ragIndexer, cfg)
+	issueService := service.NewIssueService(issueRepo, watcherRepo, historyRepo, auditLogRepo, projectService, customFieldService, geminiService, ragIndexer)
+	reportService := service.NewReportService(taskRepo, issueRepo, auditLogRepo, worklogRepo, workflowService, projectService, geminiService)
 	userService := service.NewUserService(userRepo, auditLogRepo)
-	documentService := service.NewDocumentService(documentRepo, geminiService, langChainSvc, ragIndexer, cfg)
+	documentService := service.NewDocumentService(documentRepo, projectService, geminiService, langChainSvc, ragIndexer, auditLogRepo, cfg)
+	commentService := service.NewCommentService(commentRepo, userRepo, auditLogRepo, taskService, issueService, ragIndexer)
+	recurringTaskService := service.NewRecurringTaskService(recurringTaskRepo, taskService, projectService, auditLogRepo)
+	taskTemplateService := service.NewTaskTemplateService(taskTemplateRepo, taskService, projectService, auditLogRepo)
+	labelService := service.NewLabelService(labelRepo, auditLogRepo, taskService, issueService)
+	issueLinkService := service.NewIssueLinkService(issueLinkRepo, auditLogRepo, taskService, issueService)
+	worklogService := service.NewWorklogService(worklogRepo, taskService, auditLogRepo)
+	sprintService := service.NewSprintService(sprintRepo, taskService, projectService, auditLogRepo)
+	searchService := service.NewSearchService(searchRepo, projectService)
+	savedViewService := service.NewSavedViewService(savedViewRepo, taskService, issueService, auditLogRepo)
+	trashService := service.NewTrashService(trashRepo, taskService, issueService, documentService, auditLogRepo, cfg.Trash.Retention)
+	approvalPolicyService := service.NewApprovalPolicyService(approvalRepo, projectService, auditLogRepo)
+
+	// Background workers run until the server is asked to stop.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var workers sync.WaitGroup
+
+	// Materialize recurring tasks in the background
+	if cfg.Tasks.RecurringPollInterval > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			recurringTaskService.RunScheduler(ctx, cfg.Tasks.RecurringPollInterval)
+		}()
+	}
+
+	// Permanently remove trash older than the retention period
+	if cfg.Trash.PurgeInterval > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			trashService.RunPurger(ctx, cfg.Trash.PurgeInterval)
+		}()
+	}
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService, cfg)
@@ -94,6 +156,21 @@ func main() {
 	reportHandler := handler.NewReportHandler(reportService)
 	auditLogHandler := handler.NewAuditLogHandler(auditLogRepo)
 	documentHandler := handler.NewDocumentHandler(documentService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	watcherHandler := handler.NewWatcherHandler(taskService, issueService)
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
+	recurringTaskHandler := handler.NewRecurringTaskHandler(recurringTaskService)
+	taskTemplateHandler := handler.NewTaskTemplateHandler(taskTemplateService)
+	labelHandler := handler.NewLabelHandler(labelService)
+	issueLinkHandler := handler.NewIssueLinkHandler(issueLinkService)
+	projectHandler := handler.NewProjectHandler(projectService)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+	worklogHandler := handler.NewWorklogHandler(worklogService)
+	sprintHandler := handler.NewSprintHandler(sprintService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	savedViewHandler := handler.NewSavedViewHandler(savedViewService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	approvalPolicyHandler := handler.NewApprovalPolicyHandler(approvalPolicyService)
 
 	// Setup Gin
 	if cfg.Server.Env == "production" {
@@ -103,12 +180,26 @@ func main() {
 	r := gin.Default()
 
 	// Setup routes
-	router.SetupRoutes(r, cfg, authHandler, taskHandler, issueHandler, userHandler, reportHandler, auditLogHandler, documentHandler, ragHandler)
+	router.SetupRoutes(r, cfg, authHandler, taskHandler, issueHandler, userHandler, reportHandler, auditLogHandler, documentHandler, commentHandler, watcherHandler, workflowHandler, recurringTaskHandler, taskTemplateHandler, labelHandler, issueLinkHandler, projectHandler, customFieldHandler, worklogHandler, sprintHandler, searchHandler, savedViewHandler, trashHandler, approvalPolicyHandler, ragHandler)
 
 	// Start server
 	addr := fmt.Sprintf(":%s", cfg.Server.Port)
-	log.Printf("Server starting on %s", addr)
-	if err := r.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	srv := &http.Server{Addr: addr, Handler: r}
+	go func() {
+		log.Printf("Server starting on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// On SIGINT or SIGTERM, stop accepting requests, let in-flight ones
+	// finish and wait for the background workers to return.
+	<-ctx.Done()
+	log.Printf("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown failed: %v", err)
 	}
+	workers.Wait()
 }
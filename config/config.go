@@ -16,6 +16,9 @@ type Config struct {
 	JWT      JWTConfig
 	Gemini   GeminiConfig
 	CORS     CORSConfig
+	Tasks    TasksConfig
+	Trash    TrashConfig
+	Issues   IssuesConfig
 }
 
 type ServerConfig struct {
@@ -50,6 +53,25 @@ type CORSConfig struct {
 	AllowedOrigins []string
 }
 
+type TasksConfig struct {
+	// RequireSubtasksComplete refuses to move a parent task into a completed status while any subtask is still open.
+	RequireSubtasksComplete bool
+	// RecurringPollInterval is how often due recurring tasks are materialized; zero disables the scheduler.
+	RecurringPollInterval time.Duration
+}
+
+type TrashConfig struct {
+	// Retention is how long deleted tasks, issues and documents stay restorable.
+	Retention time.Duration
+	// PurgeInterval is how often expired trash is removed; zero disables the purge job.
+	PurgeInterval time.Duration
+}
+
+type IssuesConfig struct {
+	// AutoResolve resolves an issue once every task linked as fixing it is approved.
+	AutoResolve bool
+}
+
 func Load() (*Config, error) {
 	// Try to load .env file from multiple locations
 	// First try current directory, then walk up to find the project root
@@ -81,6 +103,21 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT_REFRESH_EXPIRY: %w", err)
 	}
 
+	recurringPollInterval, err := time.ParseDuration(getEnv("RECURRING_TASKS_POLL_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RECURRING_TASKS_POLL_INTERVAL: %w", err)
+	}
+
+	trashRetention, err := time.ParseDuration(getEnv("TRASH_RETENTION", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRASH_RETENTION: %w", err)
+	}
+
+	trashPurgeInterval, err := time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRASH_PURGE_INTERVAL: %w", err)
+	}
+
 	config := &Config{
 		Server: ServerConfig{
 			Port:      getEnv("PORT", "8080"),
@@ -109,6 +146,17 @@ func Load() (*Config, error) {
 		CORS: CORSConfig{
 			AllowedOrigins: parseList(getEnv("ALLOWED_ORIGINS", "http://localhost:3000")),
 		},
+		Tasks: TasksConfig{
+			RequireSubtasksComplete: getEnv("TASK_REQUIRE_SUBTASKS_COMPLETE", "false") == "true",
+			RecurringPollInterval:   recurringPollInterval,
+		},
+		Trash: TrashConfig{
+			Retention:     trashRetention,
+			PurgeInterval: trashPurgeInterval,
+		},
+		Issues: IssuesConfig{
+			AutoResolve: getEnv("ISSUE_AUTO_RESOLVE", "false") == "true",
+		},
 	}
 
 	// JWT secrets: required in production; auto-default in development to reduce setup friction.
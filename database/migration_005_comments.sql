@@ -0,0 +1,26 @@
+-- Migration: Threaded comments on tasks and issues
+-- Each comment belongs to exactly one task or issue and may reply to another comment.
+
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    task_id UUID REFERENCES tasks(id) ON DELETE CASCADE,
+    issue_id UUID REFERENCES issues(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((task_id IS NOT NULL AND issue_id IS NULL) OR (task_id IS NULL AND issue_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_org_id ON comments(org_id);
+CREATE INDEX IF NOT EXISTS idx_comments_task_id ON comments(task_id) WHERE task_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_issue_id ON comments(issue_id) WHERE issue_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments(parent_id);
+CREATE INDEX IF NOT EXISTS idx_comments_author_id ON comments(author_id);
+
+DROP TRIGGER IF EXISTS update_comments_updated_at ON comments;
+CREATE TRIGGER update_comments_updated_at BEFORE UPDATE ON comments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Migration: Subtasks
+-- Tasks may point at a parent task. Deleting a parent promotes its children to top-level tasks.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Migration: Task dependencies (blocks / blocked-by)
+-- A row means task_id cannot proceed until depends_on_id is approved.
+
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    depends_on_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, depends_on_id),
+    CHECK (task_id <> depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_org_id ON task_dependencies(org_id);
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on ON task_dependencies(depends_on_id);
+
+-- Remember the status a task had before it was automatically blocked so it can be restored.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS blocked_from_status VARCHAR(50);
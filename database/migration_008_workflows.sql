@@ -0,0 +1,33 @@
+-- Migration: Per-organization workflow state machine
+-- Each organization defines its task statuses and the transitions between them.
+-- Organizations without rows here fall back to the built-in default workflow
+-- (todo -> in_progress -> done -> verified -> approved).
+
+CREATE TABLE IF NOT EXISTS workflow_statuses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    is_initial BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(org_id, key)
+);
+
+-- allowed_roles holds user roles (admin, manager, member) and the pseudo-role
+-- "assignee", which lets the task's assignee perform the transition.
+CREATE TABLE IF NOT EXISTS workflow_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    allowed_roles TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(org_id, from_status, to_status)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_statuses_org_id ON workflow_statuses(org_id);
+CREATE INDEX IF NOT EXISTS idx_workflow_transitions_org_id ON workflow_transitions(org_id);
+
+-- Task statuses are now defined per organization, so the fixed list no longer applies.
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
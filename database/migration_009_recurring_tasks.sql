@@ -0,0 +1,34 @@
+-- Migration: Recurring tasks
+-- A recurring task is a template plus an iCalendar RRULE. The scheduler creates
+-- a regular task each time next_run_at comes due and then advances next_run_at.
+
+CREATE TABLE IF NOT EXISTS recurring_tasks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority VARCHAR(50) DEFAULT 'medium' CHECK (priority IN ('low', 'medium', 'high', 'urgent')),
+    assigned_to UUID REFERENCES users(id) ON DELETE SET NULL,
+    rrule TEXT NOT NULL,
+    dtstart TIMESTAMP WITH TIME ZONE NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    -- Generated tasks are due this many minutes after their occurrence; NULL means no due date.
+    due_offset_minutes INT,
+    is_paused BOOLEAN NOT NULL DEFAULT false,
+    -- NULL once the rule has no further occurrences.
+    next_run_at TIMESTAMP WITH TIME ZONE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_tasks_org_id ON recurring_tasks(org_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_tasks_next_run ON recurring_tasks(next_run_at) WHERE is_paused = false;
+
+DROP TRIGGER IF EXISTS update_recurring_tasks_updated_at ON recurring_tasks;
+CREATE TRIGGER update_recurring_tasks_updated_at BEFORE UPDATE ON recurring_tasks
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurring_task_id UUID REFERENCES recurring_tasks(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_recurring_task_id ON tasks(recurring_task_id);
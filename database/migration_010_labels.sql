@@ -0,0 +1,35 @@
+-- Migration: Labels for tasks and issues
+-- Labels are org-scoped; names are unique per organization regardless of case.
+
+CREATE TABLE IF NOT EXISTS labels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#6B7280',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_org_name ON labels(org_id, LOWER(name));
+
+DROP TRIGGER IF EXISTS update_labels_updated_at ON labels;
+CREATE TRIGGER update_labels_updated_at BEFORE UPDATE ON labels
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS task_labels (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels(label_id);
+
+CREATE TABLE IF NOT EXISTS issue_labels (
+    issue_id UUID NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, label_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_labels_label_id ON issue_labels(label_id);
@@ -0,0 +1,28 @@
+-- Migration: Custom fields
+-- Organizations define typed fields for tasks and issues. Values are stored
+-- per entity in a JSONB column keyed by the field key.
+
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('task', 'issue')),
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    field_type VARCHAR(20) NOT NULL CHECK (field_type IN ('text', 'number', 'date', 'single_select', 'multi_select', 'user')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (org_id, entity_type, key)
+);
+
+DROP TRIGGER IF EXISTS update_custom_fields_updated_at ON custom_fields;
+CREATE TRIGGER update_custom_fields_updated_at BEFORE UPDATE ON custom_fields
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+-- GIN indexes serve the containment (@>) filters used by list endpoints.
+CREATE INDEX IF NOT EXISTS idx_tasks_custom_fields ON tasks USING GIN (custom_fields);
+CREATE INDEX IF NOT EXISTS idx_issues_custom_fields ON issues USING GIN (custom_fields);
@@ -0,0 +1,29 @@
+-- Migration: Worklogs
+-- Time entries against tasks, either logged manually or recorded with a
+-- start/stop timer. A running timer has is_running = true and no duration yet.
+
+CREATE TABLE IF NOT EXISTS worklogs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    work_date DATE NOT NULL,
+    duration_seconds INTEGER NOT NULL DEFAULT 0 CHECK (duration_seconds >= 0),
+    started_at TIMESTAMP WITH TIME ZONE,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    is_running BOOLEAN NOT NULL DEFAULT false,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_worklogs_task_id ON worklogs(task_id);
+CREATE INDEX IF NOT EXISTS idx_worklogs_org_date ON worklogs(org_id, work_date);
+CREATE INDEX IF NOT EXISTS idx_worklogs_user_date ON worklogs(user_id, work_date);
+
+-- A user can only have one timer running at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_worklogs_running_timer ON worklogs(user_id) WHERE is_running;
+
+DROP TRIGGER IF EXISTS update_worklogs_updated_at ON worklogs;
+CREATE TRIGGER update_worklogs_updated_at BEFORE UPDATE ON worklogs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
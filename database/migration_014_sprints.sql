@@ -0,0 +1,44 @@
+-- Migration: Sprints
+-- Time-boxed iterations that group tasks. A sprint moves planned -> active ->
+-- closed; closing it carries unfinished tasks forward. Velocity is the sum of
+-- estimate_points of tasks completed inside the sprint window, so tasks also
+-- record when they first reached a completed status.
+
+CREATE TABLE IF NOT EXISTS sprints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    goal TEXT,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    state VARCHAR(20) NOT NULL DEFAULT 'planned' CHECK (state IN ('planned', 'active', 'closed')),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sprints_org_id ON sprints(org_id, start_date);
+CREATE INDEX IF NOT EXISTS idx_sprints_project_id ON sprints(project_id);
+
+-- One active sprint per project (or per organization for sprints without a project).
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sprints_one_active
+    ON sprints(org_id, COALESCE(project_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE state = 'active';
+
+DROP TRIGGER IF EXISTS update_sprints_updated_at ON sprints;
+CREATE TRIGGER update_sprints_updated_at BEFORE UPDATE ON sprints
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS sprint_id UUID REFERENCES sprints(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate_points NUMERIC(6,2) CHECK (estimate_points >= 0);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_sprint_id ON tasks(sprint_id);
+
+-- Backfill completion times for tasks that are already complete.
+UPDATE tasks
+SET completed_at = COALESCE(approved_at, verified_at, updated_at)
+WHERE status IN ('done', 'verified', 'approved') AND completed_at IS NULL;
@@ -0,0 +1,22 @@
+-- Migration: Kanban board ordering
+-- board_rank orders tasks within their status column. Ranks are base-36
+-- strings compared bytewise, so a task can always be placed between two
+-- neighbors without renumbering the column. Columns may carry a WIP limit.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS board_rank TEXT COLLATE "C";
+
+-- Seed ranks for existing tasks in creation order within each column.
+UPDATE tasks t
+SET board_rank = ranked.rank
+FROM (
+    SELECT id, LPAD(ROW_NUMBER() OVER (PARTITION BY org_id, status ORDER BY created_at, id)::TEXT, 8, '0') || 'i' AS rank
+    FROM tasks
+) ranked
+WHERE ranked.id = t.id AND t.board_rank IS NULL;
+
+ALTER TABLE tasks ALTER COLUMN board_rank SET DEFAULT 'i';
+ALTER TABLE tasks ALTER COLUMN board_rank SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_board_rank ON tasks(org_id, status, board_rank);
+
+ALTER TABLE workflow_statuses ADD COLUMN IF NOT EXISTS wip_limit INT CHECK (wip_limit > 0);
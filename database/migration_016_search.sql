@@ -0,0 +1,26 @@
+-- Migration: Keyword full-text search
+-- Generated tsvector columns let GET /search match tasks, issues and documents
+-- without an embedding model. Titles weigh more than bodies. Extracted document
+-- text is capped so very large files stay under the tsvector size limit.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(title, '') || ' ' || filename), 'A') ||
+        setweight(to_tsvector('english', LEFT(COALESCE(extracted_text, ''), 500000)), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_issues_search ON issues USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_documents_search ON documents USING GIN(search_vector);
@@ -0,0 +1,43 @@
+-- Migration: Saved views
+-- A saved view stores a task or issue listing: a q= filter expression, sort,
+-- visible columns and grouping. Views are private to their owner or shared
+-- with the whole organization or one role. Managers can pin a shared view as
+-- the default for a role.
+
+CREATE TABLE IF NOT EXISTS saved_views (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('task', 'issue')),
+    query TEXT NOT NULL DEFAULT '',
+    sort VARCHAR(50) NOT NULL DEFAULT '',
+    sort_order VARCHAR(4) NOT NULL DEFAULT '' CHECK (sort_order IN ('', 'asc', 'desc')),
+    columns TEXT[] NOT NULL DEFAULT '{}',
+    group_by VARCHAR(50) NOT NULL DEFAULT '',
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'org', 'role')),
+    shared_role VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (org_id, owner_id, entity_type, name),
+    CHECK ((visibility = 'role') = (shared_role IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_org ON saved_views(org_id, entity_type);
+
+DROP TRIGGER IF EXISTS update_saved_views_updated_at ON saved_views;
+CREATE TRIGGER update_saved_views_updated_at BEFORE UPDATE ON saved_views
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- At most one pinned default view per organization, entity type and role.
+CREATE TABLE IF NOT EXISTS saved_view_defaults (
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    entity_type VARCHAR(20) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    view_id UUID NOT NULL REFERENCES saved_views(id) ON DELETE CASCADE,
+    pinned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, entity_type, role)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_view_defaults_view ON saved_view_defaults(view_id);
@@ -0,0 +1,35 @@
+-- Migration: Task templates
+-- A task template is a reusable task with optional subtasks. Titles and
+-- descriptions may contain {{placeholders}} that are filled in when the
+-- template is instantiated. Due dates are stored as offsets from that moment.
+
+CREATE TABLE IF NOT EXISTS task_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority VARCHAR(50) DEFAULT 'medium' CHECK (priority IN ('low', 'medium', 'high', 'urgent')),
+    assigned_to UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- Instantiated tasks are due this many minutes after creation; NULL means no due date.
+    due_offset_minutes INT,
+    -- [{title, description, priority, assigned_to, due_offset_minutes}]
+    subtasks JSONB NOT NULL DEFAULT '[]',
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (org_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_templates_org_id ON task_templates(org_id);
+
+DROP TRIGGER IF EXISTS update_task_templates_updated_at ON task_templates;
+CREATE TRIGGER update_task_templates_updated_at BEFORE UPDATE ON task_templates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS task_template_labels (
+    template_id UUID NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    PRIMARY KEY (template_id, label_id)
+);
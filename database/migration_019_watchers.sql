@@ -0,0 +1,36 @@
+-- Migration: Task and issue watchers
+-- Watchers follow a task or issue. Creators, assignees, commenters and users
+-- @mentioned in comments are added automatically; anyone who can see the
+-- entity may watch or unwatch it. Watchers are the recipients of change
+-- notifications.
+
+CREATE TABLE IF NOT EXISTS task_watchers (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_watchers_user_id ON task_watchers(user_id);
+
+CREATE TABLE IF NOT EXISTS issue_watchers (
+    issue_id UUID NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_watchers_user_id ON issue_watchers(user_id);
+
+-- Existing creators and assignees start out watching their work.
+INSERT INTO task_watchers (task_id, user_id)
+SELECT id, created_by FROM tasks
+UNION
+SELECT id, assigned_to FROM tasks WHERE assigned_to IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO issue_watchers (issue_id, user_id)
+SELECT id, reported_by FROM issues
+UNION
+SELECT id, assigned_to FROM issues WHERE assigned_to IS NOT NULL
+ON CONFLICT DO NOTHING;
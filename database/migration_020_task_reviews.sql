@@ -0,0 +1,43 @@
+-- Migration: Task review history
+-- Every step of the verify/approve workflow (submit, verify, approve, reject)
+-- appends a row to task_reviews with the actor, notes and the document that
+-- was attached at the time. Rows are never updated. Rejections require notes
+-- and increment the task's rework_count.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS rework_count INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS task_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('submit', 'verify', 'approve', 'reject')),
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    notes TEXT,
+    document_filename VARCHAR(255),
+    document_path VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (action <> 'reject' OR COALESCE(TRIM(notes), '') <> '')
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_reviews_task ON task_reviews(task_id, created_at);
+
+-- Review history is append-only. Deletes still cascade from tasks, and
+-- actor_id may still be cleared when a user is deleted.
+CREATE OR REPLACE FUNCTION prevent_task_review_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.actor_id IS NULL AND OLD.actor_id IS NOT NULL
+        AND (NEW.id, NEW.org_id, NEW.task_id, NEW.action, NEW.from_status, NEW.to_status, NEW.notes, NEW.document_filename, NEW.document_path, NEW.created_at)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.org_id, OLD.task_id, OLD.action, OLD.from_status, OLD.to_status, OLD.notes, OLD.document_filename, OLD.document_path, OLD.created_at) THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'task_reviews is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS task_reviews_append_only ON task_reviews;
+CREATE TRIGGER task_reviews_append_only BEFORE UPDATE ON task_reviews
+    FOR EACH ROW EXECUTE FUNCTION prevent_task_review_update();
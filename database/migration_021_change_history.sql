@@ -0,0 +1,27 @@
+-- Migration: Field-level change history
+-- Each task or issue update that changes something appends one row listing
+-- the changed fields with their old and new values, plus a readable
+-- description of each change:
+-- [{"field": "priority", "old": "medium", "new": "high", "text": "..."}]
+
+CREATE TABLE IF NOT EXISTS task_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    changes JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_history_task ON task_history(task_id, created_at);
+
+CREATE TABLE IF NOT EXISTS issue_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    issue_id UUID NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    changes JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_history_issue ON issue_history(issue_id, created_at);
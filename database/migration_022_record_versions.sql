@@ -0,0 +1,7 @@
+-- Migration: Record versions for optimistic concurrency
+-- Every write to a task or issue increments its version. Updates only apply
+-- when the version still matches the one the writer read, and clients can
+-- send it back as an If-Match header to detect conflicting edits.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
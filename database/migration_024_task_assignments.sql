@@ -0,0 +1,20 @@
+-- Migration: Multiple assignees and designated reviewers and approvers
+-- task_assignments lists every assignee of a task, plus the users designated
+-- to verify (reviewer) and approve (approver) it. tasks.assigned_to remains
+-- the primary assignee and always appears among the assignee rows. When a
+-- task has reviewers or approvers, only they may take it through that step.
+
+CREATE TABLE IF NOT EXISTS task_assignments (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('assignee', 'reviewer', 'approver')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, kind, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_assignments_user_id ON task_assignments(user_id, kind);
+
+-- Existing assignees become each task's first assignee row.
+INSERT INTO task_assignments (task_id, user_id, kind)
+SELECT id, assigned_to, 'assignee' FROM tasks WHERE assigned_to IS NOT NULL
+ON CONFLICT DO NOTHING;
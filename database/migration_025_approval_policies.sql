@@ -0,0 +1,38 @@
+-- Migration: Approval policies
+-- An approval policy makes task sign-off take several approvals. A policy set
+-- on a project applies to its tasks; a policy without a project is the
+-- organization's default. Each approval is a row in task_approvals; the task
+-- only reaches approved once the policy is satisfied. Approvals count from the
+-- task's latest submit, verify or reject, so a rejected task starts over.
+
+CREATE TABLE IF NOT EXISTS approval_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    min_approvals INT NOT NULL DEFAULT 1 CHECK (min_approvals >= 1),
+    -- requirements is a JSON array of {"role": ...} or {"user_id": ...}
+    -- entries, each needing an approval of its own.
+    requirements JSONB NOT NULL DEFAULT '[]',
+    sequential BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_policies_org_default ON approval_policies(org_id) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_policies_project ON approval_policies(project_id) WHERE project_id IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_approval_policies_updated_at ON approval_policies;
+CREATE TRIGGER update_approval_policies_updated_at BEFORE UPDATE ON approval_policies
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS task_approvals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    role VARCHAR(50) NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_approvals_task_id ON task_approvals(task_id, created_at);
@@ -0,0 +1,24 @@
+-- Migration: Issue links
+-- Links an issue to a task or to another issue. A row reads "the issue
+-- <link_type> the linked task or issue" (for example, an issue caused_by a
+-- task, or duplicates another issue), except that a fixes link means the task
+-- fixes the issue. Only tasks can fix an issue.
+
+CREATE TABLE IF NOT EXISTS issue_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    issue_id UUID NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+    link_type VARCHAR(20) NOT NULL CHECK (link_type IN ('fixes', 'relates_to', 'duplicates', 'caused_by')),
+    task_id UUID REFERENCES tasks(id) ON DELETE CASCADE,
+    linked_issue_id UUID REFERENCES issues(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((task_id IS NULL) <> (linked_issue_id IS NULL)),
+    CHECK (link_type <> 'fixes' OR task_id IS NOT NULL),
+    CHECK (issue_id <> linked_issue_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issue_links_task ON issue_links(issue_id, link_type, task_id) WHERE task_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issue_links_issue ON issue_links(issue_id, link_type, linked_issue_id) WHERE linked_issue_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_issue_links_task_id ON issue_links(task_id);
+CREATE INDEX IF NOT EXISTS idx_issue_links_linked_issue_id ON issue_links(linked_issue_id);
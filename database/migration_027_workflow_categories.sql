@@ -0,0 +1,55 @@
+-- Migration: Workflow status categories
+-- Each workflow status gets a category (todo, in_progress, completed or
+-- blocked) so completion, sprint roll-ups and auto-blocking work with custom
+-- statuses. A completed status may also carry the review step that reaches it:
+-- submit (mark done), verify or approve. Reports and the review endpoints use
+-- these instead of fixed status names.
+
+ALTER TABLE workflow_statuses ADD COLUMN IF NOT EXISTS category VARCHAR(20) NOT NULL DEFAULT 'in_progress'
+    CHECK (category IN ('todo', 'in_progress', 'completed', 'blocked'));
+ALTER TABLE workflow_statuses ADD COLUMN IF NOT EXISTS review_step VARCHAR(20)
+    CHECK (review_step IN ('submit', 'verify', 'approve'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workflow_statuses_review_step ON workflow_statuses(org_id, review_step) WHERE review_step IS NOT NULL;
+
+-- Organizations still on the built-in workflow get it stored, so queries can
+-- always read categories from workflow_statuses.
+INSERT INTO workflow_statuses (org_id, key, name, position, is_initial)
+SELECT o.id, s.key, s.name, s.position, s.is_initial
+FROM organizations o
+CROSS JOIN (VALUES
+    ('todo', 'To Do', 0, true),
+    ('in_progress', 'In Progress', 1, false),
+    ('done', 'Done', 2, false),
+    ('verified', 'Verified', 3, false),
+    ('approved', 'Approved', 4, false),
+    ('blocked', 'Blocked', 5, false)
+) AS s (key, name, position, is_initial)
+WHERE NOT EXISTS (SELECT 1 FROM workflow_statuses ws WHERE ws.org_id = o.id);
+
+INSERT INTO workflow_transitions (org_id, from_status, to_status, allowed_roles)
+SELECT o.id, t.from_status, t.to_status, t.allowed_roles
+FROM organizations o
+CROSS JOIN (VALUES
+    ('todo', 'in_progress', ARRAY['assignee', 'manager', 'admin']),
+    ('in_progress', 'todo', ARRAY['assignee', 'manager', 'admin']),
+    ('todo', 'done', ARRAY['assignee']),
+    ('in_progress', 'done', ARRAY['assignee']),
+    ('done', 'verified', ARRAY['manager', 'admin']),
+    ('done', 'in_progress', ARRAY['manager', 'admin']),
+    ('verified', 'approved', ARRAY['admin']),
+    ('verified', 'in_progress', ARRAY['manager', 'admin']),
+    ('todo', 'blocked', ARRAY['manager', 'admin']),
+    ('in_progress', 'blocked', ARRAY['manager', 'admin']),
+    ('blocked', 'todo', ARRAY['manager', 'admin']),
+    ('blocked', 'in_progress', ARRAY['manager', 'admin'])
+) AS t (from_status, to_status, allowed_roles)
+WHERE NOT EXISTS (SELECT 1 FROM workflow_transitions wt WHERE wt.org_id = o.id)
+ON CONFLICT (org_id, from_status, to_status) DO NOTHING;
+
+-- Categorize existing statuses by their well-known keys.
+UPDATE workflow_statuses SET category = 'todo' WHERE is_initial;
+UPDATE workflow_statuses SET category = 'blocked' WHERE key = 'blocked';
+UPDATE workflow_statuses SET category = 'completed', review_step = 'submit' WHERE key = 'done';
+UPDATE workflow_statuses SET category = 'completed', review_step = 'verify' WHERE key = 'verified';
+UPDATE workflow_statuses SET category = 'completed', review_step = 'approve' WHERE key = 'approved';
@@ -190,7 +190,7 @@ export default function Tasks() {
     if (!reason) return;
 
     try {
-      await taskService.rejectTask(id);
+      await taskService.rejectTask(id, reason);
       toast.success('Task rejected and sent back');
       loadTasks();
     } catch (error) {
@@ -616,6 +616,13 @@ export default function Tasks() {
                       </div>
                     )}
 
+                    {/* Rework info */}
+                    {task.status === 'in_progress' && task.latest_rejection_note && (
+                      <div className="text-xs text-red-300 bg-red-900/20 border border-red-500/30 rounded-lg p-2 mb-3">
+                        Sent back{task.rework_count > 1 ? ` ${task.rework_count} times` : ''}: {task.latest_rejection_note}
+                      </div>
+                    )}
+
                     {/* Document Info - Enhanced for Manager/Admin Review */}
                     {task.document_filename && (
                       <div className="mt-3 p-4 bg-gradient-to-r from-blue-900/30 to-purple-900/30 border-2 border-blue-500/40 rounded-xl shadow-lg">
@@ -98,8 +98,8 @@ export const taskService = {
     return response;
   },
 
-  async rejectTask(id) {
-    const response = await api.post(`/tasks/${id}/reject`);
+  async rejectTask(id, reason) {
+    const response = await api.post(`/tasks/${id}/reject`, { reason });
     return response;
   },
 };
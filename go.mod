@@ -12,6 +12,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/ledongthuc/pdf v0.0.0-20250511090121-5959a4027728
 	github.com/lib/pq v1.10.9
+	github.com/teambition/rrule-go v1.8.2
 	github.com/tmc/langchaingo v0.1.13
 	golang.org/x/crypto v0.46.0
 	google.golang.org/api v0.258.0
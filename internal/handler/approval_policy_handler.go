@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApprovalPolicyHandler struct {
+	approvalPolicyService *service.ApprovalPolicyService
+}
+
+func NewApprovalPolicyHandler(approvalPolicyService *service.ApprovalPolicyService) *ApprovalPolicyHandler {
+	return &ApprovalPolicyHandler{
+		approvalPolicyService: approvalPolicyService,
+	}
+}
+
+func (h *ApprovalPolicyHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+
+	policies, err := h.approvalPolicyService.ListPolicies(orgID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list approval policies", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, policies)
+}
+
+func (h *ApprovalPolicyHandler) Get(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	policyID, ok := utils.ParseUUID(c, "id", "approval policy ID")
+	if !ok {
+		return
+	}
+
+	policy, err := h.approvalPolicyService.GetPolicy(orgID, policyID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusNotFound, "approval policy not found", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, policy)
+}
+
+func (h *ApprovalPolicyHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateApprovalPolicyRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	policy, err := h.approvalPolicyService.CreatePolicy(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create approval policy", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, policy)
+}
+
+func (h *ApprovalPolicyHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	policyID, ok := utils.ParseUUID(c, "id", "approval policy ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateApprovalPolicyRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	policy, err := h.approvalPolicyService.UpdatePolicy(orgID, policyID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update approval policy", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, policy)
+}
+
+func (h *ApprovalPolicyHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	policyID, ok := utils.ParseUUID(c, "id", "approval policy ID")
+	if !ok {
+		return
+	}
+
+	if err := h.approvalPolicyService.DeletePolicy(orgID, policyID, userID); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to delete approval policy", err.Error())
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "approval policy deleted successfully")
+}
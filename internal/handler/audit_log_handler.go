@@ -1,9 +1,6 @@
 package handler
 
 import (
-	"net/http"
-	"strconv"
-
 	"saas-backend/internal/middleware"
 	"saas-backend/internal/repository"
 	"saas-backend/internal/utils"
@@ -22,24 +19,11 @@ func NewAuditLogHandler(auditRepo *repository.AuditLogRepository) *AuditLogHandl
 func (h *AuditLogHandler) List(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 
-	limit := 50
-	if raw := c.Query("limit"); raw != "" {
-		if v, err := strconv.Atoi(raw); err == nil {
-			limit = v
-		}
-	}
-	if limit < 1 {
-		limit = 1
-	}
-	if limit > 200 {
-		limit = 200
-	}
-
-	logs, err := h.auditRepo.List(orgID, limit)
+	logs, next, err := h.auditRepo.List(orgID, utils.ParsePageRequest(c))
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list audit logs", err.Error())
+		utils.HandlePermissionError(c, err, "failed to list audit logs")
 		return
 	}
 
-	utils.RespondWithSuccess(c, http.StatusOK, logs)
+	utils.RespondWithPage(c, logs, next)
 }
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentService *service.CommentService
+}
+
+func NewCommentHandler(commentService *service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+func (h *CommentHandler) ListTaskComments(c *gin.Context)  { h.list(c, "task") }
+func (h *CommentHandler) CreateTaskComment(c *gin.Context) { h.create(c, "task") }
+func (h *CommentHandler) UpdateTaskComment(c *gin.Context) { h.update(c, "task") }
+func (h *CommentHandler) DeleteTaskComment(c *gin.Context) { h.delete(c, "task") }
+
+func (h *CommentHandler) ListIssueComments(c *gin.Context)  { h.list(c, "issue") }
+func (h *CommentHandler) CreateIssueComment(c *gin.Context) { h.create(c, "issue") }
+func (h *CommentHandler) UpdateIssueComment(c *gin.Context) { h.update(c, "issue") }
+func (h *CommentHandler) DeleteIssueComment(c *gin.Context) { h.delete(c, "issue") }
+
+func (h *CommentHandler) list(c *gin.Context, entityType string) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	entityID, ok := utils.ParseUUID(c, "id", entityType+" ID")
+	if !ok {
+		return
+	}
+
+	comments, err := h.commentService.ListComments(orgID, entityType, entityID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list comments")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, comments)
+}
+
+func (h *CommentHandler) create(c *gin.Context, entityType string) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	entityID, ok := utils.ParseUUID(c, "id", entityType+" ID")
+	if !ok {
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(orgID, entityType, entityID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to create comment")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, comment)
+}
+
+func (h *CommentHandler) update(c *gin.Context, entityType string) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	entityID, ok := utils.ParseUUID(c, "id", entityType+" ID")
+	if !ok {
+		return
+	}
+	commentID, ok := utils.ParseUUID(c, "comment_id", "comment ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCommentRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(orgID, entityType, entityID, commentID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to update comment")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, comment)
+}
+
+func (h *CommentHandler) delete(c *gin.Context, entityType string) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	entityID, ok := utils.ParseUUID(c, "id", entityType+" ID")
+	if !ok {
+		return
+	}
+	commentID, ok := utils.ParseUUID(c, "comment_id", "comment ID")
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.DeleteComment(orgID, entityType, entityID, commentID, userID, role); err != nil {
+		utils.HandlePermissionError(c, err, "failed to delete comment")
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "comment deleted successfully")
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const customFieldQueryPrefix = "cf."
+
+type CustomFieldHandler struct {
+	customFieldService *service.CustomFieldService
+}
+
+func NewCustomFieldHandler(customFieldService *service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		customFieldService: customFieldService,
+	}
+}
+
+// parseCustomFieldFilter reads ?cf.<key>=value pairs from the query string.
+// Values are typed against the field definitions by the service.
+func parseCustomFieldFilter(c *gin.Context) map[string]interface{} {
+	var filter map[string]interface{}
+	for param, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(param, customFieldQueryPrefix) || len(values) == 0 {
+			continue
+		}
+		if filter == nil {
+			filter = map[string]interface{}{}
+		}
+		filter[strings.TrimPrefix(param, customFieldQueryPrefix)] = values[0]
+	}
+	return filter
+}
+
+func (h *CustomFieldHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+
+	fields, err := h.customFieldService.ListFields(orgID, c.Query("entity_type"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to list custom fields", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, fields)
+}
+
+func (h *CustomFieldHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateCustomFieldRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	field, err := h.customFieldService.CreateField(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create custom field", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, field)
+}
+
+func (h *CustomFieldHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	fieldID, ok := utils.ParseUUID(c, "id", "custom field ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCustomFieldRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	field, err := h.customFieldService.UpdateField(orgID, fieldID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update custom field", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, field)
+}
+
+func (h *CustomFieldHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	fieldID, ok := utils.ParseUUID(c, "id", "custom field ID")
+	if !ok {
+		return
+	}
+
+	if err := h.customFieldService.DeleteField(orgID, fieldID, userID); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to delete custom field", err.Error())
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "custom field deleted successfully")
+}
@@ -24,6 +24,7 @@ func NewDocumentHandler(documentService *service.DocumentService) *DocumentHandl
 func (h *DocumentHandler) Upload(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
 
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -48,9 +49,15 @@ func (h *DocumentHandler) Upload(c *gin.Context) {
 		taskID = &parsed
 	}
 
-	doc, err := h.documentService.Upload(c.Request.Context(), orgID, userID, taskID, file, title)
+	// Optional project_id association
+	var projectID *string
+	if projectIDStr := c.PostForm("project_id"); projectIDStr != "" {
+		projectID = &projectIDStr
+	}
+
+	doc, err := h.documentService.Upload(c.Request.Context(), orgID, userID, role, taskID, projectID, file, title)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "upload failed", err.Error())
+		utils.HandlePermissionError(c, err, "upload failed")
 		return
 	}
 
@@ -59,21 +66,20 @@ func (h *DocumentHandler) Upload(c *gin.Context) {
 
 func (h *DocumentHandler) List(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
-
-	limit := 50
-	if l := c.Query("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
-			limit = parsed
-		}
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
 	}
 
-	docs, err := h.documentService.List(c.Request.Context(), orgID, limit)
+	docs, next, err := h.documentService.List(c.Request.Context(), orgID, userID, role, projectID, utils.ParsePageRequest(c))
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list documents", err.Error())
+		utils.HandlePermissionError(c, err, "failed to list documents")
 		return
 	}
 
-	utils.RespondWithSuccess(c, http.StatusOK, docs)
+	utils.RespondWithPage(c, docs, next)
 }
 
 func (h *DocumentHandler) ListByTask(c *gin.Context) {
@@ -162,6 +168,24 @@ func (h *DocumentHandler) UpdateStatus(c *gin.Context) {
 	utils.RespondWithSuccess(c, http.StatusOK, gin.H{"message": "status updated"})
 }
 
+// Delete moves a document to the trash.
+func (h *DocumentHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	docID, ok := utils.ParseUUID(c, "id", "document id")
+	if !ok {
+		return
+	}
+
+	if err := h.documentService.Delete(c.Request.Context(), orgID, docID, userID); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "delete failed", err.Error())
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "document deleted successfully")
+}
+
 func (h *DocumentHandler) GenerateSummary(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 
@@ -24,15 +24,16 @@ func NewIssueHandler(issueService *service.IssueService) *IssueHandler {
 func (h *IssueHandler) CreateIssue(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
 
 	var req models.CreateIssueRequest
 	if !utils.BindJSON(c, &req) {
 		return
 	}
 
-	issue, err := h.issueService.CreateIssue(orgID, userID, &req)
+	issue, err := h.issueService.CreateIssue(orgID, userID, role, &req)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "failed to create issue", err.Error())
+		utils.HandlePermissionError(c, err, "failed to create issue")
 		return
 	}
 
@@ -54,23 +55,54 @@ func (h *IssueHandler) GetIssue(c *gin.Context) {
 		return
 	}
 
+	utils.SetETag(c, issue.Version)
 	utils.RespondWithSuccess(c, http.StatusOK, issue)
 }
 
+// ListHistory returns an issue's field-level change history, newest first.
+func (h *IssueHandler) ListHistory(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	entries, err := h.issueService.ListIssueHistoryForRole(orgID, issueID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list history")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, entries)
+}
+
 func (h *IssueHandler) ListIssues(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 	userID, _ := middleware.GetUserID(c)
 	role, _ := middleware.GetRole(c)
-	status := c.Query("status")
-	severity := c.Query("severity")
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
+	}
+	filter := models.IssueListFilter{
+		Status:       c.Query("status"),
+		Severity:     c.Query("severity"),
+		ProjectID:    projectID,
+		Labels:       parseLabelFilter(c),
+		CustomFields: parseCustomFieldFilter(c),
+		Query:        c.Query("q"),
+		WatcherID:    parseWatchingFilter(c),
+	}
 
-	issues, err := h.issueService.ListIssuesForRole(orgID, userID, role, status, severity)
+	issues, next, err := h.issueService.ListIssuesPageForRole(orgID, userID, role, filter, utils.ParsePageRequest(c))
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list issues", err.Error())
+		utils.HandleListError(c, err, "failed to list issues")
 		return
 	}
 
-	utils.RespondWithSuccess(c, http.StatusOK, issues)
+	utils.RespondWithPage(c, issues, next)
 }
 
 func (h *IssueHandler) UpdateIssue(c *gin.Context) {
@@ -86,13 +118,17 @@ func (h *IssueHandler) UpdateIssue(c *gin.Context) {
 	if !utils.BindJSON(c, &req) {
 		return
 	}
+	if req.IfMatch, ok = utils.ParseIfMatch(c); !ok {
+		return
+	}
 
 	issue, err := h.issueService.UpdateIssueForRole(orgID, issueID, userID, role, &req)
 	if err != nil {
-		utils.HandlePermissionError(c, err, "failed to update issue")
+		utils.HandleUpdateError(c, err, "failed to update issue")
 		return
 	}
 
+	utils.SetETag(c, issue.Version)
 	utils.RespondWithSuccess(c, http.StatusOK, issue)
 }
 
@@ -112,3 +148,23 @@ func (h *IssueHandler) DeleteIssue(c *gin.Context) {
 
 	utils.RespondWithMessage(c, http.StatusOK, "issue deleted successfully")
 }
+
+// BulkUpdate applies one action to many issues and reports per-issue results.
+func (h *IssueHandler) BulkUpdate(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+
+	var req models.BulkIssueRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.issueService.BulkUpdateIssuesForRole(orgID, userID, role, &req)
+	if err != nil {
+		utils.HandleListError(c, err, "failed to apply bulk update")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, result)
+}
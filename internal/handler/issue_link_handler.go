@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IssueLinkHandler struct {
+	issueLinkService *service.IssueLinkService
+}
+
+func NewIssueLinkHandler(issueLinkService *service.IssueLinkService) *IssueLinkHandler {
+	return &IssueLinkHandler{
+		issueLinkService: issueLinkService,
+	}
+}
+
+func (h *IssueLinkHandler) ListIssueLinks(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	links, err := h.issueLinkService.ListIssueLinksForRole(orgID, issueID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list links")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, links)
+}
+
+func (h *IssueLinkHandler) ListTaskLinks(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	links, err := h.issueLinkService.ListTaskLinksForRole(orgID, taskID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list links")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, links)
+}
+
+func (h *IssueLinkHandler) AddLink(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	var req models.CreateIssueLinkRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	links, err := h.issueLinkService.AddLinkForRole(orgID, issueID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to add link")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, links)
+}
+
+func (h *IssueLinkHandler) RemoveLink(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+	linkID, ok := utils.ParseUUID(c, "link_id", "link ID")
+	if !ok {
+		return
+	}
+
+	links, err := h.issueLinkService.RemoveLinkForRole(orgID, issueID, linkID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to remove link")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, links)
+}
+
+// CreateFixTask creates a task that fixes the issue. The body is optional.
+func (h *IssueLinkHandler) CreateFixTask(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	var req models.CreateFixTaskRequest
+	if c.Request.ContentLength > 0 && !utils.BindJSON(c, &req) {
+		return
+	}
+
+	task, err := h.issueLinkService.CreateFixTaskForRole(orgID, issueID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to create fix task")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, task)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LabelHandler struct {
+	labelService *service.LabelService
+}
+
+func NewLabelHandler(labelService *service.LabelService) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+	}
+}
+
+// parseLabelFilter reads ?labels=a,b and ?labels_match=all from the query string.
+func parseLabelFilter(c *gin.Context) models.LabelFilter {
+	filter := models.LabelFilter{MatchAll: c.Query("labels_match") == "all"}
+	if raw := c.Query("labels"); raw != "" {
+		filter.Names = strings.Split(raw, ",")
+	}
+	return filter
+}
+
+func (h *LabelHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+
+	labels, err := h.labelService.ListLabels(orgID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list labels", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, labels)
+}
+
+func (h *LabelHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateLabelRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	label, err := h.labelService.CreateLabel(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create label", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, label)
+}
+
+func (h *LabelHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	labelID, ok := utils.ParseUUID(c, "id", "label ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateLabelRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	label, err := h.labelService.UpdateLabel(orgID, labelID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update label", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, label)
+}
+
+func (h *LabelHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	labelID, ok := utils.ParseUUID(c, "id", "label ID")
+	if !ok {
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(orgID, labelID, userID); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to delete label", err.Error())
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "label deleted successfully")
+}
+
+func (h *LabelHandler) AttachTaskLabel(c *gin.Context)  { h.attach(c, "task") }
+func (h *LabelHandler) DetachTaskLabel(c *gin.Context)  { h.detach(c, "task") }
+func (h *LabelHandler) AttachIssueLabel(c *gin.Context) { h.attach(c, "issue") }
+func (h *LabelHandler) DetachIssueLabel(c *gin.Context) { h.detach(c, "issue") }
+
+func (h *LabelHandler) attach(c *gin.Context, entityType string) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	entityID, ok := utils.ParseUUID(c, "id", entityType+" ID")
+	if !ok {
+		return
+	}
+
+	var req models.AttachLabelRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	labels, err := h.labelService.AttachLabel(orgID, entityType, entityID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to attach label")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, labels)
+}
+
+func (h *LabelHandler) detach(c *gin.Context, entityType string) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	entityID, ok := utils.ParseUUID(c, "id", entityType+" ID")
+	if !ok {
+		return
+	}
+	labelID, ok := utils.ParseUUID(c, "label_id", "label ID")
+	if !ok {
+		return
+	}
+
+	labels, err := h.labelService.DetachLabel(orgID, entityType, entityID, labelID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to detach label")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, labels)
+}
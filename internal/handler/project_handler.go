@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projectService *service.ProjectService
+}
+
+func NewProjectHandler(projectService *service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+	}
+}
+
+func (h *ProjectHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+
+	projects, err := h.projectService.ListProjectsForRole(orgID, userID, role, c.Query("include_archived") == "true")
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list projects", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, projects)
+}
+
+func (h *ProjectHandler) Get(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	projectID, ok := utils.ParseUUID(c, "id", "project ID")
+	if !ok {
+		return
+	}
+
+	project, err := h.projectService.GetProjectForRole(orgID, projectID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "project not found")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, project)
+}
+
+func (h *ProjectHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateProjectRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	project, err := h.projectService.CreateProject(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create project", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, project)
+}
+
+func (h *ProjectHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	projectID, ok := utils.ParseUUID(c, "id", "project ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateProjectRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	project, err := h.projectService.UpdateProject(orgID, projectID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update project", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, project)
+}
+
+func (h *ProjectHandler) Archive(c *gin.Context)   { h.setArchived(c, true) }
+func (h *ProjectHandler) Unarchive(c *gin.Context) { h.setArchived(c, false) }
+
+func (h *ProjectHandler) setArchived(c *gin.Context, archived bool) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	projectID, ok := utils.ParseUUID(c, "id", "project ID")
+	if !ok {
+		return
+	}
+
+	project, err := h.projectService.SetArchived(orgID, projectID, userID, archived)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update project", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, project)
+}
+
+func (h *ProjectHandler) ListMembers(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	projectID, ok := utils.ParseUUID(c, "id", "project ID")
+	if !ok {
+		return
+	}
+
+	members, err := h.projectService.ListMembersForRole(orgID, projectID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list project members")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, members)
+}
+
+func (h *ProjectHandler) AddMember(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	projectID, ok := utils.ParseUUID(c, "id", "project ID")
+	if !ok {
+		return
+	}
+
+	var req models.AddProjectMemberRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	members, err := h.projectService.AddMember(orgID, projectID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to add project member", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, members)
+}
+
+func (h *ProjectHandler) RemoveMember(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	projectID, ok := utils.ParseUUID(c, "id", "project ID")
+	if !ok {
+		return
+	}
+	memberID, ok := utils.ParseUUID(c, "user_id", "user ID")
+	if !ok {
+		return
+	}
+
+	members, err := h.projectService.RemoveMember(orgID, projectID, memberID, userID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to remove project member", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, members)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringTaskHandler struct {
+	recurringTaskService *service.RecurringTaskService
+}
+
+func NewRecurringTaskHandler(recurringTaskService *service.RecurringTaskService) *RecurringTaskHandler {
+	return &RecurringTaskHandler{
+		recurringTaskService: recurringTaskService,
+	}
+}
+
+func (h *RecurringTaskHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateRecurringTaskRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	rt, err := h.recurringTaskService.Create(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create recurring task", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, rt)
+}
+
+func (h *RecurringTaskHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+
+	items, err := h.recurringTaskService.List(orgID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list recurring tasks", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, items)
+}
+
+func (h *RecurringTaskHandler) Get(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	id, ok := utils.ParseUUID(c, "id", "recurring task ID")
+	if !ok {
+		return
+	}
+
+	rt, err := h.recurringTaskService.Get(orgID, id)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusNotFound, "recurring task not found", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, rt)
+}
+
+func (h *RecurringTaskHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	id, ok := utils.ParseUUID(c, "id", "recurring task ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateRecurringTaskRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	rt, err := h.recurringTaskService.Update(orgID, id, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update recurring task", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, rt)
+}
+
+func (h *RecurringTaskHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	id, ok := utils.ParseUUID(c, "id", "recurring task ID")
+	if !ok {
+		return
+	}
+
+	if err := h.recurringTaskService.Delete(orgID, id, userID); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to delete recurring task", err.Error())
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "recurring task deleted successfully")
+}
+
+// ListTasks returns the tasks generated by a recurring task.
+func (h *RecurringTaskHandler) ListTasks(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	id, ok := utils.ParseUUID(c, "id", "recurring task ID")
+	if !ok {
+		return
+	}
+
+	tasks, err := h.recurringTaskService.ListGeneratedTasks(orgID, id)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to list generated tasks", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, tasks)
+}
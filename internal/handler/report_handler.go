@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
 	"saas-backend/internal/service"
 	"saas-backend/internal/utils"
 
@@ -21,8 +22,12 @@ func NewReportHandler(reportService *service.ReportService) *ReportHandler {
 func (h *ReportHandler) WeeklySummary(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 	userID, _ := middleware.GetUserID(c)
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
+	}
 
-	summary, err := h.reportService.GenerateWeeklySummary(orgID, userID)
+	summary, err := h.reportService.GenerateWeeklySummary(orgID, userID, projectID)
 	if err != nil {
 		utils.RespondWithError(c, http.StatusServiceUnavailable, "AI summary unavailable", err.Error())
 		return
@@ -30,3 +35,29 @@ func (h *ReportHandler) WeeklySummary(c *gin.Context) {
 
 	utils.RespondWithSuccess(c, http.StatusOK, gin.H{"summary": summary})
 }
+
+func (h *ReportHandler) Timesheet(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, ok := utils.ParseOptionalUUIDQuery(c, "user_id", "user_id")
+	if !ok {
+		return
+	}
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
+	}
+	filter := models.TimesheetFilter{
+		From:      c.Query("from"),
+		To:        c.Query("to"),
+		UserID:    userID,
+		ProjectID: projectID,
+	}
+
+	sheet, err := h.reportService.GenerateTimesheet(orgID, filter)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to generate timesheet", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sheet)
+}
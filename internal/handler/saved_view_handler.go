@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedViewHandler struct {
+	viewService *service.SavedViewService
+}
+
+func NewSavedViewHandler(viewService *service.SavedViewService) *SavedViewHandler {
+	return &SavedViewHandler{viewService: viewService}
+}
+
+func (h *SavedViewHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+
+	views, err := h.viewService.ListViewsForRole(orgID, userID, role, c.Query("entity_type"))
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list views")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, views)
+}
+
+// Default returns the view pinned for the caller's role.
+func (h *SavedViewHandler) Default(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	role, _ := middleware.GetRole(c)
+
+	view, err := h.viewService.GetDefaultView(orgID, role, c.Query("entity_type"))
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to get default view")
+		return
+	}
+	if view == nil {
+		utils.RespondWithError(c, http.StatusNotFound, "no default view", "no view is pinned for your role")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, view)
+}
+
+func (h *SavedViewHandler) Get(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	viewID, ok := utils.ParseUUID(c, "id", "view ID")
+	if !ok {
+		return
+	}
+
+	view, err := h.viewService.GetViewForRole(orgID, viewID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "view not found")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, view)
+}
+
+func (h *SavedViewHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateSavedViewRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	view, err := h.viewService.CreateView(orgID, userID, &req)
+	if err != nil {
+		utils.HandleListError(c, err, "failed to create view")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, view)
+}
+
+func (h *SavedViewHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	viewID, ok := utils.ParseUUID(c, "id", "view ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateSavedViewRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	view, err := h.viewService.UpdateViewForRole(orgID, viewID, userID, role, &req)
+	if err != nil {
+		utils.HandleListError(c, err, "failed to update view")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, view)
+}
+
+func (h *SavedViewHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	viewID, ok := utils.ParseUUID(c, "id", "view ID")
+	if !ok {
+		return
+	}
+
+	if err := h.viewService.DeleteViewForRole(orgID, viewID, userID, role); err != nil {
+		utils.HandlePermissionError(c, err, "failed to delete view")
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "view deleted successfully")
+}
+
+// Run returns one page of the view's results. It accepts cursor and limit.
+func (h *SavedViewHandler) Run(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	viewID, ok := utils.ParseUUID(c, "id", "view ID")
+	if !ok {
+		return
+	}
+
+	result, err := h.viewService.RunViewForRole(orgID, viewID, userID, role, utils.ParsePageRequest(c))
+	if err != nil {
+		utils.HandleListError(c, err, "failed to run view")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+func (h *SavedViewHandler) Pin(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	viewID, ok := utils.ParseUUID(c, "id", "view ID")
+	if !ok {
+		return
+	}
+
+	var req models.PinSavedViewRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	view, err := h.viewService.PinView(orgID, viewID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to pin view")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, view)
+}
+
+func (h *SavedViewHandler) Unpin(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	viewID, ok := utils.ParseUUID(c, "id", "view ID")
+	if !ok {
+		return
+	}
+
+	view, err := h.viewService.UnpinView(orgID, viewID, userID, role, c.Param("role"))
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to unpin view")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, view)
+}
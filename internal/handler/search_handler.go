@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchService *service.SearchService
+}
+
+func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+func (h *SearchHandler) Search(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
+	}
+
+	filter := models.SearchFilter{
+		Query:     c.Query("q"),
+		Type:      c.Query("type"),
+		ProjectID: projectID,
+	}
+
+	result, err := h.searchService.SearchForRole(orgID, userID, role, filter, utils.ParsePageRequest(c))
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to search")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, result)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SprintHandler struct {
+	sprintService *service.SprintService
+}
+
+func NewSprintHandler(sprintService *service.SprintService) *SprintHandler {
+	return &SprintHandler{
+		sprintService: sprintService,
+	}
+}
+
+func (h *SprintHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
+	}
+	filter := models.SprintListFilter{
+		ProjectID: projectID,
+		State:     c.Query("state"),
+	}
+
+	sprints, err := h.sprintService.ListSprintsForRole(orgID, userID, role, filter)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list sprints")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sprints)
+}
+
+func (h *SprintHandler) Get(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	sprintID, ok := utils.ParseUUID(c, "id", "sprint ID")
+	if !ok {
+		return
+	}
+
+	sprint, err := h.sprintService.GetSprintForRole(orgID, sprintID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "sprint not found")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sprint)
+}
+
+func (h *SprintHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateSprintRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	sprint, err := h.sprintService.CreateSprint(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create sprint", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, sprint)
+}
+
+func (h *SprintHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	sprintID, ok := utils.ParseUUID(c, "id", "sprint ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateSprintRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	sprint, err := h.sprintService.UpdateSprint(orgID, sprintID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update sprint", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sprint)
+}
+
+func (h *SprintHandler) Start(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	sprintID, ok := utils.ParseUUID(c, "id", "sprint ID")
+	if !ok {
+		return
+	}
+
+	sprint, err := h.sprintService.StartSprint(orgID, sprintID, userID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to start sprint", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sprint)
+}
+
+func (h *SprintHandler) Close(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	sprintID, ok := utils.ParseUUID(c, "id", "sprint ID")
+	if !ok {
+		return
+	}
+
+	var req models.CloseSprintRequest
+	if c.Request.ContentLength > 0 && !utils.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.sprintService.CloseSprint(orgID, sprintID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to close sprint", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+func (h *SprintHandler) ListTasks(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	sprintID, ok := utils.ParseUUID(c, "id", "sprint ID")
+	if !ok {
+		return
+	}
+
+	tasks, err := h.sprintService.ListSprintTasksForRole(orgID, sprintID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list sprint tasks")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, tasks)
+}
+
+func (h *SprintHandler) AddTasks(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	sprintID, ok := utils.ParseUUID(c, "id", "sprint ID")
+	if !ok {
+		return
+	}
+
+	var req models.SprintTasksRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	sprint, err := h.sprintService.AddTasks(orgID, sprintID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to add tasks to sprint", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sprint)
+}
+
+func (h *SprintHandler) RemoveTask(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	sprintID, ok := utils.ParseUUID(c, "id", "sprint ID")
+	if !ok {
+		return
+	}
+	taskID, ok := utils.ParseUUID(c, "task_id", "task ID")
+	if !ok {
+		return
+	}
+
+	sprint, err := h.sprintService.RemoveTask(orgID, sprintID, taskID, userID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to remove task from sprint", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sprint)
+}
+
+func (h *SprintHandler) Velocity(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	report, err := h.sprintService.Velocity(orgID, userID, role, projectID, limit)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to compute velocity")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, report)
+}
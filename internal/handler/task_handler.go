@@ -53,25 +53,149 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskService.GetTaskForRole(orgID, taskID, userID, role)
+	task, err := h.taskService.GetTaskDetailForRole(orgID, taskID, userID, role)
 	if err != nil {
 		utils.HandlePermissionError(c, err, "task not found")
 		return
 	}
 
+	utils.SetETag(c, task.Version)
 	utils.RespondWithSuccess(c, http.StatusOK, task)
 }
 
+// parseTaskListFilter reads the task list query parameters shared by the
+// list and board endpoints. It responds with 400 and returns false on bad input.
+func parseTaskListFilter(c *gin.Context) (models.TaskListFilter, bool) {
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return models.TaskListFilter{}, false
+	}
+	sprintID, ok := utils.ParseOptionalUUIDQuery(c, "sprint_id", "sprint_id")
+	if !ok {
+		return models.TaskListFilter{}, false
+	}
+	return models.TaskListFilter{
+		Status:       c.Query("status"),
+		Priority:     c.Query("priority"),
+		TopLevelOnly: c.Query("top_level") == "true",
+		ProjectID:    projectID,
+		SprintID:     sprintID,
+		Backlog:      c.Query("backlog") == "true",
+		Labels:       parseLabelFilter(c),
+		CustomFields: parseCustomFieldFilter(c),
+		Query:        c.Query("q"),
+		WatcherID:    parseWatchingFilter(c),
+	}, true
+}
+
 func (h *TaskHandler) ListTasks(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 	userID, _ := middleware.GetUserID(c)
 	role, _ := middleware.GetRole(c)
-	status := c.Query("status")
-	priority := c.Query("priority")
+	filter, ok := parseTaskListFilter(c)
+	if !ok {
+		return
+	}
+
+	// A tree needs every task to attach children to their parents, so it is
+	// returned as a single page.
+	if c.Query("tree") == "true" {
+		tasks, err := h.taskService.ListTasksForRole(orgID, userID, role, filter)
+		if err != nil {
+			utils.HandleListError(c, err, "failed to list tasks")
+			return
+		}
+		utils.RespondWithPage(c, service.BuildTaskTree(tasks), "")
+		return
+	}
 
-	tasks, err := h.taskService.ListTasksForRole(orgID, userID, role, status, priority)
+	tasks, next, err := h.taskService.ListTasksPageForRole(orgID, userID, role, filter, utils.ParsePageRequest(c))
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list tasks", err.Error())
+		utils.HandleListError(c, err, "failed to list tasks")
+		return
+	}
+
+	utils.RespondWithPage(c, tasks, next)
+}
+
+// Board returns tasks grouped into status columns in board order. It accepts
+// the same filters as ListTasks.
+func (h *TaskHandler) Board(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	filter, ok := parseTaskListFilter(c)
+	if !ok {
+		return
+	}
+
+	columns, err := h.taskService.GetBoardForRole(orgID, userID, role, filter)
+	if err != nil {
+		utils.HandleListError(c, err, "failed to load board")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, columns)
+}
+
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	var req models.MoveTaskRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	task, err := h.taskService.MoveTaskForRole(orgID, taskID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to move task")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) CreateSubtask(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	parentID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	var req models.CreateTaskRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	task, err := h.taskService.CreateSubtaskForRole(orgID, parentID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to create subtask")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, task)
+}
+
+func (h *TaskHandler) ListSubtasks(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	parentID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	tasks, err := h.taskService.ListSubtasksForRole(orgID, parentID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list subtasks")
 		return
 	}
 
@@ -104,13 +228,17 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	if !utils.BindJSON(c, &req) {
 		return
 	}
+	if req.IfMatch, ok = utils.ParseIfMatch(c); !ok {
+		return
+	}
 
 	task, err := h.taskService.UpdateTaskForRole(orgID, taskID, userID, role, &req)
 	if err != nil {
-		utils.HandlePermissionError(c, err, "failed to update task")
+		utils.HandleUpdateError(c, err, "failed to update task")
 		return
 	}
 
+	utils.SetETag(c, task.Version)
 	utils.RespondWithSuccess(c, http.StatusOK, task)
 }
 
@@ -131,14 +259,102 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	utils.RespondWithMessage(c, http.StatusOK, "task deleted successfully")
 }
 
+// BulkUpdate applies one action to many tasks and reports per-task results.
+func (h *TaskHandler) BulkUpdate(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+
+	var req models.BulkTaskRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.taskService.BulkUpdateTasksForRole(orgID, userID, role, &req)
+	if err != nil {
+		utils.HandleListError(c, err, "failed to apply bulk update")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+func (h *TaskHandler) ListDependencies(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	deps, err := h.taskService.ListDependenciesForRole(orgID, taskID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list dependencies")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, deps)
+}
+
+func (h *TaskHandler) AddDependency(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	var req models.AddTaskDependencyRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	deps, err := h.taskService.AddDependencyForRole(orgID, taskID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to add dependency")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, deps)
+}
+
+func (h *TaskHandler) RemoveDependency(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+	dependsOnID, ok := utils.ParseUUID(c, "depends_on_id", "blocking task ID")
+	if !ok {
+		return
+	}
+
+	deps, err := h.taskService.RemoveDependencyForRole(orgID, taskID, dependsOnID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to remove dependency")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, deps)
+}
+
 // MarkDone - Member marks task as done
 func (h *TaskHandler) MarkDone(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
 	taskID, ok := utils.ParseUUID(c, "id", "task ID")
 	if !ok {
 		return
 	}
+	notes, ok := parseReviewNotes(c)
+	if !ok {
+		return
+	}
 
 	// Check if there's a file upload
 	file, err := c.FormFile("document")
@@ -184,9 +400,9 @@ func (h *TaskHandler) MarkDone(c *gin.Context) {
 		}
 
 		// Mark done with document
-		task, err := h.taskService.MarkDoneWithDocument(orgID, taskID, userID, file.Filename, filepath, content)
+		task, err := h.taskService.MarkDoneWithDocument(orgID, taskID, userID, role, notes, file.Filename, filepath, content)
 		if err != nil {
-			utils.RespondWithError(c, http.StatusBadRequest, "failed to mark task as done", err.Error())
+			utils.HandlePermissionError(c, err, "failed to mark task as done")
 			return
 		}
 
@@ -195,7 +411,7 @@ func (h *TaskHandler) MarkDone(c *gin.Context) {
 	}
 
 	// No file upload - regular mark done
-	task, err := h.taskService.MarkDone(orgID, taskID, userID)
+	task, err := h.taskService.MarkDone(orgID, taskID, userID, role, notes)
 	if err != nil {
 		status := http.StatusBadRequest
 		errMsg := "failed to mark task as done"
@@ -214,6 +430,57 @@ func (h *TaskHandler) MarkDone(c *gin.Context) {
 	utils.RespondWithSuccess(c, http.StatusOK, task)
 }
 
+// parseReviewNotes reads the optional notes for a review step, from a JSON
+// body or, for uploads, a form field.
+func parseReviewNotes(c *gin.Context) (string, bool) {
+	if c.ContentType() == "application/json" {
+		var req models.ReviewNotesRequest
+		if c.Request.ContentLength > 0 && !utils.BindJSON(c, &req) {
+			return "", false
+		}
+		return req.Notes, true
+	}
+	return c.PostForm("notes"), true
+}
+
+// ListReviews returns a task's review history.
+func (h *TaskHandler) ListReviews(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	reviews, err := h.taskService.ListReviewsForRole(orgID, taskID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list reviews")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, reviews)
+}
+
+// ListHistory returns a task's field-level change history, newest first.
+func (h *TaskHandler) ListHistory(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	entries, err := h.taskService.ListTaskHistoryForRole(orgID, taskID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list history")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, entries)
+}
+
 // VerifyTask - Manager verifies a completed task
 func (h *TaskHandler) VerifyTask(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
@@ -223,23 +490,22 @@ func (h *TaskHandler) VerifyTask(c *gin.Context) {
 	if !ok {
 		return
 	}
-
-	// Only managers and admins can verify
-	if role != "manager" && role != "admin" {
-		utils.RespondWithError(c, http.StatusForbidden, "insufficient permissions", "only managers and admins can verify tasks")
+	notes, ok := parseReviewNotes(c)
+	if !ok {
 		return
 	}
 
-	task, err := h.taskService.VerifyTask(orgID, taskID, userID)
+	task, err := h.taskService.VerifyTask(orgID, taskID, userID, role, notes)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "failed to verify task", err.Error())
+		utils.HandlePermissionError(c, err, "failed to verify task")
 		return
 	}
 
 	utils.RespondWithSuccess(c, http.StatusOK, task)
 }
 
-// ApproveTask - Admin approves a verified task
+// ApproveTask - Admin approves a verified task. Under an approval policy this
+// records one approval, and the task is approved once the policy is met.
 func (h *TaskHandler) ApproveTask(c *gin.Context) {
 	orgID, _ := middleware.GetOrgID(c)
 	userID, _ := middleware.GetUserID(c)
@@ -248,16 +514,14 @@ func (h *TaskHandler) ApproveTask(c *gin.Context) {
 	if !ok {
 		return
 	}
-
-	// Only admins can approve
-	if role != "admin" {
-		utils.RespondWithError(c, http.StatusForbidden, "insufficient permissions", "only admins can approve tasks")
+	notes, ok := parseReviewNotes(c)
+	if !ok {
 		return
 	}
 
-	task, err := h.taskService.ApproveTask(orgID, taskID, userID)
+	task, err := h.taskService.ApproveTask(orgID, taskID, userID, role, notes)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "failed to approve task", err.Error())
+		utils.HandleUpdateError(c, err, "failed to approve task")
 		return
 	}
 
@@ -274,15 +538,14 @@ func (h *TaskHandler) RejectTask(c *gin.Context) {
 		return
 	}
 
-	// Only managers and admins can reject
-	if role != "manager" && role != "admin" {
-		utils.RespondWithError(c, http.StatusForbidden, "insufficient permissions", "only managers and admins can reject tasks")
+	var req models.RejectTaskRequest
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
-	task, err := h.taskService.RejectTask(orgID, taskID, userID)
+	task, err := h.taskService.RejectTask(orgID, taskID, userID, role, req.Reason)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "failed to reject task", err.Error())
+		utils.HandlePermissionError(c, err, "failed to reject task")
 		return
 	}
 
@@ -299,7 +562,12 @@ func (h *TaskHandler) AdminAIReport(c *gin.Context) {
 		return
 	}
 
-	report, err := h.taskService.GenerateAdminTaskReport(orgID)
+	projectID, ok := utils.ParseOptionalUUIDQuery(c, "project_id", "project_id")
+	if !ok {
+		return
+	}
+
+	report, err := h.taskService.GenerateAdminTaskReport(orgID, projectID)
 	if err != nil {
 		utils.RespondWithError(c, http.StatusServiceUnavailable, "AI report unavailable", err.Error())
 		return
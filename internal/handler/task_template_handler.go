@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskTemplateHandler struct {
+	taskTemplateService *service.TaskTemplateService
+}
+
+func NewTaskTemplateHandler(taskTemplateService *service.TaskTemplateService) *TaskTemplateHandler {
+	return &TaskTemplateHandler{
+		taskTemplateService: taskTemplateService,
+	}
+}
+
+func (h *TaskTemplateHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.CreateTaskTemplateRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	tmpl, err := h.taskTemplateService.Create(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create task template", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, tmpl)
+}
+
+func (h *TaskTemplateHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+
+	items, err := h.taskTemplateService.List(orgID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "failed to list task templates", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, items)
+}
+
+func (h *TaskTemplateHandler) Get(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	id, ok := utils.ParseUUID(c, "id", "task template ID")
+	if !ok {
+		return
+	}
+
+	tmpl, err := h.taskTemplateService.Get(orgID, id)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusNotFound, "task template not found", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, tmpl)
+}
+
+func (h *TaskTemplateHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	id, ok := utils.ParseUUID(c, "id", "task template ID")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateTaskTemplateRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	tmpl, err := h.taskTemplateService.Update(orgID, id, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update task template", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, tmpl)
+}
+
+func (h *TaskTemplateHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	id, ok := utils.ParseUUID(c, "id", "task template ID")
+	if !ok {
+		return
+	}
+
+	if err := h.taskTemplateService.Delete(orgID, id, userID); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to delete task template", err.Error())
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "task template deleted successfully")
+}
+
+// Instantiate creates a task, and any subtasks, from a template. The body is
+// optional for templates without placeholders.
+func (h *TaskTemplateHandler) Instantiate(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	id, ok := utils.ParseUUID(c, "id", "task template ID")
+	if !ok {
+		return
+	}
+
+	var req models.InstantiateTemplateRequest
+	if c.Request.ContentLength > 0 && !utils.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.taskTemplateService.Instantiate(orgID, id, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to create task from template", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, result)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	trashService *service.TrashService
+}
+
+func NewTrashHandler(trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// List returns deleted tasks, issues and documents, optionally filtered by
+// ?type=task|issue|document.
+func (h *TrashHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	role, _ := middleware.GetRole(c)
+
+	items, err := h.trashService.ListForRole(orgID, role, c.Query("type"))
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list trash")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, items)
+}
+
+func (h *TrashHandler) RestoreTask(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	task, err := h.trashService.RestoreTaskForRole(orgID, taskID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to restore task")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, task)
+}
+
+func (h *TrashHandler) RestoreIssue(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	issue, err := h.trashService.RestoreIssueForRole(orgID, issueID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to restore issue")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, issue)
+}
+
+func (h *TrashHandler) RestoreDocument(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	docID, ok := utils.ParseUUID(c, "id", "document id")
+	if !ok {
+		return
+	}
+
+	doc, err := h.trashService.RestoreDocumentForRole(c.Request.Context(), orgID, docID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to restore document")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, doc)
+}
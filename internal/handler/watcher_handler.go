@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WatcherHandler struct {
+	taskService  *service.TaskService
+	issueService *service.IssueService
+}
+
+func NewWatcherHandler(taskService *service.TaskService, issueService *service.IssueService) *WatcherHandler {
+	return &WatcherHandler{
+		taskService:  taskService,
+		issueService: issueService,
+	}
+}
+
+// parseWatchingFilter reads watching=true, which limits a listing to entities
+// the current user is watching.
+func parseWatchingFilter(c *gin.Context) *uuid.UUID {
+	if c.Query("watching") != "true" {
+		return nil
+	}
+	userID, _ := middleware.GetUserID(c)
+	return &userID
+}
+
+func (h *WatcherHandler) WatchTask(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	if err := h.taskService.WatchTaskForRole(orgID, taskID, userID, role); err != nil {
+		utils.HandlePermissionError(c, err, "failed to watch task")
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "watching task")
+}
+
+func (h *WatcherHandler) UnwatchTask(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	if err := h.taskService.UnwatchTaskForRole(orgID, taskID, userID, role); err != nil {
+		utils.HandlePermissionError(c, err, "failed to unwatch task")
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "stopped watching task")
+}
+
+func (h *WatcherHandler) ListTaskWatchers(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	watchers, err := h.taskService.ListTaskWatchersForRole(orgID, taskID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list watchers")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, watchers)
+}
+
+func (h *WatcherHandler) WatchIssue(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	if err := h.issueService.WatchIssueForRole(orgID, issueID, userID, role); err != nil {
+		utils.HandlePermissionError(c, err, "failed to watch issue")
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "watching issue")
+}
+
+func (h *WatcherHandler) UnwatchIssue(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	if err := h.issueService.UnwatchIssueForRole(orgID, issueID, userID, role); err != nil {
+		utils.HandlePermissionError(c, err, "failed to unwatch issue")
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "stopped watching issue")
+}
+
+func (h *WatcherHandler) ListIssueWatchers(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	issueID, ok := utils.ParseUUID(c, "id", "issue ID")
+	if !ok {
+		return
+	}
+
+	watchers, err := h.issueService.ListIssueWatchersForRole(orgID, issueID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list watchers")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, watchers)
+}
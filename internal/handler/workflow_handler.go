@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowHandler struct {
+	workflowService *service.WorkflowService
+}
+
+func NewWorkflowHandler(workflowService *service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+func (h *WorkflowHandler) Get(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+
+	wf, err := h.workflowService.GetWorkflow(orgID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "failed to get workflow", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, wf)
+}
+
+func (h *WorkflowHandler) Update(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.UpdateWorkflowRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	wf, err := h.workflowService.UpdateWorkflow(orgID, userID, &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "failed to update workflow", err.Error())
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, wf)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+
+	"saas-backend/internal/middleware"
+	"saas-backend/internal/models"
+	"saas-backend/internal/service"
+	"saas-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorklogHandler struct {
+	worklogService *service.WorklogService
+}
+
+func NewWorklogHandler(worklogService *service.WorklogService) *WorklogHandler {
+	return &WorklogHandler{
+		worklogService: worklogService,
+	}
+}
+
+func (h *WorklogHandler) List(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	worklogs, err := h.worklogService.ListForTask(orgID, taskID, userID, role)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to list worklogs")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, worklogs)
+}
+
+func (h *WorklogHandler) Create(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	var req models.CreateWorklogRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	worklog, err := h.worklogService.LogTime(orgID, taskID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to log time")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, worklog)
+}
+
+func (h *WorklogHandler) StartTimer(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	var req models.StartTimerRequest
+	if c.Request.ContentLength > 0 && !utils.BindJSON(c, &req) {
+		return
+	}
+
+	worklog, err := h.worklogService.StartTimer(orgID, taskID, userID, role, &req)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to start timer")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusCreated, worklog)
+}
+
+func (h *WorklogHandler) StopTimer(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+
+	worklog, err := h.worklogService.StopTimer(orgID, taskID, userID)
+	if err != nil {
+		utils.HandlePermissionError(c, err, "failed to stop timer")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, worklog)
+}
+
+func (h *WorklogHandler) Delete(c *gin.Context) {
+	orgID, _ := middleware.GetOrgID(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	taskID, ok := utils.ParseUUID(c, "id", "task ID")
+	if !ok {
+		return
+	}
+	worklogID, ok := utils.ParseUUID(c, "worklog_id", "worklog ID")
+	if !ok {
+		return
+	}
+
+	if err := h.worklogService.DeleteWorklog(orgID, taskID, worklogID, userID, role); err != nil {
+		utils.HandlePermissionError(c, err, "failed to delete worklog")
+		return
+	}
+
+	utils.RespondWithMessage(c, http.StatusOK, "worklog deleted successfully")
+}
@@ -1,5 +1,7 @@
 package models
 
+import "github.com/google/uuid"
+
 // Request/Response DTOs
 
 type RegisterRequest struct {
@@ -31,6 +33,181 @@ type CreateTaskRequest struct {
 	Priority    string  `json:"priority"`
 	AssignedTo  *string `json:"assigned_to"`
 	DueDate     *string `json:"due_date"`
+	ParentID    *string `json:"parent_id"`
+	ProjectID   *string `json:"project_id"`
+	// AssigneeIDs assigns several users at once; the first is the primary
+	// assignee unless AssignedTo names one.
+	AssigneeIDs []string `json:"assignee_ids"`
+	// ReviewerIDs and ApproverIDs designate who may verify and approve.
+	ReviewerIDs []string `json:"reviewer_ids"`
+	ApproverIDs []string `json:"approver_ids"`
+	// EstimatePoints is the task's story point estimate.
+	EstimatePoints *float64 `json:"estimate_points" binding:"omitempty,min=0"`
+	// CustomFields holds values keyed by custom field key.
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	// LabelIDs attaches existing org labels on creation.
+	LabelIDs []string `json:"label_ids"`
+
+	// RecurringTaskID links a task generated by the recurring task scheduler.
+	RecurringTaskID *uuid.UUID `json:"-"`
+	// CreatedFrom describes what generated the task, such as a template, for
+	// its creation history entry.
+	CreatedFrom string `json:"-"`
+}
+
+// TaskListFilter narrows TaskRepository.List. Zero values mean "no filter".
+type TaskListFilter struct {
+	Status          string
+	Priority        string
+	AssigneeID      *uuid.UUID
+	ParentID        *uuid.UUID
+	TopLevelOnly    bool
+	RecurringTaskID *uuid.UUID
+	ProjectID       *uuid.UUID
+	SprintID        *uuid.UUID
+	// Backlog selects tasks that are not in any sprint.
+	Backlog bool
+	// SortByRank orders results by board rank instead of newest first.
+	SortByRank bool
+	Labels     LabelFilter
+	// CustomFields matches entities whose values contain these, keyed by field key.
+	CustomFields map[string]interface{}
+	// Query is a filter-language expression (see package query). Viewer
+	// resolves "me" in it.
+	Query  string
+	Viewer *uuid.UUID
+	// WatcherID selects tasks the user is watching.
+	WatcherID *uuid.UUID
+	// VisibleTo restricts results to tasks the user is assigned to or
+	// designated to review or approve.
+	VisibleTo *uuid.UUID
+}
+
+// IssueListFilter narrows IssueRepository.List. Zero values mean "no filter".
+type IssueListFilter struct {
+	Status   string
+	Severity string
+	// VisibleTo restricts results to issues the user reported or is assigned to.
+	VisibleTo *uuid.UUID
+	ProjectID *uuid.UUID
+	Labels    LabelFilter
+	// CustomFields matches entities whose values contain these, keyed by field key.
+	CustomFields map[string]interface{}
+	// Query is a filter-language expression (see package query). Viewer
+	// resolves "me" in it.
+	Query  string
+	Viewer *uuid.UUID
+	// WatcherID selects issues the user is watching.
+	WatcherID *uuid.UUID
+}
+
+// LabelFilter matches entities carrying any (or, with MatchAll, every) of the
+// named labels. Names are compared case-insensitively.
+type LabelFilter struct {
+	Names    []string
+	MatchAll bool
+}
+
+// SearchFilter drives SearchRepository.Search. Type is empty for all types.
+type SearchFilter struct {
+	Query     string
+	Type      string
+	ProjectID *uuid.UUID
+	// VisibleTo applies member visibility: assigned tasks, reported or
+	// assigned issues, and documents uploaded by the user or attached to
+	// their tasks.
+	VisibleTo *uuid.UUID
+}
+
+type CreateLabelRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color"`
+}
+
+type UpdateLabelRequest struct {
+	Name  *string `json:"name" binding:"omitempty,max=50"`
+	Color *string `json:"color"`
+}
+
+type AttachLabelRequest struct {
+	LabelID string `json:"label_id" binding:"required"`
+}
+
+type CreateRecurringTaskRequest struct {
+	Title            string  `json:"title" binding:"required"`
+	Description      string  `json:"description"`
+	Priority         string  `json:"priority"`
+	AssignedTo       *string `json:"assigned_to"`
+	RRule            string  `json:"rrule" binding:"required"`
+	DTStart          *string `json:"dtstart"`
+	Timezone         string  `json:"timezone"`
+	DueOffsetMinutes *int    `json:"due_offset_minutes"`
+	ProjectID        *string `json:"project_id"`
+}
+
+type UpdateRecurringTaskRequest struct {
+	Title            *string `json:"title"`
+	Description      *string `json:"description"`
+	Priority         *string `json:"priority"`
+	AssignedTo       *string `json:"assigned_to"`
+	RRule            *string `json:"rrule"`
+	DTStart          *string `json:"dtstart"`
+	Timezone         *string `json:"timezone"`
+	DueOffsetMinutes *int    `json:"due_offset_minutes"`
+	IsPaused         *bool   `json:"is_paused"`
+	ProjectID        *string `json:"project_id"`
+}
+
+type TemplateSubtaskRequest struct {
+	Title            string  `json:"title"`
+	Description      string  `json:"description"`
+	Priority         string  `json:"priority"`
+	AssignedTo       *string `json:"assigned_to"`
+	DueOffsetMinutes *int    `json:"due_offset_minutes"`
+}
+
+type CreateTaskTemplateRequest struct {
+	Name             string                   `json:"name" binding:"required,max=100"`
+	Title            string                   `json:"title" binding:"required"`
+	Description      string                   `json:"description"`
+	Priority         string                   `json:"priority"`
+	AssignedTo       *string                  `json:"assigned_to"`
+	DueOffsetMinutes *int                     `json:"due_offset_minutes"`
+	LabelIDs         []string                 `json:"label_ids"`
+	Subtasks         []TemplateSubtaskRequest `json:"subtasks"`
+	ProjectID        *string                  `json:"project_id"`
+}
+
+// UpdateTaskTemplateRequest replaces the given fields. LabelIDs and Subtasks
+// replace the whole list when present.
+type UpdateTaskTemplateRequest struct {
+	Name             *string                   `json:"name" binding:"omitempty,max=100"`
+	Title            *string                   `json:"title"`
+	Description      *string                   `json:"description"`
+	Priority         *string                   `json:"priority"`
+	AssignedTo       *string                   `json:"assigned_to"`
+	DueOffsetMinutes *int                      `json:"due_offset_minutes"`
+	LabelIDs         *[]string                 `json:"label_ids"`
+	Subtasks         *[]TemplateSubtaskRequest `json:"subtasks"`
+	ProjectID        *string                   `json:"project_id"`
+}
+
+// InstantiateTemplateRequest supplies placeholder values and optional
+// overrides for the template's assignee and project.
+type InstantiateTemplateRequest struct {
+	Variables  map[string]string `json:"variables"`
+	AssignedTo *string           `json:"assigned_to"`
+	ProjectID  *string           `json:"project_id"`
+}
+
+// ReviewNotesRequest carries optional notes for a submit, verify or approve
+// step.
+type ReviewNotesRequest struct {
+	Notes string `json:"notes"`
+}
+
+type RejectTaskRequest struct {
+	Reason string `json:"reason" binding:"required"`
 }
 
 type UpdateTaskRequest struct {
@@ -40,6 +217,121 @@ type UpdateTaskRequest struct {
 	Priority    *string `json:"priority"`
 	AssignedTo  *string `json:"assigned_to"`
 	DueDate     *string `json:"due_date"`
+	ProjectID   *string `json:"project_id"`
+	// AssigneeIDs replaces the assignees, the first becoming the primary
+	// assignee. AssignedTo alone swaps the primary and keeps the others.
+	AssigneeIDs *[]string `json:"assignee_ids"`
+	// ReviewerIDs and ApproverIDs replace the designated reviewers and
+	// approvers; an empty list falls back to role-based review.
+	ReviewerIDs *[]string `json:"reviewer_ids"`
+	ApproverIDs *[]string `json:"approver_ids"`
+	// EstimatePoints sets the story point estimate.
+	EstimatePoints *float64 `json:"estimate_points" binding:"omitempty,min=0"`
+	// CustomFields sets the given values; a null value clears the field.
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	// IfMatch, taken from the If-Match header, is the version the client
+	// last read. The update fails if the task has changed since.
+	IfMatch *int `json:"-"`
+}
+
+type CreateWorklogRequest struct {
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1,max=1440"`
+	WorkDate        string `json:"work_date"`
+	Note            string `json:"note"`
+}
+
+type StartTimerRequest struct {
+	Note string `json:"note"`
+}
+
+// TimesheetFilter narrows WorklogRepository.ListTimesheetRows. From and To are
+// inclusive dates.
+type TimesheetFilter struct {
+	From      string
+	To        string
+	UserID    *uuid.UUID
+	ProjectID *uuid.UUID
+}
+
+// MoveTaskRequest places a task on the board. Status defaults to the task's
+// current status. AfterID and BeforeID name the tasks that should end up
+// directly above and below it; with neither, the task goes to the bottom.
+type MoveTaskRequest struct {
+	Status   string  `json:"status"`
+	AfterID  *string `json:"after_id"`
+	BeforeID *string `json:"before_id"`
+}
+
+// Bulk actions shared by tasks and issues. Tasks also accept "priority";
+// issues accept "severity".
+const (
+	BulkAssign      = "assign"
+	BulkStatus      = "status"
+	BulkPriority    = "priority"
+	BulkSeverity    = "severity"
+	BulkAddLabel    = "add_label"
+	BulkRemoveLabel = "remove_label"
+	BulkDelete      = "delete"
+)
+
+// MaxBulkItems caps how many entities one bulk call may touch.
+const MaxBulkItems = 500
+
+// BulkTaskRequest applies one action to the tasks listed in IDs or matched
+// by Filter. Exactly one of the two must be given.
+type BulkTaskRequest struct {
+	IDs        []string        `json:"ids"`
+	Filter     *BulkTaskFilter `json:"filter"`
+	Action     string          `json:"action" binding:"required,oneof=assign status priority add_label remove_label delete"`
+	AssignedTo *string         `json:"assigned_to"`
+	Status     string          `json:"status"`
+	Priority   string          `json:"priority"`
+	LabelID    string          `json:"label_id"`
+}
+
+// BulkTaskFilter mirrors the GET /tasks query parameters.
+type BulkTaskFilter struct {
+	Status     string   `json:"status"`
+	Priority   string   `json:"priority"`
+	AssignedTo *string  `json:"assigned_to"`
+	ProjectID  *string  `json:"project_id"`
+	SprintID   *string  `json:"sprint_id"`
+	Labels     []string `json:"labels"`
+	Q          string   `json:"q"`
+}
+
+// BulkIssueRequest is the issue counterpart of BulkTaskRequest.
+type BulkIssueRequest struct {
+	IDs        []string         `json:"ids"`
+	Filter     *BulkIssueFilter `json:"filter"`
+	Action     string           `json:"action" binding:"required,oneof=assign status severity add_label remove_label delete"`
+	AssignedTo *string          `json:"assigned_to"`
+	Status     string           `json:"status"`
+	Severity   string           `json:"severity"`
+	LabelID    string           `json:"label_id"`
+}
+
+// BulkIssueFilter mirrors the GET /issues query parameters.
+type BulkIssueFilter struct {
+	Status    string   `json:"status"`
+	Severity  string   `json:"severity"`
+	ProjectID *string  `json:"project_id"`
+	Labels    []string `json:"labels"`
+	Q         string   `json:"q"`
+}
+
+type AddTaskDependencyRequest struct {
+	DependsOnID string `json:"depends_on_id" binding:"required"`
+}
+
+type TaskDependenciesResponse struct {
+	Blockers   []TaskRef `json:"blockers"`
+	Dependents []TaskRef `json:"dependents"`
+}
+
+type UpdateWorkflowRequest struct {
+	Statuses    []WorkflowStatus     `json:"statuses" binding:"required,dive"`
+	Transitions []WorkflowTransition `json:"transitions" binding:"required,dive"`
 }
 
 type CreateIssueRequest struct {
@@ -47,6 +339,9 @@ type CreateIssueRequest struct {
 	Description string  `json:"description" binding:"required"`
 	Severity    string  `json:"severity" binding:"required"`
 	AssignedTo  *string `json:"assigned_to"`
+	ProjectID   *string `json:"project_id"`
+	// CustomFields holds values keyed by custom field key.
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 type UpdateIssueRequest struct {
@@ -55,6 +350,144 @@ type UpdateIssueRequest struct {
 	Severity    *string `json:"severity"`
 	Status      *string `json:"status"`
 	AssignedTo  *string `json:"assigned_to"`
+	ProjectID   *string `json:"project_id"`
+	// CustomFields sets the given values; a null value clears the field.
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	// IfMatch is the issue version from the If-Match header, as for tasks.
+	IfMatch *int `json:"-"`
+}
+
+// CreateIssueLinkRequest links an issue to exactly one of a task or another
+// issue.
+type CreateIssueLinkRequest struct {
+	LinkType string  `json:"link_type" binding:"required,oneof=fixes relates_to duplicates caused_by"`
+	TaskID   *string `json:"task_id"`
+	IssueID  *string `json:"issue_id"`
+}
+
+// CreateFixTaskRequest creates a task that fixes an issue. The title,
+// description and project come from the issue unless given.
+type CreateFixTaskRequest struct {
+	Title       *string  `json:"title"`
+	Priority    string   `json:"priority"`
+	AssignedTo  *string  `json:"assigned_to"`
+	AssigneeIDs []string `json:"assignee_ids"`
+	DueDate     *string  `json:"due_date"`
+	ProjectID   *string  `json:"project_id"`
+}
+
+type CreateApprovalPolicyRequest struct {
+	// ProjectID scopes the policy to a project; empty sets the org default.
+	ProjectID    *string               `json:"project_id"`
+	MinApprovals int                   `json:"min_approvals" binding:"omitempty,min=1,max=20"`
+	Requirements []ApprovalRequirement `json:"requirements"`
+	Sequential   bool                  `json:"sequential"`
+}
+
+type UpdateApprovalPolicyRequest struct {
+	MinApprovals *int                   `json:"min_approvals" binding:"omitempty,min=1,max=20"`
+	Requirements *[]ApprovalRequirement `json:"requirements"`
+	Sequential   *bool                  `json:"sequential"`
+}
+
+type CreateCustomFieldRequest struct {
+	EntityType string   `json:"entity_type" binding:"required,oneof=task issue"`
+	Key        string   `json:"key" binding:"required"`
+	Name       string   `json:"name" binding:"required,max=100"`
+	FieldType  string   `json:"field_type" binding:"required,oneof=text number date single_select multi_select user"`
+	Options    []string `json:"options"`
+	Position   int      `json:"position"`
+}
+
+type UpdateCustomFieldRequest struct {
+	Name     *string  `json:"name" binding:"omitempty,max=100"`
+	Options  []string `json:"options"`
+	Position *int     `json:"position"`
+}
+
+type CreateProjectRequest struct {
+	Name        string   `json:"name" binding:"required,max=255"`
+	Description string   `json:"description"`
+	OwnerID     *string  `json:"owner_id"`
+	MemberIDs   []string `json:"member_ids"`
+}
+
+type UpdateProjectRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=255"`
+	Description *string `json:"description"`
+	OwnerID     *string `json:"owner_id"`
+}
+
+type AddProjectMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type CreateSprintRequest struct {
+	Name      string  `json:"name" binding:"required,max=255"`
+	Goal      string  `json:"goal"`
+	StartDate string  `json:"start_date" binding:"required"`
+	EndDate   string  `json:"end_date" binding:"required"`
+	ProjectID *string `json:"project_id"`
+}
+
+type UpdateSprintRequest struct {
+	Name      *string `json:"name" binding:"omitempty,max=255"`
+	Goal      *string `json:"goal"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+type SprintTasksRequest struct {
+	TaskIDs []string `json:"task_ids" binding:"required,min=1"`
+}
+
+// CloseSprintRequest chooses where unfinished tasks go. Without CarryOverTo
+// they move to the next planned sprint in the same scope, or to the backlog
+// when there is none.
+type CloseSprintRequest struct {
+	CarryOverTo *string `json:"carry_over_to"`
+}
+
+// SprintListFilter narrows SprintRepository.List. Zero values mean "no filter".
+type SprintListFilter struct {
+	ProjectID *uuid.UUID
+	State     string
+}
+
+type CreateSavedViewRequest struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	EntityType string   `json:"entity_type" binding:"required,oneof=task issue"`
+	Query      string   `json:"query"`
+	Sort       string   `json:"sort"`
+	Order      string   `json:"order" binding:"omitempty,oneof=asc desc"`
+	Columns    []string `json:"columns" binding:"max=50"`
+	GroupBy    string   `json:"group_by"`
+	Visibility string   `json:"visibility" binding:"omitempty,oneof=private org role"`
+	SharedRole *string  `json:"shared_role"`
+}
+
+type UpdateSavedViewRequest struct {
+	Name       *string   `json:"name" binding:"omitempty,max=100"`
+	Query      *string   `json:"query"`
+	Sort       *string   `json:"sort"`
+	Order      *string   `json:"order" binding:"omitempty,oneof=asc desc"`
+	Columns    *[]string `json:"columns" binding:"omitempty,max=50"`
+	GroupBy    *string   `json:"group_by"`
+	Visibility *string   `json:"visibility" binding:"omitempty,oneof=private org role"`
+	SharedRole *string   `json:"shared_role"`
+}
+
+type PinSavedViewRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin manager member"`
+}
+
+type CreateCommentRequest struct {
+	Body     string  `json:"body" binding:"required"`
+	ParentID *string `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
 }
 
 type CreateUserRequest struct {
@@ -65,6 +498,22 @@ type CreateUserRequest struct {
 	Role      string `json:"role" binding:"required"`
 }
 
+// PageRequest asks for one page of a keyset-paginated listing. Cursor is the
+// opaque next_cursor of the previous page; Sort and Order default per listing.
+type PageRequest struct {
+	Cursor string
+	Limit  int
+	Sort   string
+	Order  string
+}
+
+// Page is the envelope for paginated listings. NextCursor is null on the last
+// page.
+type Page struct {
+	Data       interface{} `json:"data"`
+	NextCursor *string     `json:"next_cursor"`
+}
+
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message,omitempty"`
@@ -76,8 +525,9 @@ type SuccessResponse struct {
 }
 
 type UploadDocumentRequest struct {
-	TaskID *string `form:"task_id"`
-	Title  *string `form:"title"`
+	TaskID    *string `form:"task_id"`
+	ProjectID *string `form:"project_id"`
+	Title     *string `form:"title"`
 }
 
 type VerifyDocumentRequest struct {
@@ -1,11 +1,16 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrVersionConflict reports an update based on a stale read: the record's
+// version changed after the writer loaded it.
+var ErrVersionConflict = errors.New("record was modified by someone else, reload it and try again")
+
 type Organization struct {
 	ID        uuid.UUID `json:"id"`
 	Name      string    `json:"name"`
@@ -40,43 +45,608 @@ type RefreshToken struct {
 type Task struct {
 	ID              uuid.UUID  `json:"id"`
 	OrgID           uuid.UUID  `json:"org_id"`
+	ProjectID       *uuid.UUID `json:"project_id,omitempty"`
+	SprintID        *uuid.UUID `json:"sprint_id,omitempty"`
+	ParentID        *uuid.UUID `json:"parent_id,omitempty"`
+	RecurringTaskID *uuid.UUID `json:"recurring_task_id,omitempty"`
 	Title           string     `json:"title"`
 	Description     string     `json:"description"`
 	Status          string     `json:"status"` // todo, in_progress, done, verified, approved
-	Priority        string     `json:"priority"`
-	AssignedTo      *uuid.UUID `json:"assigned_to,omitempty"`
-	AssignedToName  *string    `json:"assigned_to_name,omitempty"`
-	CreatedBy       uuid.UUID  `json:"created_by"`
-	CreatedByName   *string    `json:"created_by_name,omitempty"`
-	VerifiedBy      *uuid.UUID `json:"verified_by,omitempty"`
-	VerifiedByName  *string    `json:"verified_by_name,omitempty"`
-	VerifiedAt      *time.Time `json:"verified_at,omitempty"`
-	ApprovedBy      *uuid.UUID `json:"approved_by,omitempty"`
-	ApprovedByName  *string    `json:"approved_by_name,omitempty"`
-	ApprovedAt      *time.Time `json:"approved_at,omitempty"`
-	DocumentFilename *string   `json:"document_filename,omitempty"`
-	DocumentPath    *string    `json:"document_path,omitempty"`
-	DocumentSummary *string    `json:"document_summary,omitempty"`
-	DueDate         *time.Time `json:"due_date,omitempty"`
+	// BoardRank orders the task within its status column.
+	BoardRank        string     `json:"board_rank"`
+	Priority         string     `json:"priority"`
+	AssignedTo       *uuid.UUID `json:"assigned_to,omitempty"`
+	AssignedToName   *string    `json:"assigned_to_name,omitempty"`
+	CreatedBy        uuid.UUID  `json:"created_by"`
+	CreatedByName    *string    `json:"created_by_name,omitempty"`
+	VerifiedBy       *uuid.UUID `json:"verified_by,omitempty"`
+	VerifiedByName   *string    `json:"verified_by_name,omitempty"`
+	VerifiedAt       *time.Time `json:"verified_at,omitempty"`
+	ApprovedBy       *uuid.UUID `json:"approved_by,omitempty"`
+	ApprovedByName   *string    `json:"approved_by_name,omitempty"`
+	ApprovedAt       *time.Time `json:"approved_at,omitempty"`
+	DocumentFilename *string    `json:"document_filename,omitempty"`
+	DocumentPath     *string    `json:"document_path,omitempty"`
+	DocumentSummary  *string    `json:"document_summary,omitempty"`
+	DueDate          *time.Time `json:"due_date,omitempty"`
+	EstimatePoints   *float64   `json:"estimate_points,omitempty"`
+	// CompletedAt is when the task first reached done, verified or approved.
+	CompletedAt     *time.Time             `json:"completed_at,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	SubtaskProgress *SubtaskProgress       `json:"subtask_progress,omitempty"`
+	Subtasks        []Task                 `json:"subtasks,omitempty"`
+	Blockers        []TaskRef              `json:"blockers,omitempty"`
+	Dependents      []TaskRef              `json:"dependents,omitempty"`
+	Labels          []Label                `json:"labels"`
+	CustomFields    map[string]interface{} `json:"custom_fields"`
+	// TimeSpentSeconds totals the task's completed worklogs.
+	TimeSpentSeconds int64 `json:"time_spent_seconds"`
+	// ReworkCount is how many times the task has been rejected.
+	ReworkCount int `json:"rework_count"`
+	// LatestRejectionNote is the reason given for the most recent rejection.
+	LatestRejectionNote *string `json:"latest_rejection_note,omitempty"`
+	// Version increments on every write; it is also the task's ETag.
+	Version int `json:"version"`
+	// Assignees lists everyone the task is assigned to, the primary assignee
+	// (AssignedTo) first.
+	Assignees []TaskPerson `json:"assignees"`
+	// Reviewers and Approvers, when set, are the only users who may verify
+	// and approve the task.
+	Reviewers []TaskPerson `json:"reviewers"`
+	Approvers []TaskPerson `json:"approvers"`
+	// Approval shows progress toward sign-off when an approval policy
+	// applies. It is only filled on single-task reads.
+	Approval *ApprovalStatus `json:"approval,omitempty"`
+}
+
+// Task assignment kinds.
+const (
+	AssignmentAssignee = "assignee"
+	AssignmentReviewer = "reviewer"
+	AssignmentApprover = "approver"
+)
+
+// TaskPerson is a user assigned to a task in some capacity.
+type TaskPerson struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+// Task review actions.
+const (
+	ReviewSubmit  = "submit"
+	ReviewVerify  = "verify"
+	ReviewApprove = "approve"
+	ReviewReject  = "reject"
+)
+
+// TaskReview is one entry in a task's append-only review history.
+type TaskReview struct {
+	ID               uuid.UUID  `json:"id"`
+	OrgID            uuid.UUID  `json:"org_id"`
+	TaskID           uuid.UUID  `json:"task_id"`
+	ActorID          *uuid.UUID `json:"actor_id,omitempty"`
+	ActorName        *string    `json:"actor_name,omitempty"`
+	Action           string     `json:"action"`
+	FromStatus       string     `json:"from_status"`
+	ToStatus         string     `json:"to_status"`
+	Notes            *string    `json:"notes,omitempty"`
+	DocumentFilename *string    `json:"document_filename,omitempty"`
+	DocumentPath     *string    `json:"document_path,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// BulkResult reports a bulk operation item by item. Items that fail their
+// permission or validation checks are skipped; the rest are written together.
+type BulkResult struct {
+	Action    string           `json:"action"`
+	Requested int              `json:"requested"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+type BulkItemResult struct {
+	ID    uuid.UUID `json:"id"`
+	OK    bool      `json:"ok"`
+	Error string    `json:"error,omitempty"`
+}
+
+// TaskRef is a lightweight pointer to a related task.
+type TaskRef struct {
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	Status string    `json:"status"`
+}
+
+// SubtaskProgress is the roll-up of a parent task's direct children.
+type SubtaskProgress struct {
+	Total     int    `json:"total"`
+	Completed int    `json:"completed"`
+	Approved  int    `json:"approved"`
+	Summary   string `json:"summary"`
+}
+
+// RecurringTask is a task template that is instantiated on an RRULE schedule.
+type RecurringTask struct {
+	ID               uuid.UUID  `json:"id"`
+	OrgID            uuid.UUID  `json:"org_id"`
+	ProjectID        *uuid.UUID `json:"project_id,omitempty"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Priority         string     `json:"priority"`
+	AssignedTo       *uuid.UUID `json:"assigned_to,omitempty"`
+	AssignedToName   *string    `json:"assigned_to_name,omitempty"`
+	RRule            string     `json:"rrule"`
+	DTStart          time.Time  `json:"dtstart"`
+	Timezone         string     `json:"timezone"`
+	DueOffsetMinutes *int       `json:"due_offset_minutes,omitempty"`
+	IsPaused         bool       `json:"is_paused"`
+	NextRunAt        *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt        *time.Time `json:"last_run_at,omitempty"`
+	CreatedBy        uuid.UUID  `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TaskTemplate is a reusable task, with optional subtasks, that is expanded
+// on demand. Titles and descriptions may contain {{placeholders}}.
+type TaskTemplate struct {
+	ID               uuid.UUID         `json:"id"`
+	OrgID            uuid.UUID         `json:"org_id"`
+	ProjectID        *uuid.UUID        `json:"project_id,omitempty"`
+	Name             string            `json:"name"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	Priority         string            `json:"priority"`
+	AssignedTo       *uuid.UUID        `json:"assigned_to,omitempty"`
+	AssignedToName   *string           `json:"assigned_to_name,omitempty"`
+	DueOffsetMinutes *int              `json:"due_offset_minutes,omitempty"`
+	Labels           []Label           `json:"labels"`
+	Subtasks         []TemplateSubtask `json:"subtasks"`
+	// Placeholders lists the variable names used anywhere in the template.
+	Placeholders []string  `json:"placeholders"`
+	CreatedBy    uuid.UUID `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TemplateSubtask is a subtask created under a template's task.
+type TemplateSubtask struct {
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Priority         string     `json:"priority"`
+	AssignedTo       *uuid.UUID `json:"assigned_to,omitempty"`
+	DueOffsetMinutes *int       `json:"due_offset_minutes,omitempty"`
+}
+
+// TemplateInstance is the result of expanding a task template.
+type TemplateInstance struct {
+	Task     *Task  `json:"task"`
+	Subtasks []Task `json:"subtasks"`
+}
+
+// Worklog is time recorded against a task, either entered manually or
+// captured by a start/stop timer.
+type Worklog struct {
+	ID              uuid.UUID  `json:"id"`
+	OrgID           uuid.UUID  `json:"org_id"`
+	TaskID          uuid.UUID  `json:"task_id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	UserName        string     `json:"user_name"`
+	WorkDate        time.Time  `json:"work_date"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	IsRunning       bool       `json:"is_running"`
+	Note            string     `json:"note"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TimesheetRow is one user's time on one task for one day.
+type TimesheetRow struct {
+	UserID    uuid.UUID `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	TaskID    uuid.UUID `json:"task_id"`
+	TaskTitle string    `json:"task_title"`
+	WorkDate  time.Time `json:"work_date"`
+	Seconds   int64     `json:"seconds"`
+}
+
+// Timesheet aggregates worklogs for a period, per user.
+type Timesheet struct {
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	TotalSeconds int64           `json:"total_seconds"`
+	Users        []TimesheetUser `json:"users"`
+}
+
+type TimesheetUser struct {
+	UserID       uuid.UUID       `json:"user_id"`
+	UserName     string          `json:"user_name"`
+	TotalSeconds int64           `json:"total_seconds"`
+	Days         []TimesheetDay  `json:"days"`
+	Tasks        []TimesheetTask `json:"tasks"`
+}
+
+type TimesheetDay struct {
+	Date    string `json:"date"`
+	Seconds int64  `json:"seconds"`
+}
+
+type TimesheetTask struct {
+	TaskID  uuid.UUID `json:"task_id"`
+	Title   string    `json:"title"`
+	Seconds int64     `json:"seconds"`
+}
+
+// Custom field types.
+const (
+	CustomFieldText         = "text"
+	CustomFieldNumber       = "number"
+	CustomFieldDate         = "date"
+	CustomFieldSingleSelect = "single_select"
+	CustomFieldMultiSelect  = "multi_select"
+	CustomFieldUser         = "user"
+)
+
+// ApprovalPolicy requires several approvals before a task is approved. A
+// policy with a ProjectID applies to that project's tasks; one without is
+// the organization's default.
+type ApprovalPolicy struct {
+	ID        uuid.UUID  `json:"id"`
+	OrgID     uuid.UUID  `json:"org_id"`
+	ProjectID *uuid.UUID `json:"project_id,omitempty"`
+	// MinApprovals is the total number of approvals needed.
+	MinApprovals int `json:"min_approvals"`
+	// Requirements each need an approval of their own from a matching user.
+	Requirements []ApprovalRequirement `json:"requirements"`
+	// Sequential makes requirements be met in the order listed.
+	Sequential bool      `json:"sequential"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ApprovalRequirement is met by an approval from a user with Role, or from
+// the user UserID. Exactly one of the two is set.
+type ApprovalRequirement struct {
+	Role   string     `json:"role,omitempty"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+}
+
+// TaskApproval is one approval given toward a task's sign-off.
+type TaskApproval struct {
+	ID       uuid.UUID  `json:"id"`
+	TaskID   uuid.UUID  `json:"task_id"`
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	UserName *string    `json:"user_name,omitempty"`
+	// Role is the approver's role when they approved.
+	Role      string    `json:"role"`
+	Notes     *string   `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ApprovalStatus is a task's progress toward satisfying its approval policy.
+type ApprovalStatus struct {
+	PolicyID     uuid.UUID      `json:"policy_id"`
+	MinApprovals int            `json:"min_approvals"`
+	Sequential   bool           `json:"sequential"`
+	Received     []TaskApproval `json:"received"`
+	// Pending lists the requirements no approval has met yet, in order.
+	Pending []ApprovalRequirement `json:"pending"`
+	// Remaining is how many more approvals are needed.
+	Remaining int  `json:"remaining"`
+	Satisfied bool `json:"satisfied"`
+}
+
+// CustomField is an organization-defined field on tasks or issues. Values live
+// in the entity's custom_fields map under Key.
+type CustomField struct {
+	ID         uuid.UUID `json:"id"`
+	OrgID      uuid.UUID `json:"org_id"`
+	EntityType string    `json:"entity_type"`
+	Key        string    `json:"key"`
+	Name       string    `json:"name"`
+	FieldType  string    `json:"field_type"`
+	Options    []string  `json:"options"`
+	Position   int       `json:"position"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Project groups tasks, issues and documents within an organization.
+type Project struct {
+	ID          uuid.UUID       `json:"id"`
+	OrgID       uuid.UUID       `json:"org_id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	OwnerID     *uuid.UUID      `json:"owner_id,omitempty"`
+	OwnerName   *string         `json:"owner_name,omitempty"`
+	IsArchived  bool            `json:"is_archived"`
+	ArchivedAt  *time.Time      `json:"archived_at,omitempty"`
+	MemberCount int             `json:"member_count"`
+	Members     []ProjectMember `json:"members,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type ProjectMember struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email"`
+	Role    string    `json:"role"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// Watcher is a user following a task or issue.
+type Watcher struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	WatchedAt time.Time `json:"watched_at"`
+}
+
+// Trash entity types.
+const (
+	TrashTask     = "task"
+	TrashIssue    = "issue"
+	TrashDocument = "document"
+)
+
+// TrashItem is a soft-deleted task, issue or document awaiting restore or
+// purge.
+type TrashItem struct {
+	EntityType    string     `json:"entity_type"`
+	ID            uuid.UUID  `json:"id"`
+	Title         string     `json:"title"`
+	DeletedAt     time.Time  `json:"deleted_at"`
+	DeletedBy     *uuid.UUID `json:"deleted_by,omitempty"`
+	DeletedByName *string    `json:"deleted_by_name,omitempty"`
+	// PurgeAt is when the item will be removed for good.
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// FieldChange is one field's before and after values in a change history
+// entry. Text describes the change for display.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+	Text  string      `json:"text"`
+}
+
+// HistoryEntry is one update to a task or issue and the fields it changed.
+type HistoryEntry struct {
+	ID        uuid.UUID     `json:"id"`
+	UserID    *uuid.UUID    `json:"user_id,omitempty"`
+	UserName  *string       `json:"user_name,omitempty"`
+	Changes   []FieldChange `json:"changes"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// Sprint states.
+const (
+	SprintPlanned = "planned"
+	SprintActive  = "active"
+	SprintClosed  = "closed"
+)
+
+// Sprint is a time-boxed iteration. StartDate and EndDate are inclusive
+// calendar dates.
+type Sprint struct {
+	ID              uuid.UUID  `json:"id"`
+	OrgID           uuid.UUID  `json:"org_id"`
+	ProjectID       *uuid.UUID `json:"project_id,omitempty"`
+	Name            string     `json:"name"`
+	Goal            string     `json:"goal"`
+	StartDate       time.Time  `json:"start_date"`
+	EndDate         time.Time  `json:"end_date"`
+	State           string     `json:"state"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty"`
+	TaskCount       int        `json:"task_count"`
+	CompletedCount  int        `json:"completed_count"`
+	TotalPoints     float64    `json:"total_points"`
+	CompletedPoints float64    `json:"completed_points"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
+// SprintCloseResult reports where a closed sprint's unfinished tasks went.
+// CarriedOverTo is nil when they returned to the backlog.
+type SprintCloseResult struct {
+	Sprint        *Sprint    `json:"sprint"`
+	CarriedOver   int        `json:"carried_over"`
+	CarriedOverTo *uuid.UUID `json:"carried_over_to,omitempty"`
+}
+
+// SprintVelocity is the work completed within one sprint's window.
+type SprintVelocity struct {
+	SprintID        uuid.UUID `json:"sprint_id"`
+	Name            string    `json:"name"`
+	StartDate       string    `json:"start_date"`
+	EndDate         string    `json:"end_date"`
+	State           string    `json:"state"`
+	CompletedPoints float64   `json:"completed_points"`
+	CompletedTasks  int       `json:"completed_tasks"`
+}
+
+type VelocityReport struct {
+	Sprints []SprintVelocity `json:"sprints"`
+	// AveragePoints averages completed points over the closed sprints listed.
+	AveragePoints float64 `json:"average_points"`
+}
+
+// Saved view visibilities.
+const (
+	ViewPrivate = "private"
+	ViewOrg     = "org"
+	ViewRole    = "role"
+)
+
+// SavedView is a named task or issue listing. Query uses the q= filter
+// language; Columns and GroupBy are presentation hints returned to clients.
+type SavedView struct {
+	ID         uuid.UUID `json:"id"`
+	OrgID      uuid.UUID `json:"org_id"`
+	OwnerID    uuid.UUID `json:"owner_id"`
+	Name       string    `json:"name"`
+	EntityType string    `json:"entity_type"`
+	Query      string    `json:"query"`
+	Sort       string    `json:"sort,omitempty"`
+	Order      string    `json:"order,omitempty"`
+	Columns    []string  `json:"columns"`
+	GroupBy    string    `json:"group_by,omitempty"`
+	Visibility string    `json:"visibility"`
+	SharedRole *string   `json:"shared_role,omitempty"`
+	// PinnedFor lists the roles this view is the default for.
+	PinnedFor []string  `json:"pinned_for"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ViewGroup is one group of a grouped view's page, in first-seen order.
+type ViewGroup struct {
+	Key   string      `json:"key"`
+	Count int         `json:"count"`
+	Items interface{} `json:"items"`
+}
+
+// ViewResult is one page of a saved view's results, in Data or, for grouped
+// views, in Groups.
+type ViewResult struct {
+	View       *SavedView  `json:"view"`
+	Data       interface{} `json:"data,omitempty"`
+	Groups     []ViewGroup `json:"groups,omitempty"`
+	NextCursor *string     `json:"next_cursor"`
+}
+
+// Workflow is an organization's task state machine.
+type Workflow struct {
+	OrgID       uuid.UUID            `json:"org_id"`
+	IsDefault   bool                 `json:"is_default"`
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+type WorkflowStatus struct {
+	Key       string `json:"key" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	Position  int    `json:"position"`
+	IsInitial bool   `json:"is_initial"`
+	// WIPLimit caps how many tasks the board column may hold.
+	WIPLimit *int `json:"wip_limit,omitempty" binding:"omitempty,min=1"`
+	// Category groups the status for completion, reporting and auto-blocking.
+	// It defaults from the status when left empty.
+	Category string `json:"category" binding:"omitempty,oneof=todo in_progress completed blocked"`
+	// ReviewStep names the review action that moves a task into this status.
+	// Only completed statuses may carry one, and each step at most once.
+	ReviewStep string `json:"review_step,omitempty" binding:"omitempty,oneof=submit verify approve"`
+}
+
+// Workflow status categories.
+const (
+	CategoryTodo       = "todo"
+	CategoryInProgress = "in_progress"
+	CategoryCompleted  = "completed"
+	CategoryBlocked    = "blocked"
+)
+
+// BoardColumn is one status column of the task board, in rank order.
+type BoardColumn struct {
+	Status   string `json:"status"`
+	Name     string `json:"name"`
+	WIPLimit *int   `json:"wip_limit,omitempty"`
+	Count    int    `json:"count"`
+	Tasks    []Task `json:"tasks"`
+}
+
+// WorkflowTransition allows moving a task from one status to another.
+// AllowedRoles may contain "assignee" to grant the task's assignee access.
+type WorkflowTransition struct {
+	FromStatus   string   `json:"from_status" binding:"required"`
+	ToStatus     string   `json:"to_status" binding:"required"`
+	AllowedRoles []string `json:"allowed_roles" binding:"required"`
+}
+
 type Issue struct {
-	ID             uuid.UUID  `json:"id"`
-	OrgID          uuid.UUID  `json:"org_id"`
-	Title          string     `json:"title"`
-	Description    string     `json:"description"`
-	Severity       string     `json:"severity"`
-	Status         string     `json:"status"`
-	ReportedBy     uuid.UUID  `json:"reported_by"`
-	ReportedByName *string    `json:"reported_by_name,omitempty"`
-	AssignedTo     *uuid.UUID `json:"assigned_to,omitempty"`
-	AssignedToName *string    `json:"assigned_to_name,omitempty"`
-	AISummary      *string    `json:"ai_summary,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
-	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ID             uuid.UUID              `json:"id"`
+	OrgID          uuid.UUID              `json:"org_id"`
+	ProjectID      *uuid.UUID             `json:"project_id,omitempty"`
+	Title          string                 `json:"title"`
+	Description    string                 `json:"description"`
+	Severity       string                 `json:"severity"`
+	Status         string                 `json:"status"`
+	ReportedBy     uuid.UUID              `json:"reported_by"`
+	ReportedByName *string                `json:"reported_by_name,omitempty"`
+	AssignedTo     *uuid.UUID             `json:"assigned_to,omitempty"`
+	AssignedToName *string                `json:"assigned_to_name,omitempty"`
+	AISummary      *string                `json:"ai_summary,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	ResolvedAt     *time.Time             `json:"resolved_at,omitempty"`
+	Labels         []Label                `json:"labels"`
+	CustomFields   map[string]interface{} `json:"custom_fields"`
+	// Version increments on every write; it is also the issue's ETag.
+	Version int `json:"version"`
+}
+
+// Issue link types. An issue <type> the linked task or issue, except that a
+// task linked with LinkFixes fixes the issue.
+const (
+	LinkFixes      = "fixes"
+	LinkRelatesTo  = "relates_to"
+	LinkDuplicates = "duplicates"
+	LinkCausedBy   = "caused_by"
+)
+
+// IssueLink links an issue to a task or to another issue.
+type IssueLink struct {
+	ID            uuid.UUID  `json:"id"`
+	IssueID       uuid.UUID  `json:"issue_id"`
+	LinkType      string     `json:"link_type"`
+	TaskID        *uuid.UUID `json:"task_id,omitempty"`
+	LinkedIssueID *uuid.UUID `json:"linked_issue_id,omitempty"`
+	// Target is the other end of the link from the task or issue it was
+	// listed for.
+	Target    LinkTarget `json:"target"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LinkTarget is a lightweight pointer to a linked task or issue.
+type LinkTarget struct {
+	Type   string    `json:"type"`
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	Status string    `json:"status"`
+}
+
+// Label is an org-scoped tag that can be attached to tasks and issues.
+type Label struct {
+	ID        uuid.UUID `json:"id"`
+	OrgID     uuid.UUID `json:"org_id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Comment struct {
+	ID         uuid.UUID  `json:"id"`
+	OrgID      uuid.UUID  `json:"org_id"`
+	TaskID     *uuid.UUID `json:"task_id,omitempty"`
+	IssueID    *uuid.UUID `json:"issue_id,omitempty"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	AuthorID   *uuid.UUID `json:"author_id,omitempty"`
+	AuthorName *string    `json:"author_name,omitempty"`
+	Body       string     `json:"body"`
+	EditedAt   *time.Time `json:"edited_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Replies    []Comment  `json:"replies,omitempty"`
 }
 
 type AuditLog struct {
@@ -94,6 +664,7 @@ type AuditLog struct {
 type Document struct {
 	ID                uuid.UUID  `json:"id"`
 	OrgID             uuid.UUID  `json:"org_id"`
+	ProjectID         *uuid.UUID `json:"project_id,omitempty"`
 	TaskID            *uuid.UUID `json:"task_id,omitempty"`
 	UploadedBy        *uuid.UUID `json:"uploaded_by,omitempty"`
 	UploadedByName    *string    `json:"uploaded_by_name,omitempty"`
@@ -121,3 +692,34 @@ type DocumentChunk struct {
 	Content    string    `json:"content"`
 	CreatedAt  time.Time `json:"created_at"`
 }
+
+// Search result types.
+const (
+	SearchTask     = "task"
+	SearchIssue    = "issue"
+	SearchDocument = "document"
+)
+
+// SearchHit is one keyword search match. TitleHighlight and Snippet wrap
+// matched terms in <mark> tags.
+type SearchHit struct {
+	Type           string     `json:"type"`
+	ID             uuid.UUID  `json:"id"`
+	Title          string     `json:"title"`
+	TitleHighlight string     `json:"title_highlight"` // HTML-escaped, with matches in <mark>
+	Snippet        string     `json:"snippet"`         // HTML-escaped, with matches in <mark>
+	Rank           float64    `json:"rank"`
+	ProjectID      *uuid.UUID `json:"project_id,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// SearchResponse is a page of hits plus match counts per type across the
+// whole result set.
+type SearchResponse struct {
+	Query  string         `json:"query"`
+	Total  int            `json:"total"`
+	Facets map[string]int `json:"facets"`
+	Data   []SearchHit    `json:"data"`
+	// NextCursor resumes after this page; it is null on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
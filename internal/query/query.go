@@ -0,0 +1,207 @@
+// Package query parses the filter language accepted by the q= parameter of
+// list endpoints, for example:
+//
+//	assignee:me priority:high,urgent due<7d status!=approved label:backend
+//
+// An expression is a whitespace-separated list of terms that must all match.
+// A term is a field, an operator (":", "=", "!=", "<", "<=", ">" or ">=") and
+// a comma-separated list of values; values containing spaces are quoted.
+// Words that are not terms are free text. Parse only checks syntax; callers
+// decide which fields and operators they support and report problems with
+// Errorf so that messages point at the offending token.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Operators.
+const (
+	OpEq  = ":"
+	OpNe  = "!="
+	OpLt  = "<"
+	OpLte = "<="
+	OpGt  = ">"
+	OpGte = ">="
+)
+
+// Term is one field comparison.
+type Term struct {
+	Field  string
+	Op     string
+	Values []string
+	// Pos is the byte offset of the term in the input, and Token its text.
+	Pos   int
+	Token string
+}
+
+// Query is a parsed expression.
+type Query struct {
+	Terms []Term
+	// Text holds free-text words and quoted phrases.
+	Text []string
+}
+
+// Error is a syntax or validation error tied to a token of the input.
+type Error struct {
+	Pos   int
+	Token string
+	Msg   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d: %q", e.Msg, e.Pos+1, e.Token)
+}
+
+// Errorf reports a problem with a term.
+func Errorf(t Term, format string, args ...interface{}) *Error {
+	return &Error{Pos: t.Pos, Token: t.Token, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parse splits an expression into terms and free text. An empty expression
+// yields an empty query.
+func Parse(input string) (*Query, error) {
+	p := &parser{in: input}
+	q := &Query{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.in) {
+			return q, nil
+		}
+		start := p.pos
+
+		if p.in[p.pos] == '"' {
+			phrase, err := p.quoted()
+			if err != nil {
+				return nil, err
+			}
+			if phrase != "" {
+				q.Text = append(q.Text, `"`+phrase+`"`)
+			}
+			continue
+		}
+
+		field := p.ident()
+		op := ""
+		if field != "" {
+			op = p.operator()
+		}
+		if op == "" {
+			// Not a term: consume the rest of the word as free text.
+			p.pos = start
+			q.Text = append(q.Text, p.word())
+			continue
+		}
+
+		values, err := p.values(start, field+op)
+		if err != nil {
+			return nil, err
+		}
+		if op == "=" {
+			op = OpEq
+		}
+		q.Terms = append(q.Terms, Term{
+			Field:  strings.ToLower(field),
+			Op:     op,
+			Values: values,
+			Pos:    start,
+			Token:  p.in[start:p.pos],
+		})
+	}
+}
+
+type parser struct {
+	in  string
+	pos int
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.in) && isSpace(p.in[p.pos]) {
+		p.pos++
+	}
+}
+
+func isSpace(b byte) bool {
+	return unicode.IsSpace(rune(b))
+}
+
+func (p *parser) ident() string {
+	start := p.pos
+	for p.pos < len(p.in) {
+		b := p.in[p.pos]
+		if b == '_' || b == '.' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.in[start:p.pos]
+}
+
+func (p *parser) operator() string {
+	for _, op := range []string{OpNe, OpLte, OpGte, OpEq, "=", OpLt, OpGt} {
+		if strings.HasPrefix(p.in[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *parser) word() string {
+	start := p.pos
+	for p.pos < len(p.in) && !isSpace(p.in[p.pos]) {
+		p.pos++
+	}
+	return p.in[start:p.pos]
+}
+
+// quoted reads a double-quoted string starting at the current position.
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	end := strings.IndexByte(p.in[p.pos+1:], '"')
+	if end < 0 {
+		return "", &Error{Pos: start, Token: p.in[start:], Msg: "unterminated quote"}
+	}
+	p.pos += end + 2
+	return p.in[start+1 : p.pos-1], nil
+}
+
+// values reads a comma-separated value list that ends at whitespace.
+func (p *parser) values(termStart int, prefix string) ([]string, error) {
+	values := []string{}
+	for {
+		valueStart := p.pos
+		var v string
+		if p.pos < len(p.in) && p.in[p.pos] == '"' {
+			var err error
+			if v, err = p.quoted(); err != nil {
+				return nil, err
+			}
+		} else {
+			for p.pos < len(p.in) && !isSpace(p.in[p.pos]) && p.in[p.pos] != ',' && p.in[p.pos] != '"' {
+				p.pos++
+			}
+			v = p.in[valueStart:p.pos]
+		}
+		if strings.TrimSpace(v) == "" {
+			token := p.in[termStart:p.pos]
+			if len(values) == 0 {
+				return nil, &Error{Pos: termStart, Token: token, Msg: fmt.Sprintf("missing value after %q", prefix)}
+			}
+			return nil, &Error{Pos: termStart, Token: token, Msg: "empty value in list"}
+		}
+		values = append(values, v)
+
+		if p.pos < len(p.in) && p.in[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		if p.pos < len(p.in) && !isSpace(p.in[p.pos]) {
+			return nil, &Error{Pos: termStart, Token: p.in[termStart : p.pos+1], Msg: "unexpected character after value"}
+		}
+		return values, nil
+	}
+}
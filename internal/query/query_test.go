@@ -0,0 +1,100 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		terms []Term
+		text  []string
+	}{
+		{
+			name:  "empty",
+			input: "   ",
+		},
+		{
+			name:  "terms and operators",
+			input: "assignee:me priority>=high due<7d status!=approved",
+			terms: []Term{
+				{Field: "assignee", Op: OpEq, Values: []string{"me"}, Pos: 0, Token: "assignee:me"},
+				{Field: "priority", Op: OpGte, Values: []string{"high"}, Pos: 12, Token: "priority>=high"},
+				{Field: "due", Op: OpLt, Values: []string{"7d"}, Pos: 27, Token: "due<7d"},
+				{Field: "status", Op: OpNe, Values: []string{"approved"}, Pos: 34, Token: "status!=approved"},
+			},
+		},
+		{
+			name:  "equals is an alias for colon and fields are lower-cased",
+			input: "Status=todo",
+			terms: []Term{{Field: "status", Op: OpEq, Values: []string{"todo"}, Pos: 0, Token: "Status=todo"}},
+		},
+		{
+			name:  "value lists and quoted values",
+			input: `priority:high,urgent project:"Mobile App",web`,
+			terms: []Term{
+				{Field: "priority", Op: OpEq, Values: []string{"high", "urgent"}, Pos: 0, Token: "priority:high,urgent"},
+				{Field: "project", Op: OpEq, Values: []string{"Mobile App", "web"}, Pos: 21, Token: `project:"Mobile App",web`},
+			},
+		},
+		{
+			name:  "free text and phrases",
+			input: `login "page timeout" label:backend crash`,
+			terms: []Term{{Field: "label", Op: OpEq, Values: []string{"backend"}, Pos: 21, Token: "label:backend"}},
+			text:  []string{"login", `"page timeout"`, "crash"},
+		},
+		{
+			name:  "words without an operator are text",
+			input: "-urgent v1.2",
+			text:  []string{"-urgent", "v1.2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if len(q.Terms) != len(tt.terms) || len(tt.terms) > 0 && !reflect.DeepEqual(q.Terms, tt.terms) {
+				t.Errorf("terms = %+v, want %+v", q.Terms, tt.terms)
+			}
+			if len(q.Text) != len(tt.text) || len(tt.text) > 0 && !reflect.DeepEqual(q.Text, tt.text) {
+				t.Errorf("text = %q, want %q", q.Text, tt.text)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Error
+	}{
+		{`status:`, Error{Pos: 0, Token: "status:", Msg: `missing value after "status:"`}},
+		{`title "unclosed`, Error{Pos: 6, Token: `"unclosed`, Msg: "unterminated quote"}},
+		{`label:a,,b`, Error{Pos: 0, Token: "label:a,", Msg: "empty value in list"}},
+		{`project:"a"b`, Error{Pos: 0, Token: `project:"a"b`, Msg: "unexpected character after value"}},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var qerr *Error
+		if !errors.As(err, &qerr) {
+			t.Errorf("Parse(%q) error = %v, want *Error", tt.input, err)
+			continue
+		}
+		if *qerr != tt.want {
+			t.Errorf("Parse(%q) error = %+v, want %+v", tt.input, *qerr, tt.want)
+		}
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	err := Errorf(Term{Pos: 4, Token: "prio:high"}, "unknown field %q", "prio")
+	want := `unknown field "prio" at position 5: "prio:high"`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
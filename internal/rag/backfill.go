@@ -7,6 +7,7 @@ import (
 	"log"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type BackfillService struct {
@@ -25,9 +26,10 @@ func NewBackfillService(db *sql.DB, service *Service) *BackfillService {
 }
 
 type BackfillResult struct {
-	TasksIndexed  int `json:"tasks_indexed"`
-	IssuesIndexed int `json:"issues_indexed"`
-	Errors        int `json:"errors"`
+	TasksIndexed    int `json:"tasks_indexed"`
+	IssuesIndexed   int `json:"issues_indexed"`
+	CommentsIndexed int `json:"comments_indexed"`
+	Errors          int `json:"errors"`
 }
 
 func (b *BackfillService) BackfillOrganization(ctx context.Context, orgID uuid.UUID) (*BackfillResult, error) {
@@ -47,14 +49,39 @@ func (b *BackfillService) BackfillOrganization(ctx context.Context, orgID uuid.U
 	result.IssuesIndexed = issuesIndexed
 	result.Errors += issueErrors
 
+	// Index all comments for the organization
+	commentsIndexed, commentErrors := b.indexComments(ctx, orgID)
+	result.CommentsIndexed = commentsIndexed
+	result.Errors += commentErrors
+
 	return result, nil
 }
 
+// customFieldLinesSQL renders an entity's custom field values as
+// "Name: value" lines in field order, matching what the services index.
+func customFieldLinesSQL(table, entityType string) string {
+	return fmt.Sprintf(`ARRAY(
+				SELECT cf.name || ': ' || CASE
+					WHEN cf.field_type = 'user' THEN COALESCE(
+						(SELECT TRIM(CONCAT(u.first_name, ' ', u.last_name)) FROM users u WHERE u.id::text = %[1]s.custom_fields->>cf.key),
+						%[1]s.custom_fields->>cf.key)
+					WHEN jsonb_typeof(%[1]s.custom_fields->cf.key) = 'array' THEN
+						(SELECT string_agg(e, ', ') FROM jsonb_array_elements_text(%[1]s.custom_fields->cf.key) e)
+					ELSE %[1]s.custom_fields->>cf.key
+				END
+				FROM custom_fields cf
+				WHERE cf.org_id = %[1]s.org_id AND cf.entity_type = '%[2]s' AND %[1]s.custom_fields ? cf.key
+				ORDER BY cf.position, LOWER(cf.name)
+			)`, table, entityType)
+}
+
 func (b *BackfillService) indexTasks(ctx context.Context, orgID uuid.UUID) (int, int) {
 	query := `
-		SELECT id, title, description 
-		FROM tasks 
-		WHERE org_id = $1
+		SELECT id, title, description,
+			ARRAY(SELECT l.name FROM task_labels x JOIN labels l ON l.id = x.label_id WHERE x.task_id = tasks.id ORDER BY l.name) AS labels,
+			` + customFieldLinesSQL("tasks", "task") + ` AS fields
+		FROM tasks
+		WHERE org_id = $1 AND deleted_at IS NULL
 	`
 
 	rows, err := b.db.QueryContext(ctx, query, orgID)
@@ -71,14 +98,16 @@ func (b *BackfillService) indexTasks(ctx context.Context, orgID uuid.UUID) (int,
 		var id uuid.UUID
 		var title string
 		var description string
+		var labels []string
+		var fields []string
 
-		if err := rows.Scan(&id, &title, &description); err != nil {
+		if err := rows.Scan(&id, &title, &description, pq.Array(&labels), pq.Array(&fields)); err != nil {
 			log.Printf("Failed to scan task: %v", err)
 			errors++
 			continue
 		}
 
-		content := fmt.Sprintf("Task: %s\n\n%s", title, description)
+		content := TaskContent(title, description, labels, fields)
 		err := b.service.IndexDocument(ctx, IndexRequest{
 			OrgID:      orgID,
 			SourceType: "task",
@@ -103,9 +132,11 @@ func (b *BackfillService) indexTasks(ctx context.Context, orgID uuid.UUID) (int,
 
 func (b *BackfillService) indexIssues(ctx context.Context, orgID uuid.UUID) (int, int) {
 	query := `
-		SELECT id, title, description 
-		FROM issues 
-		WHERE org_id = $1
+		SELECT id, title, description,
+			ARRAY(SELECT l.name FROM issue_labels x JOIN labels l ON l.id = x.label_id WHERE x.issue_id = issues.id ORDER BY l.name) AS labels,
+			` + customFieldLinesSQL("issues", "issue") + ` AS fields
+		FROM issues
+		WHERE org_id = $1 AND deleted_at IS NULL
 	`
 
 	rows, err := b.db.QueryContext(ctx, query, orgID)
@@ -122,14 +153,16 @@ func (b *BackfillService) indexIssues(ctx context.Context, orgID uuid.UUID) (int
 		var id uuid.UUID
 		var title string
 		var description string
+		var labels []string
+		var fields []string
 
-		if err := rows.Scan(&id, &title, &description); err != nil {
+		if err := rows.Scan(&id, &title, &description, pq.Array(&labels), pq.Array(&fields)); err != nil {
 			log.Printf("Failed to scan issue: %v", err)
 			errors++
 			continue
 		}
 
-		content := fmt.Sprintf("Issue: %s\n\n%s", title, description)
+		content := IssueContent(title, description, labels, fields)
 		err := b.service.IndexDocument(ctx, IndexRequest{
 			OrgID:      orgID,
 			SourceType: "issue",
@@ -151,3 +184,59 @@ func (b *BackfillService) indexIssues(ctx context.Context, orgID uuid.UUID) (int
 
 	return indexed, errors
 }
+
+func (b *BackfillService) indexComments(ctx context.Context, orgID uuid.UUID) (int, int) {
+	query := `
+		SELECT c.id, c.body,
+			CASE WHEN c.task_id IS NOT NULL THEN 'task' ELSE 'issue' END AS entity_type,
+			COALESCE(t.title, i.title, '') AS entity_title
+		FROM comments c
+		LEFT JOIN tasks t ON t.id = c.task_id
+		LEFT JOIN issues i ON i.id = c.issue_id
+		WHERE c.org_id = $1 AND t.deleted_at IS NULL AND i.deleted_at IS NULL
+	`
+
+	rows, err := b.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		log.Printf("Failed to query comments for backfill: %v", err)
+		return 0, 1
+	}
+	defer rows.Close()
+
+	indexed := 0
+	errors := 0
+
+	for rows.Next() {
+		var id uuid.UUID
+		var body string
+		var entityType string
+		var entityTitle string
+
+		if err := rows.Scan(&id, &body, &entityType, &entityTitle); err != nil {
+			log.Printf("Failed to scan comment: %v", err)
+			errors++
+			continue
+		}
+
+		content := fmt.Sprintf("Comment: %s\n\n(on %s: %s)", body, entityType, entityTitle)
+		err := b.service.IndexDocument(ctx, IndexRequest{
+			OrgID:      orgID,
+			SourceType: "comment",
+			SourceID:   id,
+			Content:    content,
+		})
+		if err != nil {
+			log.Printf("Failed to index comment %s: %v", id, err)
+			errors++
+			continue
+		}
+		indexed++
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("Error iterating comments: %v", err)
+		errors++
+	}
+
+	return indexed, errors
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -19,12 +20,32 @@ func NewIndexer(service *Service) *Indexer {
 	return &Indexer{service: service}
 }
 
-func (i *Indexer) IndexTask(ctx context.Context, orgID, taskID uuid.UUID, title, description string) {
+// TaskContent builds the text indexed for a task. fields are pre-rendered
+// "Name: value" custom field lines.
+func TaskContent(title, description string, labels, fields []string) string {
+	return entityContent("Task", title, description, labels, fields)
+}
+
+// IssueContent builds the text indexed for an issue.
+func IssueContent(title, description string, labels, fields []string) string {
+	return entityContent("Issue", title, description, labels, fields)
+}
+
+func entityContent(kind, title, description string, labels, fields []string) string {
+	header := []string{fmt.Sprintf("%s: %s", kind, title)}
+	if len(labels) > 0 {
+		header = append(header, "Labels: "+strings.Join(labels, ", "))
+	}
+	header = append(header, fields...)
+	return fmt.Sprintf("%s\n\n%s", strings.Join(header, "\n"), description)
+}
+
+func (i *Indexer) IndexTask(ctx context.Context, orgID, taskID uuid.UUID, title, description string, labels, fields []string) {
 	if i == nil || i.service == nil {
 		return
 	}
 
-	content := fmt.Sprintf("Task: %s\n\n%s", title, description)
+	content := TaskContent(title, description, labels, fields)
 	if content == "" {
 		return
 	}
@@ -40,12 +61,12 @@ func (i *Indexer) IndexTask(ctx context.Context, orgID, taskID uuid.UUID, title,
 	}
 }
 
-func (i *Indexer) IndexIssue(ctx context.Context, orgID, issueID uuid.UUID, title, description string) {
+func (i *Indexer) IndexIssue(ctx context.Context, orgID, issueID uuid.UUID, title, description string, labels, fields []string) {
 	if i == nil || i.service == nil {
 		return
 	}
 
-	content := fmt.Sprintf("Issue: %s\n\n%s", title, description)
+	content := IssueContent(title, description, labels, fields)
 	if content == "" {
 		return
 	}
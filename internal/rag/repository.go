@@ -117,6 +117,15 @@ func (r *Repository) FindSimilar(
 			1 - (embedding <=> $2::vector) AS similarity
 		FROM rag_documents
 		WHERE org_id = $1 %s
+		AND (source_type NOT IN ('task', 'task_document') OR EXISTS (SELECT 1 FROM tasks t WHERE t.id = rag_documents.source_id AND t.deleted_at IS NULL))
+		AND (source_type <> 'issue' OR EXISTS (SELECT 1 FROM issues i WHERE i.id = rag_documents.source_id AND i.deleted_at IS NULL))
+		AND (source_type <> 'document' OR EXISTS (SELECT 1 FROM documents d WHERE d.id = rag_documents.source_id AND d.deleted_at IS NULL))
+		AND (source_type <> 'comment' OR EXISTS (
+			SELECT 1 FROM comments c
+			LEFT JOIN tasks t ON t.id = c.task_id
+			LEFT JOIN issues i ON i.id = c.issue_id
+			WHERE c.id = rag_documents.source_id AND t.deleted_at IS NULL AND i.deleted_at IS NULL
+		))
 		ORDER BY embedding <=> $2::vector
 		LIMIT $3
 	`, sourceTypeFilter)
@@ -173,17 +182,41 @@ func (r *Repository) FindSimilarForMember(
 				SELECT 1 FROM tasks t 
 				WHERE t.id = rd.source_id 
 				AND t.org_id = rd.org_id
-				AND (t.assigned_to = $4 OR t.created_by = $4)
+				AND t.deleted_at IS NULL
+				AND (t.created_by = $4 OR EXISTS (
+					SELECT 1 FROM task_assignments ta WHERE ta.task_id = t.id AND ta.user_id = $4
+				))
 			))
 			OR
 			(rd.source_type = 'issue' AND EXISTS (
 				SELECT 1 FROM issues i 
 				WHERE i.id = rd.source_id 
 				AND i.org_id = rd.org_id
+				AND i.deleted_at IS NULL
 				AND (i.assigned_to = $4 OR i.reported_by = $4)
 			))
 			OR
-			(rd.source_type = 'comment')
+			(rd.source_type = 'comment' AND EXISTS (
+				SELECT 1 FROM comments c
+				WHERE c.id = rd.source_id
+				AND c.org_id = rd.org_id
+				AND (
+					EXISTS (
+						SELECT 1 FROM tasks t
+						WHERE t.id = c.task_id
+						AND t.deleted_at IS NULL
+						AND (t.created_by = $4 OR EXISTS (
+							SELECT 1 FROM task_assignments ta WHERE ta.task_id = t.id AND ta.user_id = $4
+						))
+					)
+					OR EXISTS (
+						SELECT 1 FROM issues i
+						WHERE i.id = c.issue_id
+						AND i.deleted_at IS NULL
+						AND (i.assigned_to = $4 OR i.reported_by = $4)
+					)
+				)
+			))
 		)
 		ORDER BY rd.embedding <=> $2::vector
 		LIMIT $3
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ApprovalRepository struct {
+	db *sql.DB
+}
+
+func NewApprovalRepository(db *sql.DB) *ApprovalRepository {
+	return &ApprovalRepository{db: db}
+}
+
+const approvalPolicySelect = `
+		SELECT id, org_id, project_id, min_approvals, requirements, sequential, created_at, updated_at
+		FROM approval_policies
+`
+
+func scanApprovalPolicy(row rowScanner, policy *models.ApprovalPolicy) error {
+	var requirements []byte
+	err := row.Scan(
+		&policy.ID,
+		&policy.OrgID,
+		&policy.ProjectID,
+		&policy.MinApprovals,
+		&requirements,
+		&policy.Sequential,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	policy.Requirements = []models.ApprovalRequirement{}
+	if err := json.Unmarshal(requirements, &policy.Requirements); err != nil {
+		return fmt.Errorf("failed to decode approval requirements: %w", err)
+	}
+	return nil
+}
+
+func encodeRequirements(requirements []models.ApprovalRequirement) ([]byte, error) {
+	if requirements == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(requirements)
+}
+
+func mapApprovalPolicyError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("an approval policy already exists for this scope")
+	}
+	return err
+}
+
+func (r *ApprovalRepository) Create(policy *models.ApprovalPolicy) error {
+	requirements, err := encodeRequirements(policy.Requirements)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO approval_policies (id, org_id, project_id, min_approvals, requirements, sequential)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, updated_at
+	`
+	err = r.db.QueryRow(
+		query,
+		policy.ID,
+		policy.OrgID,
+		policy.ProjectID,
+		policy.MinApprovals,
+		requirements,
+		policy.Sequential,
+	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	return mapApprovalPolicyError(err)
+}
+
+func (r *ApprovalRepository) GetByID(orgID, policyID uuid.UUID) (*models.ApprovalPolicy, error) {
+	policy := &models.ApprovalPolicy{}
+	err := scanApprovalPolicy(r.db.QueryRow(approvalPolicySelect+`WHERE org_id = $1 AND id = $2`, orgID, policyID), policy)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return policy, err
+}
+
+// GetForProject returns the policy that applies to tasks in a project: the
+// project's own policy, or else the organization default. It returns nil
+// when neither exists.
+func (r *ApprovalRepository) GetForProject(orgID uuid.UUID, projectID *uuid.UUID) (*models.ApprovalPolicy, error) {
+	query := approvalPolicySelect + `
+		WHERE org_id = $1 AND (project_id IS NULL OR project_id = $2)
+		ORDER BY project_id IS NULL
+		LIMIT 1
+	`
+	policy := &models.ApprovalPolicy{}
+	err := scanApprovalPolicy(r.db.QueryRow(query, orgID, projectID), policy)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return policy, err
+}
+
+// List returns the organization default first, then project policies.
+func (r *ApprovalRepository) List(orgID uuid.UUID) ([]models.ApprovalPolicy, error) {
+	rows, err := r.db.Query(approvalPolicySelect+`
+		WHERE org_id = $1
+		ORDER BY project_id IS NOT NULL, created_at
+	`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := []models.ApprovalPolicy{}
+	for rows.Next() {
+		var policy models.ApprovalPolicy
+		if err := scanApprovalPolicy(rows, &policy); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+func (r *ApprovalRepository) Update(policy *models.ApprovalPolicy) error {
+	requirements, err := encodeRequirements(policy.Requirements)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE approval_policies
+		SET min_approvals = $1, requirements = $2, sequential = $3
+		WHERE org_id = $4 AND id = $5
+		RETURNING updated_at
+	`
+	err = r.db.QueryRow(query, policy.MinApprovals, requirements, policy.Sequential, policy.OrgID, policy.ID).Scan(&policy.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("approval policy not found")
+	}
+	return err
+}
+
+func (r *ApprovalRepository) Delete(orgID, policyID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM approval_policies WHERE org_id = $1 AND id = $2`, orgID, policyID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("approval policy not found")
+	}
+	return nil
+}
+
+// currentApprovals limits task_approvals rows a to the task's current review
+// round, which starts at its latest submit, verify or reject.
+const currentApprovals = `
+		a.created_at > COALESCE((
+			SELECT MAX(r.created_at) FROM task_reviews r
+			WHERE r.task_id = a.task_id AND r.action IN ('submit', 'verify', 'reject')
+		), '-infinity')
+`
+
+// AddTaskApproval records an approval while the task is still in status.
+// Approvals of one task are serialized, and a user can approve only once per
+// round.
+func (r *ApprovalRepository) AddTaskApproval(approval *models.TaskApproval, orgID uuid.UUID, status string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var current string
+	err = tx.QueryRow(`
+		SELECT status FROM tasks
+		WHERE org_id = $1 AND id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, orgID, approval.TaskID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("task not found")
+	}
+	if err != nil {
+		return err
+	}
+	if current != status {
+		return models.ErrVersionConflict
+	}
+
+	var already bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM task_approvals a WHERE a.task_id = $1 AND a.user_id = $2 AND `+currentApprovals+`)
+	`, approval.TaskID, approval.UserID).Scan(&already)
+	if err != nil {
+		return err
+	}
+	if already {
+		return fmt.Errorf("you have already approved this task")
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO task_approvals (id, org_id, task_id, user_id, role, notes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`, approval.ID, orgID, approval.TaskID, approval.UserID, approval.Role, approval.Notes).Scan(&approval.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListTaskApprovals returns the approvals of the task's current round, oldest
+// first.
+func (r *ApprovalRepository) ListTaskApprovals(orgID, taskID uuid.UUID) ([]models.TaskApproval, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.task_id, a.user_id, a.role, a.notes, a.created_at,
+			CASE
+				WHEN u.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, ''))
+			END AS user_name
+		FROM task_approvals a
+		LEFT JOIN users u ON u.id = a.user_id
+		WHERE a.org_id = $1 AND a.task_id = $2 AND `+currentApprovals+`
+		ORDER BY a.created_at, a.id
+	`, orgID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	approvals := []models.TaskApproval{}
+	for rows.Next() {
+		var a models.TaskApproval
+		if err := rows.Scan(&a.ID, &a.TaskID, &a.UserID, &a.Role, &a.Notes, &a.CreatedAt, &a.UserName); err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, a)
+	}
+	return approvals, rows.Err()
+}
@@ -41,15 +41,37 @@ func (r *AuditLogRepository) Create(log *models.AuditLog) error {
 	).Scan(&log.CreatedAt)
 }
 
-func (r *AuditLogRepository) List(orgID uuid.UUID, limit int) ([]models.AuditLog, error) {
-	query := `
+var auditLogKeyset = keyset{
+	idExpr:      "id",
+	defaultSort: "created_at",
+	columns: map[string]sortColumn{
+		"created_at": {expr: "created_at", cast: "timestamptz", defaultOrder: "desc"},
+	},
+}
+
+// List returns one page of the organization's audit trail, newest first by
+// default, and the cursor for the next page.
+func (r *AuditLogRepository) List(orgID uuid.UUID, page models.PageRequest) ([]models.AuditLog, string, error) {
+	plan, err := auditLogKeyset.plan(page)
+	if err != nil {
+		return nil, "", err
+	}
+	query, args := plan.apply(auditLogKeyset, `
 		SELECT id, org_id, user_id, action, entity_type, entity_id, details, ip_address, created_at
 		FROM audit_logs
-		WHERE org_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2
-	`
-	rows, err := r.db.Query(query, orgID, limit)
+		WHERE org_id = $1`, []interface{}{orgID}, 2)
+
+	logs, err := r.queryAuditLogs(query, args...)
+	if err != nil || !plan.more(len(logs)) {
+		return logs, "", err
+	}
+	logs = logs[:plan.limit]
+	last := logs[len(logs)-1]
+	return logs, plan.next(cursorTime(last.CreatedAt), last.ID), nil
+}
+
+func (r *AuditLogRepository) queryAuditLogs(query string, args ...interface{}) ([]models.AuditLog, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
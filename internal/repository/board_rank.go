@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Board ranks are base-36 digit strings ordered bytewise. rankBetween always
+// finds a key strictly between two others, so moving a task only rewrites
+// that task's rank. Generated ranks never end in '0', which keeps a gap
+// available below every key.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// rankBetween returns a rank strictly between prev and next. An empty prev
+// means the start of the column and an empty next means its end. prev must
+// sort before next.
+func rankBetween(prev, next string) string {
+	if next != "" {
+		n := 0
+		for n < len(next) {
+			c := byte('0')
+			if n < len(prev) {
+				c = prev[n]
+			}
+			if c != next[n] {
+				break
+			}
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(prev) {
+				rest = prev[n:]
+			}
+			return next[:n] + rankBetween(rest, next[n:])
+		}
+	}
+
+	lo := 0
+	if prev != "" {
+		lo = strings.IndexByte(rankDigits, prev[0])
+	}
+	hi := len(rankDigits)
+	if next != "" {
+		hi = strings.IndexByte(rankDigits, next[0])
+	}
+	if hi-lo > 1 {
+		return string(rankDigits[(lo+hi)/2])
+	}
+	if next != "" && len(next) > 1 {
+		return next[:1]
+	}
+	rest := ""
+	if len(prev) > 1 {
+		rest = prev[1:]
+	}
+	return string(rankDigits[lo]) + rankBetween(rest, "")
+}
+
+// sequentialRank is the evenly spaced rank used when a column is seeded or
+// rebalanced. It matches the format written by migration 015.
+func sequentialRank(i int) string {
+	return fmt.Sprintf("%08di", i+1)
+}
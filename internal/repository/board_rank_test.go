@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func checkBetween(t *testing.T, prev, next, got string) {
+	t.Helper()
+	if got <= prev || next != "" && got >= next {
+		t.Fatalf("rankBetween(%q, %q) = %q, not strictly between", prev, next, got)
+	}
+	if strings.HasSuffix(got, "0") {
+		t.Fatalf("rankBetween(%q, %q) = %q ends in '0'", prev, next, got)
+	}
+	if strings.Trim(got, rankDigits) != "" {
+		t.Fatalf("rankBetween(%q, %q) = %q has characters outside the rank alphabet", prev, next, got)
+	}
+}
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		prev, next string
+	}{
+		{"", ""},
+		{"", "1"},
+		{"", "01"},
+		{"", "001"},
+		{"a", ""},
+		{"z", ""},
+		{"zzz", ""},
+		{"a", "b"},
+		{"a", "a1"},
+		{"ab", "ac"},
+		{"a0001", "a001"},
+		{"0000001i", "0000002i"},
+		{"0000001i", "0000001j"},
+		{"y", "z"},
+		{"yz", "z"},
+	}
+	for _, tt := range tests {
+		checkBetween(t, tt.prev, tt.next, rankBetween(tt.prev, tt.next))
+	}
+}
+
+func TestRankBetweenSequentialRanks(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		prev, next := sequentialRank(i), sequentialRank(i+1)
+		if prev >= next {
+			t.Fatalf("sequentialRank(%d) = %q does not sort before %q", i, prev, next)
+		}
+		checkBetween(t, prev, next, rankBetween(prev, next))
+	}
+}
+
+// TestRankBetweenRandomInserts places many tasks at random positions in one
+// column, including repeatedly at the same spot, and checks the column stays
+// strictly ordered.
+func TestRankBetweenRandomInserts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranks := []string{}
+	for i := 0; i < 2000; i++ {
+		pos := rng.Intn(len(ranks) + 1)
+		if i%10 == 0 && len(ranks) > 0 {
+			pos = 1 // keep squeezing into the same gap
+		}
+		prev, next := "", ""
+		if pos > 0 {
+			prev = ranks[pos-1]
+		}
+		if pos < len(ranks) {
+			next = ranks[pos]
+		}
+		got := rankBetween(prev, next)
+		checkBetween(t, prev, next, got)
+		ranks = append(ranks[:pos], append([]string{got}, ranks[pos:]...)...)
+	}
+	if !sort.StringsAreSorted(ranks) {
+		t.Fatal("ranks are not sorted")
+	}
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// BulkTaskWrite is the set of changes a bulk task operation applies. Updates
+// are full rows as for Update; AddLabelID and RemoveLabelID apply to
+// LabelTaskIDs. Reviews are appended to the updated tasks' review history and
+// History, keyed by task ID, to their change history. DeleteIDs are moved to
+// the trash as deleted by DeletedBy.
+type BulkTaskWrite struct {
+	Updates       []*models.Task
+	Reviews       []*models.TaskReview
+	DeleteIDs     []uuid.UUID
+	DeletedBy     uuid.UUID
+	AddLabelID    *uuid.UUID
+	RemoveLabelID *uuid.UUID
+	LabelTaskIDs  []uuid.UUID
+	History       map[uuid.UUID]*models.HistoryEntry
+}
+
+// ApplyBulk writes every change in one transaction; any failure rolls back
+// the whole batch.
+func (r *TaskRepository) ApplyBulk(orgID uuid.UUID, write BulkTaskWrite) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, task := range write.Updates {
+		if err := updateTask(tx, task); err != nil {
+			return fmt.Errorf("task %s: %w", task.ID, err)
+		}
+	}
+	for _, review := range write.Reviews {
+		if err := insertTaskReview(tx, review); err != nil {
+			return fmt.Errorf("task %s: %w", review.TaskID, err)
+		}
+	}
+	for taskID, entry := range write.History {
+		if err := insertTaskHistory(tx, orgID, taskID, entry); err != nil {
+			return fmt.Errorf("task %s: %w", taskID, err)
+		}
+	}
+	if len(write.DeleteIDs) > 0 {
+		if err := softDeleteMany(tx, "tasks", orgID, write.DeleteIDs, write.DeletedBy); err != nil {
+			return err
+		}
+	}
+	if err := applyBulkLabel(tx, orgID, "task_labels", "task_id", write.AddLabelID, write.RemoveLabelID, write.LabelTaskIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// BulkIssueWrite is the issue counterpart of BulkTaskWrite.
+type BulkIssueWrite struct {
+	Updates       []*models.Issue
+	DeleteIDs     []uuid.UUID
+	DeletedBy     uuid.UUID
+	AddLabelID    *uuid.UUID
+	RemoveLabelID *uuid.UUID
+	LabelIssueIDs []uuid.UUID
+	History       map[uuid.UUID]*models.HistoryEntry
+}
+
+func (r *IssueRepository) ApplyBulk(orgID uuid.UUID, write BulkIssueWrite) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, issue := range write.Updates {
+		if err := updateIssue(tx, issue); err != nil {
+			return fmt.Errorf("issue %s: %w", issue.ID, err)
+		}
+		if err := insertIssueHistory(tx, orgID, issue.ID, write.History[issue.ID]); err != nil {
+			return fmt.Errorf("issue %s: %w", issue.ID, err)
+		}
+	}
+	if len(write.DeleteIDs) > 0 {
+		if err := softDeleteMany(tx, "issues", orgID, write.DeleteIDs, write.DeletedBy); err != nil {
+			return err
+		}
+	}
+	if err := applyBulkLabel(tx, orgID, "issue_labels", "issue_id", write.AddLabelID, write.RemoveLabelID, write.LabelIssueIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// applyBulkLabel attaches or detaches one org label across many entities.
+// Attaching is idempotent and detaching ignores entities without the label.
+func applyBulkLabel(tx *sql.Tx, orgID uuid.UUID, joinTable, fkColumn string, addID, removeID *uuid.UUID, entityIDs []uuid.UUID) error {
+	if len(entityIDs) == 0 || (addID == nil && removeID == nil) {
+		return nil
+	}
+	labelID := addID
+	if labelID == nil {
+		labelID = removeID
+	}
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM labels WHERE org_id = $1 AND id = $2)`, orgID, *labelID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("label not found")
+	}
+
+	var query string
+	if addID != nil {
+		query = fmt.Sprintf(`INSERT INTO %s (%s, label_id) SELECT unnest($1::uuid[]), $2 ON CONFLICT DO NOTHING`, joinTable, fkColumn)
+	} else {
+		query = fmt.Sprintf(`DELETE FROM %s WHERE %s = ANY($1) AND label_id = $2`, joinTable, fkColumn)
+	}
+	_, err := tx.Exec(query, pq.Array(entityIDs), *labelID)
+	return err
+}
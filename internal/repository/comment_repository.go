@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type CommentRepository struct {
+	db *sql.DB
+}
+
+func NewCommentRepository(db *sql.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (r *CommentRepository) Create(comment *models.Comment) error {
+	query := `
+		INSERT INTO comments (id, org_id, task_id, issue_id, parent_id, author_id, body)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+	return r.db.QueryRow(
+		query,
+		comment.ID,
+		comment.OrgID,
+		comment.TaskID,
+		comment.IssueID,
+		comment.ParentID,
+		comment.AuthorID,
+		comment.Body,
+	).Scan(&comment.CreatedAt, &comment.UpdatedAt)
+}
+
+func (r *CommentRepository) GetByID(orgID, commentID uuid.UUID) (*models.Comment, error) {
+	query := `
+		SELECT
+			c.id, c.org_id, c.task_id, c.issue_id, c.parent_id, c.author_id, c.body, c.edited_at, c.created_at, c.updated_at,
+			CASE
+				WHEN u.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, ''))
+			END AS author_name
+		FROM comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.org_id = $1 AND c.id = $2
+	`
+	comment := &models.Comment{}
+	err := r.db.QueryRow(query, orgID, commentID).Scan(
+		&comment.ID,
+		&comment.OrgID,
+		&comment.TaskID,
+		&comment.IssueID,
+		&comment.ParentID,
+		&comment.AuthorID,
+		&comment.Body,
+		&comment.EditedAt,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.AuthorName,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return comment, err
+}
+
+// ListByTask returns every comment on a task in creation order (flat, not threaded).
+func (r *CommentRepository) ListByTask(orgID, taskID uuid.UUID) ([]models.Comment, error) {
+	return r.listBy(orgID, "c.task_id", taskID)
+}
+
+// ListByIssue returns every comment on an issue in creation order (flat, not threaded).
+func (r *CommentRepository) ListByIssue(orgID, issueID uuid.UUID) ([]models.Comment, error) {
+	return r.listBy(orgID, "c.issue_id", issueID)
+}
+
+func (r *CommentRepository) listBy(orgID uuid.UUID, column string, entityID uuid.UUID) ([]models.Comment, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			c.id, c.org_id, c.task_id, c.issue_id, c.parent_id, c.author_id, c.body, c.edited_at, c.created_at, c.updated_at,
+			CASE
+				WHEN u.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, ''))
+			END AS author_name
+		FROM comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.org_id = $1 AND %s = $2
+		ORDER BY c.created_at ASC
+	`, column)
+
+	rows, err := r.db.Query(query, orgID, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		var comment models.Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.OrgID,
+			&comment.TaskID,
+			&comment.IssueID,
+			&comment.ParentID,
+			&comment.AuthorID,
+			&comment.Body,
+			&comment.EditedAt,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.AuthorName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (r *CommentRepository) UpdateBody(comment *models.Comment) error {
+	query := `
+		UPDATE comments
+		SET body = $1, edited_at = CURRENT_TIMESTAMP
+		WHERE org_id = $2 AND id = $3
+		RETURNING edited_at, updated_at
+	`
+	err := r.db.QueryRow(query, comment.Body, comment.OrgID, comment.ID).Scan(&comment.EditedAt, &comment.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("comment not found")
+	}
+	return err
+}
+
+// Delete removes a comment together with all of its replies and returns the
+// IDs of every removed comment so callers can clean up derived data.
+func (r *CommentRepository) Delete(orgID, commentID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		WITH RECURSIVE thread AS (
+			SELECT id FROM comments WHERE org_id = $1 AND id = $2
+			UNION ALL
+			SELECT c.id FROM comments c JOIN thread t ON c.parent_id = t.id
+		)
+		DELETE FROM comments
+		WHERE id IN (SELECT id FROM thread)
+		RETURNING id
+	`
+	rows, err := r.db.Query(query, orgID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("comment not found")
+	}
+	return ids, nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type CustomFieldRepository struct {
+	db *sql.DB
+}
+
+func NewCustomFieldRepository(db *sql.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+func decodeCustomFields(raw []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if len(raw) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode custom fields: %w", err)
+	}
+	return values, nil
+}
+
+func encodeCustomFields(values map[string]interface{}) ([]byte, error) {
+	if values == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(values)
+}
+
+// customFieldFilterClause appends a JSONB containment condition on column,
+// returning the SQL fragment, its args, and the next arg index.
+func customFieldFilterClause(values map[string]interface{}, column string, argIdx int) (string, []interface{}, int, error) {
+	if len(values) == 0 {
+		return "", nil, argIdx, nil
+	}
+	doc, err := json.Marshal(values)
+	if err != nil {
+		return "", nil, argIdx, fmt.Errorf("failed to encode custom field filter: %w", err)
+	}
+	return fmt.Sprintf(" AND %s @> $%d::jsonb", column, argIdx), []interface{}{string(doc)}, argIdx + 1, nil
+}
+
+func (r *CustomFieldRepository) Create(field *models.CustomField) error {
+	query := `
+		INSERT INTO custom_fields (id, org_id, entity_type, key, name, field_type, options, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		field.ID,
+		field.OrgID,
+		field.EntityType,
+		field.Key,
+		field.Name,
+		field.FieldType,
+		pq.Array(field.Options),
+		field.Position,
+	).Scan(&field.CreatedAt, &field.UpdatedAt)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("a custom field with this key already exists")
+	}
+	return err
+}
+
+const customFieldSelect = `
+		SELECT id, org_id, entity_type, key, name, field_type, options, position, created_at, updated_at
+		FROM custom_fields
+`
+
+func scanCustomField(row rowScanner, field *models.CustomField) error {
+	return row.Scan(
+		&field.ID,
+		&field.OrgID,
+		&field.EntityType,
+		&field.Key,
+		&field.Name,
+		&field.FieldType,
+		pq.Array(&field.Options),
+		&field.Position,
+		&field.CreatedAt,
+		&field.UpdatedAt,
+	)
+}
+
+func (r *CustomFieldRepository) GetByID(orgID, fieldID uuid.UUID) (*models.CustomField, error) {
+	query := customFieldSelect + `
+		WHERE org_id = $1 AND id = $2
+	`
+	field := &models.CustomField{}
+	err := scanCustomField(r.db.QueryRow(query, orgID, fieldID), field)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return field, err
+}
+
+// List returns the organization's fields in display order. An empty
+// entityType returns fields for every entity type.
+func (r *CustomFieldRepository) List(orgID uuid.UUID, entityType string) ([]models.CustomField, error) {
+	query := customFieldSelect + `
+		WHERE org_id = $1 AND ($2 = '' OR entity_type = $2)
+		ORDER BY entity_type ASC, position ASC, LOWER(name) ASC
+	`
+	rows, err := r.db.Query(query, orgID, entityType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := []models.CustomField{}
+	for rows.Next() {
+		var field models.CustomField
+		if err := scanCustomField(rows, &field); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, rows.Err()
+}
+
+func (r *CustomFieldRepository) Update(field *models.CustomField) error {
+	query := `
+		UPDATE custom_fields
+		SET name = $1, options = $2, position = $3
+		WHERE org_id = $4 AND id = $5
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(query, field.Name, pq.Array(field.Options), field.Position, field.OrgID, field.ID).Scan(&field.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("custom field not found")
+	}
+	return err
+}
+
+// Delete removes a field definition and strips its values from every task or
+// issue in the organization.
+func (r *CustomFieldRepository) Delete(field *models.CustomField) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.Exec(`DELETE FROM custom_fields WHERE org_id = $1 AND id = $2`, field.OrgID, field.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("custom field not found")
+	}
+
+	table := "tasks"
+	if field.EntityType == "issue" {
+		table = "issues"
+	}
+	strip := fmt.Sprintf(`UPDATE %s SET custom_fields = custom_fields - $1 WHERE org_id = $2 AND custom_fields ? $1`, table)
+	if _, err := tx.Exec(strip, field.Key, field.OrgID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
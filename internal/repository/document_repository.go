@@ -23,9 +23,9 @@ func NewDocumentRepository(db *sql.DB) *DocumentRepository {
 func (r *DocumentRepository) Create(ctx context.Context, doc *models.Document) error {
 	query := `
 		INSERT INTO documents (
-			id, org_id, task_id, uploaded_by, title, filename, mime_type, file_size, sha256, storage_path, extracted_text, status
+			id, org_id, project_id, task_id, uploaded_by, title, filename, mime_type, file_size, sha256, storage_path, extracted_text, status
 		)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
 		RETURNING created_at, updated_at
 	`
 	doc.Status = "submitted"
@@ -34,6 +34,7 @@ func (r *DocumentRepository) Create(ctx context.Context, doc *models.Document) e
 		query,
 		doc.ID,
 		doc.OrgID,
+		doc.ProjectID,
 		doc.TaskID,
 		doc.UploadedBy,
 		doc.Title,
@@ -47,13 +48,11 @@ func (r *DocumentRepository) Create(ctx context.Context, doc *models.Document) e
 	).Scan(&doc.CreatedAt, &doc.UpdatedAt)
 }
 
-func (r *DocumentRepository) List(ctx context.Context, orgID uuid.UUID, limit int) ([]models.Document, error) {
-	if limit <= 0 || limit > 200 {
-		limit = 50
-	}
-	query := `
+// documentListSelect is the shared projection for document listings. It omits
+// the storage path and extracted text, which only single-document reads need.
+const documentListSelect = `
 		SELECT 
-			d.id, d.org_id, d.task_id, d.uploaded_by, d.title, d.filename, d.mime_type, 
+			d.id, d.org_id, d.project_id, d.task_id, d.uploaded_by, d.title, d.filename, d.mime_type, 
 			d.file_size, d.sha256, d.status, d.verified_by, d.verified_at, d.verification_notes,
 			d.created_at, d.updated_at,
 			CASE WHEN u.id IS NULL THEN NULL ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')) END AS uploaded_by_name,
@@ -61,12 +60,10 @@ func (r *DocumentRepository) List(ctx context.Context, orgID uuid.UUID, limit in
 		FROM documents d
 		LEFT JOIN users u ON u.id = d.uploaded_by
 		LEFT JOIN users v ON v.id = d.verified_by
-		WHERE d.org_id = $1
-		ORDER BY d.created_at DESC
-		LIMIT $2
-	`
+`
 
-	rows, err := r.db.QueryContext(ctx, query, orgID, limit)
+func (r *DocumentRepository) queryDocuments(ctx context.Context, query string, args ...interface{}) ([]models.Document, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +75,7 @@ func (r *DocumentRepository) List(ctx context.Context, orgID uuid.UUID, limit in
 		err := rows.Scan(
 			&d.ID,
 			&d.OrgID,
+			&d.ProjectID,
 			&d.TaskID,
 			&d.UploadedBy,
 			&d.Title,
@@ -102,65 +100,55 @@ func (r *DocumentRepository) List(ctx context.Context, orgID uuid.UUID, limit in
 	return out, rows.Err()
 }
 
+var documentKeyset = keyset{
+	idExpr:      "d.id",
+	defaultSort: "created_at",
+	columns: map[string]sortColumn{
+		"created_at": {expr: "d.created_at", cast: "timestamptz", defaultOrder: "desc"},
+		"updated_at": {expr: "d.updated_at", cast: "timestamptz", defaultOrder: "desc"},
+	},
+}
+
+// List returns one page of the organization's documents, newest first by
+// default, and the cursor for the next page. A non-nil projectID restricts
+// the listing to that project.
+func (r *DocumentRepository) List(ctx context.Context, orgID uuid.UUID, projectID *uuid.UUID, page models.PageRequest) ([]models.Document, string, error) {
+	plan, err := documentKeyset.plan(page)
+	if err != nil {
+		return nil, "", err
+	}
+	query, args := plan.apply(documentKeyset, documentListSelect+`
+		WHERE d.org_id = $1 AND d.deleted_at IS NULL AND ($2::uuid IS NULL OR d.project_id = $2)`, []interface{}{orgID, projectID}, 3)
+
+	docs, err := r.queryDocuments(ctx, query, args...)
+	if err != nil || !plan.more(len(docs)) {
+		return docs, "", err
+	}
+	docs = docs[:plan.limit]
+	last := docs[len(docs)-1]
+	value := cursorTime(last.CreatedAt)
+	if plan.sort == "updated_at" {
+		value = cursorTime(last.UpdatedAt)
+	}
+	return docs, plan.next(value, last.ID), nil
+}
+
 func (r *DocumentRepository) ListByTask(ctx context.Context, orgID, taskID uuid.UUID, limit int) ([]models.Document, error) {
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
-	query := `
-		SELECT 
-			d.id, d.org_id, d.task_id, d.uploaded_by, d.title, d.filename, d.mime_type, 
-			d.file_size, d.sha256, d.status, d.verified_by, d.verified_at, d.verification_notes,
-			d.created_at, d.updated_at,
-			CASE WHEN u.id IS NULL THEN NULL ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')) END AS uploaded_by_name,
-			CASE WHEN v.id IS NULL THEN NULL ELSE CONCAT(COALESCE(v.first_name, ''), ' ', COALESCE(v.last_name, '')) END AS verified_by_name
-		FROM documents d
-		LEFT JOIN users u ON u.id = d.uploaded_by
-		LEFT JOIN users v ON v.id = d.verified_by
-		WHERE d.org_id = $1 AND d.task_id = $2
+	query := documentListSelect + `
+		WHERE d.org_id = $1 AND d.task_id = $2 AND d.deleted_at IS NULL
 		ORDER BY d.created_at DESC
 		LIMIT $3
 	`
-
-	rows, err := r.db.QueryContext(ctx, query, orgID, taskID, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	out := []models.Document{}
-	for rows.Next() {
-		var d models.Document
-		err := rows.Scan(
-			&d.ID,
-			&d.OrgID,
-			&d.TaskID,
-			&d.UploadedBy,
-			&d.Title,
-			&d.Filename,
-			&d.MimeType,
-			&d.FileSize,
-			&d.SHA256,
-			&d.Status,
-			&d.VerifiedBy,
-			&d.VerifiedAt,
-			&d.VerificationNotes,
-			&d.CreatedAt,
-			&d.UpdatedAt,
-			&d.UploadedByName,
-			&d.VerifiedByName,
-		)
-		if err != nil {
-			return nil, err
-		}
-		out = append(out, d)
-	}
-	return out, rows.Err()
+	return r.queryDocuments(ctx, query, orgID, taskID, limit)
 }
 
 func (r *DocumentRepository) GetByID(ctx context.Context, orgID, documentID uuid.UUID) (*models.Document, error) {
 	query := `
 		SELECT 
-			d.id, d.org_id, d.task_id, d.uploaded_by, d.title, d.filename, d.mime_type,
+			d.id, d.org_id, d.project_id, d.task_id, d.uploaded_by, d.title, d.filename, d.mime_type,
 			d.file_size, d.sha256, d.storage_path, d.extracted_text, d.status,
 			d.verified_by, d.verified_at, d.verification_notes, d.created_at, d.updated_at,
 			CASE WHEN u.id IS NULL THEN NULL ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')) END AS uploaded_by_name,
@@ -168,12 +156,13 @@ func (r *DocumentRepository) GetByID(ctx context.Context, orgID, documentID uuid
 		FROM documents d
 		LEFT JOIN users u ON u.id = d.uploaded_by
 		LEFT JOIN users v ON v.id = d.verified_by
-		WHERE d.org_id = $1 AND d.id = $2
+		WHERE d.org_id = $1 AND d.id = $2 AND d.deleted_at IS NULL
 	`
 	var d models.Document
 	err := r.db.QueryRowContext(ctx, query, orgID, documentID).Scan(
 		&d.ID,
 		&d.OrgID,
+		&d.ProjectID,
 		&d.TaskID,
 		&d.UploadedBy,
 		&d.Title,
@@ -205,7 +194,7 @@ func (r *DocumentRepository) UpdateStatus(ctx context.Context, orgID, documentID
 	query := `
 		UPDATE documents
 		SET status = $1, verified_by = $2, verified_at = NOW(), verification_notes = $3, updated_at = NOW()
-		WHERE org_id = $4 AND id = $5
+		WHERE org_id = $4 AND id = $5 AND deleted_at IS NULL
 	`
 	result, err := r.db.ExecContext(ctx, query, status, verifiedBy, notes, orgID, documentID)
 	if err != nil {
@@ -225,59 +214,16 @@ func (r *DocumentRepository) ListPending(ctx context.Context, orgID uuid.UUID, l
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
-	query := `
-		SELECT 
-			d.id, d.org_id, d.task_id, d.uploaded_by, d.title, d.filename, d.mime_type, 
-			d.file_size, d.sha256, d.status, d.verified_by, d.verified_at, d.verification_notes,
-			d.created_at, d.updated_at,
-			CASE WHEN u.id IS NULL THEN NULL ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')) END AS uploaded_by_name,
-			CASE WHEN v.id IS NULL THEN NULL ELSE CONCAT(COALESCE(v.first_name, ''), ' ', COALESCE(v.last_name, '')) END AS verified_by_name
-		FROM documents d
-		LEFT JOIN users u ON u.id = d.uploaded_by
-		LEFT JOIN users v ON v.id = d.verified_by
-		WHERE d.org_id = $1 AND d.status = 'submitted'
+	query := documentListSelect + `
+		WHERE d.org_id = $1 AND d.status = 'submitted' AND d.deleted_at IS NULL
 		ORDER BY d.created_at ASC
 		LIMIT $2
 	`
-
-	rows, err := r.db.QueryContext(ctx, query, orgID, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	out := []models.Document{}
-	for rows.Next() {
-		var d models.Document
-		err := rows.Scan(
-			&d.ID,
-			&d.OrgID,
-			&d.TaskID,
-			&d.UploadedBy,
-			&d.Title,
-			&d.Filename,
-			&d.MimeType,
-			&d.FileSize,
-			&d.SHA256,
-			&d.Status,
-			&d.VerifiedBy,
-			&d.VerifiedAt,
-			&d.VerificationNotes,
-			&d.CreatedAt,
-			&d.UpdatedAt,
-			&d.UploadedByName,
-			&d.VerifiedByName,
-		)
-		if err != nil {
-			return nil, err
-		}
-		out = append(out, d)
-	}
-	return out, rows.Err()
+	return r.queryDocuments(ctx, query, orgID, limit)
 }
 
 func (r *DocumentRepository) GetExtractedText(ctx context.Context, orgID, documentID uuid.UUID) (string, error) {
-	query := `SELECT COALESCE(extracted_text, '') FROM documents WHERE org_id = $1 AND id = $2`
+	query := `SELECT COALESCE(extracted_text, '') FROM documents WHERE org_id = $1 AND id = $2 AND deleted_at IS NULL`
 	var text string
 	err := r.db.QueryRowContext(ctx, query, orgID, documentID).Scan(&text)
 	if err == sql.ErrNoRows {
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type HistoryRepository struct {
+	db *sql.DB
+}
+
+func NewHistoryRepository(db *sql.DB) *HistoryRepository {
+	return &HistoryRepository{db: db}
+}
+
+// AddTaskEntry records a change made outside the task write paths, such as
+// automatic blocking or a sprint move.
+func (r *HistoryRepository) AddTaskEntry(orgID, taskID uuid.UUID, entry *models.HistoryEntry) error {
+	return insertTaskHistory(r.db, orgID, taskID, entry)
+}
+
+// insertTaskHistory appends entry to a task's history, typically in the
+// transaction that made the change. A nil entry or one without changes is
+// skipped.
+func insertTaskHistory(q queryRower, orgID, taskID uuid.UUID, entry *models.HistoryEntry) error {
+	return insertHistory(q, `
+		INSERT INTO task_history (id, org_id, task_id, user_id, changes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, orgID, taskID, entry)
+}
+
+// ListTaskHistory returns a task's change history, newest first.
+func (r *HistoryRepository) ListTaskHistory(orgID, taskID uuid.UUID) ([]models.HistoryEntry, error) {
+	return r.list(`
+		SELECT h.id, h.user_id, h.changes, h.created_at,
+			CASE
+				WHEN u.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, ''))
+			END AS user_name
+		FROM task_history h
+		LEFT JOIN users u ON u.id = h.user_id
+		WHERE h.org_id = $1 AND h.task_id = $2
+		ORDER BY h.created_at DESC, h.id DESC
+	`, orgID, taskID)
+}
+
+func (r *HistoryRepository) AddIssueEntry(orgID, issueID uuid.UUID, entry *models.HistoryEntry) error {
+	return insertIssueHistory(r.db, orgID, issueID, entry)
+}
+
+// insertIssueHistory is the issue counterpart of insertTaskHistory.
+func insertIssueHistory(q queryRower, orgID, issueID uuid.UUID, entry *models.HistoryEntry) error {
+	return insertHistory(q, `
+		INSERT INTO issue_history (id, org_id, issue_id, user_id, changes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, orgID, issueID, entry)
+}
+
+// ListIssueHistory returns an issue's change history, newest first.
+func (r *HistoryRepository) ListIssueHistory(orgID, issueID uuid.UUID) ([]models.HistoryEntry, error) {
+	return r.list(`
+		SELECT h.id, h.user_id, h.changes, h.created_at,
+			CASE
+				WHEN u.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, ''))
+			END AS user_name
+		FROM issue_history h
+		LEFT JOIN users u ON u.id = h.user_id
+		WHERE h.org_id = $1 AND h.issue_id = $2
+		ORDER BY h.created_at DESC, h.id DESC
+	`, orgID, issueID)
+}
+
+func insertHistory(q queryRower, query string, orgID, entityID uuid.UUID, entry *models.HistoryEntry) error {
+	if entry == nil || len(entry.Changes) == 0 {
+		return nil
+	}
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode changes: %w", err)
+	}
+	return q.QueryRow(query, entry.ID, orgID, entityID, entry.UserID, changes).Scan(&entry.CreatedAt)
+}
+
+func (r *HistoryRepository) list(query string, orgID, entityID uuid.UUID) ([]models.HistoryEntry, error) {
+	rows, err := r.db.Query(query, orgID, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.HistoryEntry{}
+	for rows.Next() {
+		var entry models.HistoryEntry
+		var changes []byte
+		if err := rows.Scan(&entry.ID, &entry.UserID, &changes, &entry.CreatedAt, &entry.UserName); err != nil {
+			return nil, err
+		}
+		entry.Changes = []models.FieldChange{}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode changes: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type IssueLinkRepository struct {
+	db *sql.DB
+}
+
+func NewIssueLinkRepository(db *sql.DB) *IssueLinkRepository {
+	return &IssueLinkRepository{db: db}
+}
+
+// ResolvedIssue is an issue ResolveFixedIssues moved to resolved.
+type ResolvedIssue struct {
+	ID         uuid.UUID
+	FromStatus string
+}
+
+const issueLinkColumns = `l.id, l.issue_id, l.link_type, l.task_id, l.linked_issue_id, l.created_by, l.created_at`
+
+func (r *IssueLinkRepository) Create(orgID uuid.UUID, link *models.IssueLink) error {
+	query := `
+		INSERT INTO issue_links (id, org_id, issue_id, link_type, task_id, linked_issue_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+	err := r.db.QueryRow(
+		query,
+		link.ID,
+		orgID,
+		link.IssueID,
+		link.LinkType,
+		link.TaskID,
+		link.LinkedIssueID,
+		link.CreatedBy,
+	).Scan(&link.CreatedAt)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("link already exists")
+	}
+	return err
+}
+
+// GetByID returns a link of the issue without its target filled in.
+func (r *IssueLinkRepository) GetByID(orgID, issueID, linkID uuid.UUID) (*models.IssueLink, error) {
+	query := `SELECT ` + issueLinkColumns + ` FROM issue_links l WHERE l.org_id = $1 AND l.issue_id = $2 AND l.id = $3`
+	var link models.IssueLink
+	err := r.db.QueryRow(query, orgID, issueID, linkID).Scan(
+		&link.ID, &link.IssueID, &link.LinkType, &link.TaskID, &link.LinkedIssueID, &link.CreatedBy, &link.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *IssueLinkRepository) Delete(orgID, linkID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM issue_links WHERE org_id = $1 AND id = $2`, orgID, linkID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("link not found")
+	}
+	return nil
+}
+
+// ListForIssue returns the issue's links, including those from other issues
+// that link to it, oldest first. Links to trashed tasks or issues are left
+// out.
+func (r *IssueLinkRepository) ListForIssue(orgID, issueID uuid.UUID) ([]models.IssueLink, error) {
+	query := `
+		SELECT ` + issueLinkColumns + `,
+			CASE WHEN l.task_id IS NOT NULL THEN 'task' ELSE 'issue' END,
+			COALESCE(t.id, li.id), COALESCE(t.title, li.title), COALESCE(t.status, li.status)
+		FROM issue_links l
+		LEFT JOIN tasks t ON t.id = l.task_id AND t.deleted_at IS NULL
+		LEFT JOIN issues li ON li.id = l.linked_issue_id AND li.deleted_at IS NULL
+		WHERE l.org_id = $1 AND l.issue_id = $2 AND (t.id IS NOT NULL OR li.id IS NOT NULL)
+		UNION ALL
+		SELECT ` + issueLinkColumns + `, 'issue', i.id, i.title, i.status
+		FROM issue_links l
+		JOIN issues i ON i.id = l.issue_id
+		WHERE l.org_id = $1 AND l.linked_issue_id = $2 AND i.deleted_at IS NULL
+		ORDER BY created_at ASC
+	`
+	return r.queryLinks(query, orgID, issueID)
+}
+
+// ListForTask returns the links between issues and the task, oldest first.
+func (r *IssueLinkRepository) ListForTask(orgID, taskID uuid.UUID) ([]models.IssueLink, error) {
+	query := `
+		SELECT ` + issueLinkColumns + `, 'issue', i.id, i.title, i.status
+		FROM issue_links l
+		JOIN issues i ON i.id = l.issue_id
+		WHERE l.org_id = $1 AND l.task_id = $2 AND i.deleted_at IS NULL
+		ORDER BY l.created_at ASC
+	`
+	return r.queryLinks(query, orgID, taskID)
+}
+
+func (r *IssueLinkRepository) queryLinks(query string, args ...interface{}) ([]models.IssueLink, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []models.IssueLink{}
+	for rows.Next() {
+		var link models.IssueLink
+		if err := rows.Scan(
+			&link.ID, &link.IssueID, &link.LinkType, &link.TaskID, &link.LinkedIssueID, &link.CreatedBy, &link.CreatedAt,
+			&link.Target.Type, &link.Target.ID, &link.Target.Title, &link.Target.Status,
+		); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// ResolveFixedIssues resolves the open issues an approved taskID fixes whose
+// other fixing tasks are all approved too. Trashed tasks do not hold an issue
+// open.
+func (r *IssueLinkRepository) ResolveFixedIssues(orgID, taskID uuid.UUID) ([]ResolvedIssue, error) {
+	query := `
+		WITH fixed AS (
+			SELECT i.id, i.status FROM issues i
+			WHERE i.org_id = $1 AND i.deleted_at IS NULL AND i.status IN ('open', 'in_progress')
+				AND EXISTS (SELECT 1 FROM tasks WHERE id = $2 AND ` + signedOffStatus("status", "$1") + ` AND deleted_at IS NULL)
+				AND i.id IN (SELECT issue_id FROM issue_links WHERE task_id = $2 AND link_type = 'fixes')
+				AND NOT EXISTS (
+					SELECT 1 FROM issue_links l JOIN tasks t ON t.id = l.task_id
+					WHERE l.issue_id = i.id AND l.link_type = 'fixes' AND t.deleted_at IS NULL AND NOT ` + signedOffStatus("t.status", "$1") + `
+				)
+			FOR UPDATE OF i
+		)
+		UPDATE issues
+		SET status = 'resolved', resolved_at = COALESCE(issues.resolved_at, CURRENT_TIMESTAMP), version = issues.version + 1
+		FROM fixed
+		WHERE issues.id = fixed.id
+		RETURNING issues.id, fixed.status
+	`
+	rows, err := r.db.Query(query, orgID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resolved := []ResolvedIssue{}
+	for rows.Next() {
+		var ri ResolvedIssue
+		if err := rows.Scan(&ri.ID, &ri.FromStatus); err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, ri)
+	}
+	return resolved, rows.Err()
+}
@@ -18,15 +18,20 @@ func NewIssueRepository(db *sql.DB) *IssueRepository {
 }
 
 func (r *IssueRepository) Create(issue *models.Issue) error {
+	customFields, err := encodeCustomFields(issue.CustomFields)
+	if err != nil {
+		return err
+	}
 	query := `
-		INSERT INTO issues (id, org_id, title, description, severity, status, reported_by, assigned_to, ai_summary)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING created_at, updated_at
+		INSERT INTO issues (id, org_id, project_id, title, description, severity, status, reported_by, assigned_to, ai_summary, custom_fields)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING created_at, updated_at, version
 	`
 	return r.db.QueryRow(
 		query,
 		issue.ID,
 		issue.OrgID,
+		issue.ProjectID,
 		issue.Title,
 		issue.Description,
 		issue.Severity,
@@ -34,27 +39,31 @@ func (r *IssueRepository) Create(issue *models.Issue) error {
 		issue.ReportedBy,
 		issue.AssignedTo,
 		issue.AISummary,
-	).Scan(&issue.CreatedAt, &issue.UpdatedAt)
+		customFields,
+	).Scan(&issue.CreatedAt, &issue.UpdatedAt, &issue.Version)
 }
 
-func (r *IssueRepository) GetByID(orgID, issueID uuid.UUID) (*models.Issue, error) {
-	query := `
+// issueSelect is the shared projection for issue reads.
+var issueSelect = `
 		SELECT
-			i.id, i.org_id, i.title, i.description, i.severity, i.status, i.reported_by, i.assigned_to, i.ai_summary, i.created_at, i.updated_at, i.resolved_at,
+			i.id, i.org_id, i.project_id, i.title, i.description, i.severity, i.status, i.reported_by, i.assigned_to, i.ai_summary, i.created_at, i.updated_at, i.resolved_at, i.custom_fields, i.version,
 			CONCAT(COALESCE(ru.first_name, ''), ' ', COALESCE(ru.last_name, '')) AS reported_by_name,
 			CASE
 				WHEN au.id IS NULL THEN NULL
 				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
-			END AS assigned_to_name
+			END AS assigned_to_name,
+			` + labelsSubquery("issue_labels", "issue_id", "i.id") + ` AS labels
 		FROM issues i
 		LEFT JOIN users ru ON ru.id = i.reported_by
 		LEFT JOIN users au ON au.id = i.assigned_to
-		WHERE i.org_id = $1 AND i.id = $2
-	`
-	issue := &models.Issue{}
-	err := r.db.QueryRow(query, orgID, issueID).Scan(
+`
+
+func scanIssue(row rowScanner, issue *models.Issue) error {
+	var labels, customFields []byte
+	err := row.Scan(
 		&issue.ID,
 		&issue.OrgID,
+		&issue.ProjectID,
 		&issue.Title,
 		&issue.Description,
 		&issue.Severity,
@@ -65,45 +74,24 @@ func (r *IssueRepository) GetByID(orgID, issueID uuid.UUID) (*models.Issue, erro
 		&issue.CreatedAt,
 		&issue.UpdatedAt,
 		&issue.ResolvedAt,
+		&customFields,
+		&issue.Version,
 		&issue.ReportedByName,
 		&issue.AssignedToName,
+		&labels,
 	)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-	return issue, err
-}
-
-func (r *IssueRepository) List(orgID uuid.UUID, status string, severity string) ([]models.Issue, error) {
-	base := `
-		SELECT
-			i.id, i.org_id, i.title, i.description, i.severity, i.status, i.reported_by, i.assigned_to, i.ai_summary, i.created_at, i.updated_at, i.resolved_at,
-			CONCAT(COALESCE(ru.first_name, ''), ' ', COALESCE(ru.last_name, '')) AS reported_by_name,
-			CASE
-				WHEN au.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
-			END AS assigned_to_name
-		FROM issues i
-		LEFT JOIN users ru ON ru.id = i.reported_by
-		LEFT JOIN users au ON au.id = i.assigned_to
-		WHERE i.org_id = $1
-	`
-
-	args := []interface{}{orgID}
-	argIdx := 2
-	if status != "" {
-		base += fmt.Sprintf(" AND i.status = $%d", argIdx)
-		args = append(args, status)
-		argIdx++
+	if err != nil {
+		return err
 	}
-	if severity != "" {
-		base += fmt.Sprintf(" AND i.severity = $%d", argIdx)
-		args = append(args, severity)
-		argIdx++
+	if issue.Labels, err = decodeLabels(labels); err != nil {
+		return err
 	}
-	base += " ORDER BY i.created_at DESC"
+	issue.CustomFields, err = decodeCustomFields(customFields)
+	return err
+}
 
-	rows, err := r.db.Query(base, args...)
+func (r *IssueRepository) queryIssues(query string, args ...interface{}) ([]models.Issue, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -112,23 +100,7 @@ func (r *IssueRepository) List(orgID uuid.UUID, status string, severity string)
 	issues := []models.Issue{}
 	for rows.Next() {
 		var issue models.Issue
-		err := rows.Scan(
-			&issue.ID,
-			&issue.OrgID,
-			&issue.Title,
-			&issue.Description,
-			&issue.Severity,
-			&issue.Status,
-			&issue.ReportedBy,
-			&issue.AssignedTo,
-			&issue.AISummary,
-			&issue.CreatedAt,
-			&issue.UpdatedAt,
-			&issue.ResolvedAt,
-			&issue.ReportedByName,
-			&issue.AssignedToName,
-		)
-		if err != nil {
+		if err := scanIssue(rows, &issue); err != nil {
 			return nil, err
 		}
 		issues = append(issues, issue)
@@ -136,75 +108,162 @@ func (r *IssueRepository) List(orgID uuid.UUID, status string, severity string)
 	return issues, rows.Err()
 }
 
-func (r *IssueRepository) ListForUser(orgID uuid.UUID, userID uuid.UUID, status string, severity string) ([]models.Issue, error) {
-	base := `
-		SELECT
-			i.id, i.org_id, i.title, i.description, i.severity, i.status, i.reported_by, i.assigned_to, i.ai_summary, i.created_at, i.updated_at, i.resolved_at,
-			CONCAT(COALESCE(ru.first_name, ''), ' ', COALESCE(ru.last_name, '')) AS reported_by_name,
-			CASE
-				WHEN au.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
-			END AS assigned_to_name
-		FROM issues i
-		LEFT JOIN users ru ON ru.id = i.reported_by
-		LEFT JOIN users au ON au.id = i.assigned_to
-		WHERE i.org_id = $1 AND (i.reported_by = $2 OR i.assigned_to = $2)
+func (r *IssueRepository) GetByID(orgID, issueID uuid.UUID) (*models.Issue, error) {
+	query := issueSelect + `
+		WHERE i.org_id = $1 AND i.id = $2 AND i.deleted_at IS NULL
+	`
+	issue := &models.Issue{}
+	err := scanIssue(r.db.QueryRow(query, orgID, issueID), issue)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return issue, err
+}
+
+// issueListWhere builds the filtered issue query without an ORDER BY,
+// returning the next free placeholder index.
+func issueListWhere(orgID uuid.UUID, filter models.IssueListFilter) (string, []interface{}, int, error) {
+	base := issueSelect + `
+		WHERE i.org_id = $1 AND i.deleted_at IS NULL
 	`
 
-	args := []interface{}{orgID, userID}
-	argIdx := 3
-	if status != "" {
+	args := []interface{}{orgID}
+	argIdx := 2
+	if filter.VisibleTo != nil {
+		base += fmt.Sprintf(" AND (i.reported_by = $%d OR i.assigned_to = $%d)", argIdx, argIdx)
+		args = append(args, *filter.VisibleTo)
+		argIdx++
+	}
+	if filter.Status != "" {
 		base += fmt.Sprintf(" AND i.status = $%d", argIdx)
-		args = append(args, status)
+		args = append(args, filter.Status)
 		argIdx++
 	}
-	if severity != "" {
+	if filter.Severity != "" {
 		base += fmt.Sprintf(" AND i.severity = $%d", argIdx)
-		args = append(args, severity)
+		args = append(args, filter.Severity)
 		argIdx++
 	}
-	base += " ORDER BY i.created_at DESC"
+	if filter.ProjectID != nil {
+		base += fmt.Sprintf(" AND i.project_id = $%d", argIdx)
+		args = append(args, *filter.ProjectID)
+		argIdx++
+	}
+	if filter.WatcherID != nil {
+		base += watcherFilterClause("issue_watchers", "issue_id", "i.id", argIdx)
+		args = append(args, *filter.WatcherID)
+		argIdx++
+	}
+	if clause, labelArgs, next := labelFilterClause(filter.Labels, "issue_labels", "issue_id", "i.id", argIdx); clause != "" {
+		base += clause
+		args = append(args, labelArgs...)
+		argIdx = next
+	}
+	clause, fieldArgs, next, err := customFieldFilterClause(filter.CustomFields, "i.custom_fields", argIdx)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	base += clause
+	args = append(args, fieldArgs...)
+	argIdx = next
+	if filter.Query != "" {
+		clause, queryArgs, next, err := queryClause(filter.Query, issueQueryTarget, filter.Viewer, argIdx)
+		if err != nil {
+			return "", nil, 0, err
+		}
+		base += clause
+		args = append(args, queryArgs...)
+		argIdx = next
+	}
+	return base, args, argIdx, nil
+}
 
-	rows, err := r.db.Query(base, args...)
+func (r *IssueRepository) List(orgID uuid.UUID, filter models.IssueListFilter) ([]models.Issue, error) {
+	base, args, _, err := issueListWhere(orgID, filter)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	base += " ORDER BY i.created_at DESC"
 
-	issues := []models.Issue{}
-	for rows.Next() {
-		var issue models.Issue
-		err := rows.Scan(
-			&issue.ID,
-			&issue.OrgID,
-			&issue.Title,
-			&issue.Description,
-			&issue.Severity,
-			&issue.Status,
-			&issue.ReportedBy,
-			&issue.AssignedTo,
-			&issue.AISummary,
-			&issue.CreatedAt,
-			&issue.UpdatedAt,
-			&issue.ResolvedAt,
-			&issue.ReportedByName,
-			&issue.AssignedToName,
-		)
-		if err != nil {
-			return nil, err
-		}
-		issues = append(issues, issue)
+	return r.queryIssues(base, args...)
+}
+
+var issueSeverities = []string{"low", "medium", "high", "critical"}
+
+var issueKeyset = keyset{
+	idExpr:      "i.id",
+	defaultSort: "created_at",
+	columns: map[string]sortColumn{
+		"created_at": {expr: "i.created_at", cast: "timestamptz", defaultOrder: "desc"},
+		"updated_at": {expr: "i.updated_at", cast: "timestamptz", defaultOrder: "desc"},
+		"severity":   {expr: rankExpr("i.severity", issueSeverities), cast: "int", defaultOrder: "desc"},
+	},
+}
+
+// ListPage returns one page of issues and the cursor for the next page, which
+// is empty on the last one.
+func (r *IssueRepository) ListPage(orgID uuid.UUID, filter models.IssueListFilter, page models.PageRequest) ([]models.Issue, string, error) {
+	plan, err := issueKeyset.plan(page)
+	if err != nil {
+		return nil, "", err
 	}
-	return issues, rows.Err()
+	base, args, argIdx, err := issueListWhere(orgID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	base, args = plan.apply(issueKeyset, base, args, argIdx)
+
+	issues, err := r.queryIssues(base, args...)
+	if err != nil || !plan.more(len(issues)) {
+		return issues, "", err
+	}
+	issues = issues[:plan.limit]
+	last := issues[len(issues)-1]
+	var value string
+	switch plan.sort {
+	case "updated_at":
+		value = cursorTime(last.UpdatedAt)
+	case "severity":
+		value = rankOf(last.Severity, issueSeverities)
+	default:
+		value = cursorTime(last.CreatedAt)
+	}
+	return issues, plan.next(value, last.ID), nil
 }
 
-func (r *IssueRepository) Update(issue *models.Issue) error {
+// Update saves an issue and the history entry describing the change in one
+// transaction.
+func (r *IssueRepository) Update(issue *models.Issue, history *models.HistoryEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := updateIssue(tx, issue); err != nil {
+		return err
+	}
+	if err := insertIssueHistory(tx, issue.OrgID, issue.ID, history); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateIssue saves an issue only if its version still matches the one read,
+// and advances the version.
+func updateIssue(q queryRower, issue *models.Issue) error {
+	customFields, err := encodeCustomFields(issue.CustomFields)
+	if err != nil {
+		return err
+	}
 	query := `
 		UPDATE issues
-		SET title = $1, description = $2, severity = $3, status = $4, assigned_to = $5, ai_summary = $6, resolved_at = $7
-		WHERE org_id = $8 AND id = $9
+		SET title = $1, description = $2, severity = $3, status = $4, assigned_to = $5, ai_summary = $6, resolved_at = $7, project_id = $8, custom_fields = $9,
+			version = version + 1
+		WHERE org_id = $10 AND id = $11 AND version = $12 AND deleted_at IS NULL
+		RETURNING updated_at, version
 	`
-	result, err := r.db.Exec(
+	err = q.QueryRow(
 		query,
 		issue.Title,
 		issue.Description,
@@ -213,36 +272,24 @@ func (r *IssueRepository) Update(issue *models.Issue) error {
 		issue.AssignedTo,
 		issue.AISummary,
 		issue.ResolvedAt,
+		issue.ProjectID,
+		customFields,
 		issue.OrgID,
 		issue.ID,
-	)
-	if err != nil {
-		return err
-	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rows == 0 {
-		return fmt.Errorf("issue not found")
+		issue.Version,
+	).Scan(&issue.UpdatedAt, &issue.Version)
+	if err == sql.ErrNoRows {
+		return versionMiss(q, "issues", "issue", issue.OrgID, issue.ID)
 	}
-	return nil
+	return err
 }
 
-func (r *IssueRepository) Delete(orgID, issueID uuid.UUID) error {
-	query := `DELETE FROM issues WHERE org_id = $1 AND id = $2`
-	result, err := r.db.Exec(query, orgID, issueID)
-	if err != nil {
-		return err
-	}
+// SoftDelete moves an issue to the trash.
+func (r *IssueRepository) SoftDelete(orgID, issueID, deletedBy uuid.UUID) error {
+	return softDelete(r.db, "issues", "issue", orgID, issueID, deletedBy)
+}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rows == 0 {
-		return fmt.Errorf("issue not found")
-	}
-	return nil
+// Restore takes an issue back out of the trash.
+func (r *IssueRepository) Restore(orgID, issueID uuid.UUID) error {
+	return restore(r.db, "issues", "issue", orgID, issueID)
 }
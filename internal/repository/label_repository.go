@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type LabelRepository struct {
+	db *sql.DB
+}
+
+func NewLabelRepository(db *sql.DB) *LabelRepository {
+	return &LabelRepository{db: db}
+}
+
+// labelsSubquery renders a JSON array of the labels attached through joinTable,
+// for embedding in task and issue projections.
+func labelsSubquery(joinTable, fkColumn, entityRef string) string {
+	return fmt.Sprintf(`COALESCE((
+				SELECT json_agg(json_build_object('id', l.id, 'org_id', l.org_id, 'name', l.name, 'color', l.color, 'created_at', l.created_at, 'updated_at', l.updated_at) ORDER BY l.name)
+				FROM %s x JOIN labels l ON l.id = x.label_id
+				WHERE x.%s = %s
+			), '[]')`, joinTable, fkColumn, entityRef)
+}
+
+func decodeLabels(raw []byte) ([]models.Label, error) {
+	labels := []models.Label{}
+	if len(raw) == 0 {
+		return labels, nil
+	}
+	if err := json.Unmarshal(raw, &labels); err != nil {
+		return nil, fmt.Errorf("failed to decode labels: %w", err)
+	}
+	return labels, nil
+}
+
+// labelFilterClause appends a label condition for the entity identified by
+// entityRef, returning the SQL fragment, its args, and the next arg index.
+func labelFilterClause(filter models.LabelFilter, joinTable, fkColumn, entityRef string, argIdx int) (string, []interface{}, int) {
+	names := make([]string, 0, len(filter.Names))
+	seen := make(map[string]bool, len(filter.Names))
+	for _, n := range filter.Names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		names = append(names, n)
+	}
+	if len(names) == 0 {
+		return "", nil, argIdx
+	}
+
+	from := fmt.Sprintf(`FROM %s x JOIN labels l ON l.id = x.label_id WHERE x.%s = %s AND LOWER(l.name) = ANY($%d)`,
+		joinTable, fkColumn, entityRef, argIdx)
+	if filter.MatchAll {
+		clause := fmt.Sprintf(" AND (SELECT COUNT(DISTINCT l.id) %s) = $%d", from, argIdx+1)
+		return clause, []interface{}{pq.Array(names), len(names)}, argIdx + 2
+	}
+	return " AND EXISTS (SELECT 1 " + from + ")", []interface{}{pq.Array(names)}, argIdx + 1
+}
+
+func (r *LabelRepository) Create(label *models.Label) error {
+	query := `
+		INSERT INTO labels (id, org_id, name, color)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(query, label.ID, label.OrgID, label.Name, label.Color).Scan(&label.CreatedAt, &label.UpdatedAt)
+	return mapLabelError(err)
+}
+
+func (r *LabelRepository) GetByID(orgID, labelID uuid.UUID) (*models.Label, error) {
+	query := `
+		SELECT id, org_id, name, color, created_at, updated_at
+		FROM labels
+		WHERE org_id = $1 AND id = $2
+	`
+	label := &models.Label{}
+	err := r.db.QueryRow(query, orgID, labelID).Scan(
+		&label.ID,
+		&label.OrgID,
+		&label.Name,
+		&label.Color,
+		&label.CreatedAt,
+		&label.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return label, err
+}
+
+func (r *LabelRepository) List(orgID uuid.UUID) ([]models.Label, error) {
+	query := `
+		SELECT id, org_id, name, color, created_at, updated_at
+		FROM labels
+		WHERE org_id = $1
+		ORDER BY LOWER(name) ASC
+	`
+	rows, err := r.db.Query(query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := []models.Label{}
+	for rows.Next() {
+		var label models.Label
+		if err := rows.Scan(&label.ID, &label.OrgID, &label.Name, &label.Color, &label.CreatedAt, &label.UpdatedAt); err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
+
+func (r *LabelRepository) Update(label *models.Label) error {
+	query := `
+		UPDATE labels
+		SET name = $1, color = $2
+		WHERE org_id = $3 AND id = $4
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(query, label.Name, label.Color, label.OrgID, label.ID).Scan(&label.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("label not found")
+	}
+	return mapLabelError(err)
+}
+
+func (r *LabelRepository) Delete(orgID, labelID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM labels WHERE org_id = $1 AND id = $2`, orgID, labelID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("label not found")
+	}
+	return nil
+}
+
+func mapLabelError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("a label with this name already exists")
+	}
+	return err
+}
+
+func (r *LabelRepository) AttachToTask(taskID, labelID uuid.UUID) error {
+	_, err := r.db.Exec(`INSERT INTO task_labels (task_id, label_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, taskID, labelID)
+	return err
+}
+
+func (r *LabelRepository) DetachFromTask(taskID, labelID uuid.UUID) error {
+	return r.detach(`DELETE FROM task_labels WHERE task_id = $1 AND label_id = $2`, taskID, labelID)
+}
+
+func (r *LabelRepository) AttachToIssue(issueID, labelID uuid.UUID) error {
+	_, err := r.db.Exec(`INSERT INTO issue_labels (issue_id, label_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, issueID, labelID)
+	return err
+}
+
+func (r *LabelRepository) DetachFromIssue(issueID, labelID uuid.UUID) error {
+	return r.detach(`DELETE FROM issue_labels WHERE issue_id = $1 AND label_id = $2`, issueID, labelID)
+}
+
+func (r *LabelRepository) detach(query string, entityID, labelID uuid.UUID) error {
+	result, err := r.db.Exec(query, entityID, labelID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("label is not attached")
+	}
+	return nil
+}
+
+// ListTaggedIDs returns the tasks and issues carrying a label, so they can be
+// re-indexed when the label changes.
+func (r *LabelRepository) ListTaggedIDs(labelID uuid.UUID) (taskIDs, issueIDs []uuid.UUID, err error) {
+	taskIDs, err = r.listIDs(`SELECT task_id FROM task_labels WHERE label_id = $1`, labelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	issueIDs, err = r.listIDs(`SELECT issue_id FROM issue_labels WHERE label_id = $1`, labelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return taskIDs, issueIDs, nil
+}
+
+func (r *LabelRepository) listIDs(query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
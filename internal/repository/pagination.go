@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// sortColumn is one field a listing can be ordered by. The expression must
+// never be NULL so that row comparisons against a cursor are well defined.
+type sortColumn struct {
+	expr         string
+	cast         string
+	defaultOrder string
+}
+
+// keyset pages a listing by one sort column, using the row ID to break ties.
+type keyset struct {
+	idExpr      string
+	columns     map[string]sortColumn
+	defaultSort string
+}
+
+// pageCursor is the decoded form of the opaque cursor handed to clients. It
+// records the sort it was issued for so it cannot be replayed against another.
+type pageCursor struct {
+	Sort  string    `json:"s"`
+	Order string    `json:"o"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// pagePlan is a resolved page request.
+type pagePlan struct {
+	sort   string
+	order  string
+	limit  int
+	column sortColumn
+	after  *pageCursor
+}
+
+func (k keyset) plan(page models.PageRequest) (*pagePlan, error) {
+	p := &pagePlan{sort: page.Sort, order: strings.ToLower(page.Order), limit: page.Limit}
+	if p.sort == "" {
+		p.sort = k.defaultSort
+	}
+	col, ok := k.columns[p.sort]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort field: %s", p.sort)
+	}
+	p.column = col
+	if p.order == "" {
+		p.order = col.defaultOrder
+	}
+	if p.order != "asc" && p.order != "desc" {
+		return nil, fmt.Errorf("invalid order: %s", page.Order)
+	}
+	if p.limit < 1 {
+		p.limit = defaultPageLimit
+	}
+	if p.limit > maxPageLimit {
+		p.limit = maxPageLimit
+	}
+
+	if page.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+		var cur pageCursor
+		if err := json.Unmarshal(raw, &cur); err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+		if cur.Sort != p.sort || cur.Order != p.order {
+			return nil, fmt.Errorf("cursor does not match sort and order")
+		}
+		p.after = &cur
+	}
+	return p, nil
+}
+
+// apply appends the cursor condition, ordering and limit to a query whose
+// WHERE clause is already open. It fetches one extra row so callers can tell
+// whether another page follows.
+func (p *pagePlan) apply(k keyset, query string, args []interface{}, argIdx int) (string, []interface{}) {
+	if p.after != nil {
+		op := ">"
+		if p.order == "desc" {
+			op = "<"
+		}
+		query += fmt.Sprintf(" AND (%s, %s) %s ($%d::%s, $%d)", p.column.expr, k.idExpr, op, argIdx, p.column.cast, argIdx+1)
+		args = append(args, p.after.Value, p.after.ID)
+		argIdx += 2
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT $%d", p.column.expr, p.order, k.idExpr, p.order, argIdx)
+	args = append(args, p.limit+1)
+	return query, args
+}
+
+// more reports whether a result of n rows has another page after it.
+func (p *pagePlan) more(n int) bool {
+	return n > p.limit
+}
+
+// next encodes the cursor that resumes after the row with this sort value.
+func (p *pagePlan) next(value string, id uuid.UUID) string {
+	raw, _ := json.Marshal(pageCursor{Sort: p.sort, Order: p.order, Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// cursorTime formats a timestamp for a cursor without losing precision.
+func cursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// cursorOptionalTime treats a missing time as later than any other, matching
+// a COALESCE(column, 'infinity') sort expression.
+func cursorOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "infinity"
+	}
+	return cursorTime(*t)
+}
+
+// rankExpr maps an enumerated column onto its position in values, so that,
+// for example, priorities sort by urgency rather than alphabetically.
+func rankExpr(column string, values []string) string {
+	var b strings.Builder
+	b.WriteString("CASE " + column)
+	for i, v := range values {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", v, i+1)
+	}
+	b.WriteString(" ELSE 0 END")
+	return b.String()
+}
+
+// rankOf is the Go counterpart of rankExpr.
+func rankOf(value string, values []string) string {
+	for i, v := range values {
+		if v == value {
+			return strconv.Itoa(i + 1)
+		}
+	}
+	return "0"
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var testKeyset = keyset{
+	idExpr: "x.id",
+	columns: map[string]sortColumn{
+		"created_at": {expr: "x.created_at", cast: "timestamptz", defaultOrder: "desc"},
+		"title":      {expr: "LOWER(x.title)", cast: "text", defaultOrder: "asc"},
+	},
+	defaultSort: "created_at",
+}
+
+func TestPlanDefaults(t *testing.T) {
+	tests := []struct {
+		page  models.PageRequest
+		sort  string
+		order string
+		limit int
+	}{
+		{models.PageRequest{}, "created_at", "desc", defaultPageLimit},
+		{models.PageRequest{Sort: "title"}, "title", "asc", defaultPageLimit},
+		{models.PageRequest{Sort: "title", Order: "DESC", Limit: 10}, "title", "desc", 10},
+		{models.PageRequest{Limit: maxPageLimit + 1}, "created_at", "desc", maxPageLimit},
+		{models.PageRequest{Limit: -5}, "created_at", "desc", defaultPageLimit},
+	}
+	for _, tt := range tests {
+		p, err := testKeyset.plan(tt.page)
+		if err != nil {
+			t.Fatalf("plan(%+v) error: %v", tt.page, err)
+		}
+		if p.sort != tt.sort || p.order != tt.order || p.limit != tt.limit || p.after != nil {
+			t.Errorf("plan(%+v) = %s %s %d, want %s %s %d", tt.page, p.sort, p.order, p.limit, tt.sort, tt.order, tt.limit)
+		}
+	}
+}
+
+func TestPlanRejectsInvalidRequests(t *testing.T) {
+	valid, _ := testKeyset.plan(models.PageRequest{Sort: "title"})
+	cursor := valid.next("alpha", uuid.New())
+
+	tests := []struct {
+		name string
+		page models.PageRequest
+		want string
+	}{
+		{"unknown sort", models.PageRequest{Sort: "priority"}, "invalid sort field: priority"},
+		{"unknown order", models.PageRequest{Order: "sideways"}, "invalid order: sideways"},
+		{"not base64", models.PageRequest{Cursor: "%%%"}, "invalid cursor"},
+		{"not json", models.PageRequest{Cursor: "bm90LWpzb24"}, "invalid cursor"},
+		{"other sort", models.PageRequest{Sort: "created_at", Cursor: cursor}, "cursor does not match sort and order"},
+		{"other order", models.PageRequest{Sort: "title", Order: "desc", Cursor: cursor}, "cursor does not match sort and order"},
+	}
+	for _, tt := range tests {
+		if _, err := testKeyset.plan(tt.page); err == nil || err.Error() != tt.want {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	first, err := testKeyset.plan(models.PageRequest{Sort: "created_at", Order: "asc", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := uuid.New()
+	created := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.FixedZone("CEST", 2*3600))
+	cursor := first.next(cursorTime(created), id)
+
+	second, err := testKeyset.plan(models.PageRequest{Sort: "created_at", Order: "asc", Limit: 2, Cursor: cursor})
+	if err != nil {
+		t.Fatalf("plan with cursor error: %v", err)
+	}
+	want := &pageCursor{Sort: "created_at", Order: "asc", Value: "2024-05-01T10:30:00.123456789Z", ID: id}
+	if !reflect.DeepEqual(second.after, want) {
+		t.Errorf("decoded cursor = %+v, want %+v", second.after, want)
+	}
+
+	query, args := second.apply(testKeyset, "SELECT * FROM x WHERE x.org_id = $1", []interface{}{"org"}, 2)
+	wantQuery := "SELECT * FROM x WHERE x.org_id = $1 AND (x.created_at, x.id) > ($2::timestamptz, $3) ORDER BY x.created_at asc, x.id asc LIMIT $4"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"org", want.Value, id, 3}) {
+		t.Errorf("args = %v", args)
+	}
+	if second.more(2) || !second.more(3) {
+		t.Error("more should report a page after only when the extra row was fetched")
+	}
+}
+
+func TestApplyDescendingWithoutCursor(t *testing.T) {
+	p, err := testKeyset.plan(models.PageRequest{Sort: "title", Order: "desc", Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, args := p.apply(testKeyset, "SELECT * FROM x WHERE true", nil, 1)
+	if want := "SELECT * FROM x WHERE true ORDER BY LOWER(x.title) desc, x.id desc LIMIT $1"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{6}) {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestCursorValues(t *testing.T) {
+	if got := cursorOptionalTime(nil); got != "infinity" {
+		t.Errorf("cursorOptionalTime(nil) = %q", got)
+	}
+	priorities := []string{"low", "medium", "high", "urgent"}
+	if rankOf("high", priorities) != "3" || rankOf("unknown", priorities) != "0" {
+		t.Error("rankOf does not match positions in values")
+	}
+	if want := "CASE t.priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 ELSE 0 END"; rankExpr("t.priority", priorities) != want {
+		t.Errorf("rankExpr = %q", rankExpr("t.priority", priorities))
+	}
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ProjectRepository struct {
+	db *sql.DB
+}
+
+func NewProjectRepository(db *sql.DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+func (r *ProjectRepository) Create(project *models.Project) error {
+	query := `
+		INSERT INTO projects (id, org_id, name, description, owner_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		project.ID,
+		project.OrgID,
+		project.Name,
+		project.Description,
+		project.OwnerID,
+	).Scan(&project.CreatedAt, &project.UpdatedAt)
+	return mapProjectError(err)
+}
+
+const projectSelect = `
+		SELECT
+			p.id, p.org_id, p.name, COALESCE(p.description, ''), p.owner_id, p.is_archived, p.archived_at, p.created_at, p.updated_at,
+			CASE
+				WHEN ou.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(ou.first_name, ''), ' ', COALESCE(ou.last_name, ''))
+			END AS owner_name,
+			(SELECT COUNT(*) FROM project_members pm WHERE pm.project_id = p.id) AS member_count
+		FROM projects p
+		LEFT JOIN users ou ON ou.id = p.owner_id
+`
+
+func scanProject(row rowScanner, project *models.Project) error {
+	return row.Scan(
+		&project.ID,
+		&project.OrgID,
+		&project.Name,
+		&project.Description,
+		&project.OwnerID,
+		&project.IsArchived,
+		&project.ArchivedAt,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+		&project.OwnerName,
+		&project.MemberCount,
+	)
+}
+
+func (r *ProjectRepository) GetByID(orgID, projectID uuid.UUID) (*models.Project, error) {
+	query := projectSelect + `
+		WHERE p.org_id = $1 AND p.id = $2
+	`
+	project := &models.Project{}
+	err := scanProject(r.db.QueryRow(query, orgID, projectID), project)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return project, err
+}
+
+// List returns the organization's projects by name. A non-nil memberID
+// restricts the result to projects that user belongs to.
+func (r *ProjectRepository) List(orgID uuid.UUID, memberID *uuid.UUID, includeArchived bool) ([]models.Project, error) {
+	query := projectSelect + `
+		WHERE p.org_id = $1
+	`
+	args := []interface{}{orgID}
+	if memberID != nil {
+		query += " AND EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $2)"
+		args = append(args, *memberID)
+	}
+	if !includeArchived {
+		query += " AND p.is_archived = false"
+	}
+	query += " ORDER BY LOWER(p.name) ASC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		var project models.Project
+		if err := scanProject(rows, &project); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+func (r *ProjectRepository) Update(project *models.Project) error {
+	query := `
+		UPDATE projects
+		SET name = $1, description = $2, owner_id = $3, is_archived = $4, archived_at = $5
+		WHERE org_id = $6 AND id = $7
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		project.Name,
+		project.Description,
+		project.OwnerID,
+		project.IsArchived,
+		project.ArchivedAt,
+		project.OrgID,
+		project.ID,
+	).Scan(&project.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("project not found")
+	}
+	return mapProjectError(err)
+}
+
+func mapProjectError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("a project with this name already exists")
+	}
+	return err
+}
+
+func (r *ProjectRepository) ListMembers(projectID uuid.UUID) ([]models.ProjectMember, error) {
+	query := `
+		SELECT u.id, CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')), u.email, u.role, pm.added_at
+		FROM project_members pm
+		JOIN users u ON u.id = pm.user_id
+		WHERE pm.project_id = $1
+		ORDER BY u.first_name ASC, u.last_name ASC
+	`
+	rows, err := r.db.Query(query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.ProjectMember{}
+	for rows.Next() {
+		var m models.ProjectMember
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &m.Role, &m.AddedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *ProjectRepository) AddMember(projectID, userID uuid.UUID) error {
+	_, err := r.db.Exec(`INSERT INTO project_members (project_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, projectID, userID)
+	return err
+}
+
+func (r *ProjectRepository) RemoveMember(projectID, userID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`, projectID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("user is not a project member")
+	}
+	return nil
+}
+
+func (r *ProjectRepository) IsMember(projectID, userID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM project_members WHERE project_id = $1 AND user_id = $2)`, projectID, userID).Scan(&exists)
+	return exists, err
+}
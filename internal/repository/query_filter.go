@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/query"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type queryKind int
+
+const (
+	// queryEnum matches a text column against a set of values.
+	queryEnum queryKind = iota
+	// queryRank is an enum with an order, so it also supports < and >.
+	queryRank
+	// queryRef matches a UUID column by ID, "none", or a name resolved
+	// through lookup.
+	queryRef
+	// queryDate compares a timestamp column with a date or a relative offset.
+	queryDate
+	// queryLabel matches attached labels by name.
+	queryLabel
+)
+
+// queryField describes how a filter-language field maps onto SQL.
+type queryField struct {
+	kind   queryKind
+	column string
+	ranks  []string
+	// lookup selects IDs whose name matches any of $%d, with $1 the org ID.
+	lookup string
+	// me lets "me" stand for the viewer.
+	me bool
+	// assignment, for tasks, matches a queryRef user through
+	// task_assignments rows of this kind instead of column.
+	assignment string
+}
+
+// queryTarget is the entity a filter expression is compiled for.
+type queryTarget struct {
+	fields map[string]queryField
+	// labelJoin, labelFK and idExpr locate the entity's label links.
+	labelJoin string
+	labelFK   string
+	idExpr    string
+	// textColumn is the tsvector free text is matched against.
+	textColumn string
+}
+
+const (
+	userLookup    = `SELECT id FROM users WHERE org_id = $1 AND LOWER(email) = ANY($%d)`
+	projectLookup = `SELECT id FROM projects WHERE org_id = $1 AND LOWER(name) = ANY($%d)`
+	sprintLookup  = `SELECT id FROM sprints WHERE org_id = $1 AND LOWER(name) = ANY($%d)`
+)
+
+var taskQueryTarget = queryTarget{
+	fields: map[string]queryField{
+		"status":   {kind: queryEnum, column: "t.status"},
+		"priority": {kind: queryRank, column: "t.priority", ranks: taskPriorities},
+		"assignee": {kind: queryRef, lookup: userLookup, me: true, assignment: models.AssignmentAssignee},
+		"reviewer": {kind: queryRef, lookup: userLookup, me: true, assignment: models.AssignmentReviewer},
+		"approver": {kind: queryRef, lookup: userLookup, me: true, assignment: models.AssignmentApprover},
+		"creator":  {kind: queryRef, column: "t.created_by", lookup: userLookup, me: true},
+		"project":  {kind: queryRef, column: "t.project_id", lookup: projectLookup},
+		"sprint":   {kind: queryRef, column: "t.sprint_id", lookup: sprintLookup},
+		"label":    {kind: queryLabel},
+		"due":      {kind: queryDate, column: "t.due_date"},
+		"created":  {kind: queryDate, column: "t.created_at"},
+		"updated":  {kind: queryDate, column: "t.updated_at"},
+	},
+	labelJoin:  "task_labels",
+	labelFK:    "task_id",
+	idExpr:     "t.id",
+	textColumn: "t.search_vector",
+}
+
+var issueQueryTarget = queryTarget{
+	fields: map[string]queryField{
+		"status":   {kind: queryEnum, column: "i.status"},
+		"severity": {kind: queryRank, column: "i.severity", ranks: issueSeverities},
+		"assignee": {kind: queryRef, column: "i.assigned_to", lookup: userLookup, me: true},
+		"reporter": {kind: queryRef, column: "i.reported_by", lookup: userLookup, me: true},
+		"project":  {kind: queryRef, column: "i.project_id", lookup: projectLookup},
+		"label":    {kind: queryLabel},
+		"created":  {kind: queryDate, column: "i.created_at"},
+		"updated":  {kind: queryDate, column: "i.updated_at"},
+		"resolved": {kind: queryDate, column: "i.resolved_at"},
+	},
+	labelJoin:  "issue_labels",
+	labelFK:    "issue_id",
+	idExpr:     "i.id",
+	textColumn: "i.search_vector",
+}
+
+// queryCompiler turns parsed terms into AND-ed SQL conditions with
+// placeholders starting at argIdx.
+type queryCompiler struct {
+	target queryTarget
+	me     *uuid.UUID
+	now    time.Time
+	args   []interface{}
+	argIdx int
+}
+
+func (c *queryCompiler) arg(v interface{}) string {
+	c.args = append(c.args, v)
+	c.argIdx++
+	return fmt.Sprintf("$%d", c.argIdx-1)
+}
+
+// queryClause compiles a filter expression for target. me resolves "me" in
+// user fields. Errors are *query.Error values naming the offending token.
+func queryClause(raw string, target queryTarget, me *uuid.UUID, argIdx int) (string, []interface{}, int, error) {
+	q, err := query.Parse(raw)
+	if err != nil {
+		return "", nil, argIdx, err
+	}
+	c := &queryCompiler{target: target, me: me, now: time.Now(), argIdx: argIdx}
+
+	var b strings.Builder
+	for _, t := range q.Terms {
+		field, ok := target.fields[t.Field]
+		if !ok {
+			return "", nil, argIdx, query.Errorf(t, "unknown field %q", t.Field)
+		}
+		var cond string
+		switch field.kind {
+		case queryEnum:
+			cond, err = c.enum(t, field)
+		case queryRank:
+			cond, err = c.rank(t, field)
+		case queryRef:
+			cond, err = c.ref(t, field)
+		case queryDate:
+			cond, err = c.date(t, field)
+		case queryLabel:
+			cond, err = c.label(t)
+		}
+		if err != nil {
+			return "", nil, argIdx, err
+		}
+		b.WriteString(" AND " + cond)
+	}
+	if len(q.Text) > 0 {
+		fmt.Fprintf(&b, " AND %s @@ websearch_to_tsquery('english', %s)", target.textColumn, c.arg(strings.Join(q.Text, " ")))
+	}
+	return b.String(), c.args, c.argIdx, nil
+}
+
+func requireOps(t query.Term, ops ...string) error {
+	for _, op := range ops {
+		if t.Op == op {
+			return nil
+		}
+	}
+	return query.Errorf(t, "operator %q is not supported for %s", t.Op, t.Field)
+}
+
+// negate applies != to a condition, treating NULL as "does not match".
+func negate(t query.Term, cond string) string {
+	if t.Op == query.OpNe {
+		return "NOT COALESCE(" + cond + ", false)"
+	}
+	return cond
+}
+
+func (c *queryCompiler) enum(t query.Term, f queryField) (string, error) {
+	if err := requireOps(t, query.OpEq, query.OpNe); err != nil {
+		return "", err
+	}
+	return negate(t, fmt.Sprintf("LOWER(%s) = ANY(%s)", f.column, c.arg(pq.Array(lowerAll(t.Values))))), nil
+}
+
+func (c *queryCompiler) rank(t query.Term, f queryField) (string, error) {
+	values := lowerAll(t.Values)
+	for _, v := range values {
+		if rankOf(v, f.ranks) == "0" {
+			return "", query.Errorf(t, "unknown %s %q; expected one of %s", t.Field, v, strings.Join(f.ranks, ", "))
+		}
+	}
+	switch t.Op {
+	case query.OpEq, query.OpNe:
+		return negate(t, fmt.Sprintf("%s = ANY(%s)", f.column, c.arg(pq.Array(values)))), nil
+	}
+	if len(values) != 1 {
+		return "", query.Errorf(t, "operator %q takes a single value", t.Op)
+	}
+	rank, _ := strconv.Atoi(rankOf(values[0], f.ranks))
+	return fmt.Sprintf("%s %s %s", rankExpr(f.column, f.ranks), t.Op, c.arg(rank)), nil
+}
+
+func (c *queryCompiler) ref(t query.Term, f queryField) (string, error) {
+	if err := requireOps(t, query.OpEq, query.OpNe); err != nil {
+		return "", err
+	}
+	ids := []string{}
+	names := []string{}
+	none := false
+	for _, v := range t.Values {
+		switch lower := strings.ToLower(v); {
+		case lower == "none":
+			none = true
+		case lower == "me" && f.me:
+			if c.me == nil {
+				return "", query.Errorf(t, "%q is not available here", v)
+			}
+			ids = append(ids, c.me.String())
+		default:
+			if id, err := uuid.Parse(v); err == nil {
+				ids = append(ids, id.String())
+			} else {
+				names = append(names, lower)
+			}
+		}
+	}
+
+	if f.assignment != "" {
+		return negate(t, c.assigned(f, none, ids, names)), nil
+	}
+
+	parts := []string{}
+	if none {
+		parts = append(parts, f.column+" IS NULL")
+	}
+	if len(ids) > 0 {
+		parts = append(parts, fmt.Sprintf("%s = ANY(%s::uuid[])", f.column, c.arg(pq.Array(ids))))
+	}
+	if len(names) > 0 {
+		parts = append(parts, fmt.Sprintf("%s IN (%s)", f.column, fmt.Sprintf(f.lookup, c.argIdx)))
+		c.arg(pq.Array(names))
+	}
+	return negate(t, "("+strings.Join(parts, " OR ")+")"), nil
+}
+
+// assigned matches tasks with any user of the field's assignment kind among
+// ids or names, or with nobody of that kind when none is set.
+func (c *queryCompiler) assigned(f queryField, none bool, ids, names []string) string {
+	rows := fmt.Sprintf("SELECT 1 FROM task_assignments ta WHERE ta.task_id = %s AND ta.kind = '%s'", c.target.idExpr, f.assignment)
+	parts := []string{}
+	if none {
+		parts = append(parts, "NOT EXISTS ("+rows+")")
+	}
+	if len(ids) > 0 {
+		parts = append(parts, fmt.Sprintf("EXISTS (%s AND ta.user_id = ANY(%s::uuid[]))", rows, c.arg(pq.Array(ids))))
+	}
+	if len(names) > 0 {
+		parts = append(parts, fmt.Sprintf("EXISTS (%s AND ta.user_id IN (%s))", rows, fmt.Sprintf(f.lookup, c.argIdx)))
+		c.arg(pq.Array(names))
+	}
+	return "(" + strings.Join(parts, " OR ") + ")"
+}
+
+func (c *queryCompiler) label(t query.Term) (string, error) {
+	if err := requireOps(t, query.OpEq, query.OpNe); err != nil {
+		return "", err
+	}
+	cond := fmt.Sprintf("EXISTS (SELECT 1 FROM %s x JOIN labels l ON l.id = x.label_id WHERE x.%s = %s AND LOWER(l.name) = ANY(%s))",
+		c.target.labelJoin, c.target.labelFK, c.target.idExpr, c.arg(pq.Array(lowerAll(t.Values))))
+	return negate(t, cond), nil
+}
+
+// relativeDate matches offsets from now such as 7d, -2w or 12h.
+var relativeDate = regexp.MustCompile(`^([+-]?)(\d+)([hdw])$`)
+
+// parseQueryDate resolves a date value. Offsets are relative to now, so
+// due<7d means "due within a week" and created>-7d "created in the last
+// week". Dates are whole UTC days.
+func (c *queryCompiler) parseQueryDate(t query.Term, v string) (time.Time, bool, error) {
+	v = strings.ToLower(v)
+	switch v {
+	case "today":
+		return c.now.UTC().Truncate(24 * time.Hour), true, nil
+	case "now":
+		return c.now, false, nil
+	}
+	if m := relativeDate.FindStringSubmatch(v); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		unit := map[string]time.Duration{"h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}[m[3]]
+		offset := time.Duration(n) * unit
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return c.now.Add(offset), false, nil
+	}
+	if d, err := time.Parse("2006-01-02", v); err == nil {
+		return d, true, nil
+	}
+	return time.Time{}, false, query.Errorf(t, "invalid date %q; use YYYY-MM-DD, today, or an offset such as 7d, -2w or 12h", v)
+}
+
+func (c *queryCompiler) date(t query.Term, f queryField) (string, error) {
+	if len(t.Values) != 1 {
+		return "", query.Errorf(t, "%s takes a single value", t.Field)
+	}
+	if strings.EqualFold(t.Values[0], "none") {
+		switch t.Op {
+		case query.OpEq:
+			return f.column + " IS NULL", nil
+		case query.OpNe:
+			return f.column + " IS NOT NULL", nil
+		}
+		return "", query.Errorf(t, "operator %q cannot be used with none", t.Op)
+	}
+	if t.Op == query.OpNe {
+		return "", query.Errorf(t, "operator %q is not supported for %s", t.Op, t.Field)
+	}
+
+	at, wholeDay, err := c.parseQueryDate(t, t.Values[0])
+	if err != nil {
+		return "", err
+	}
+	if !wholeDay {
+		if t.Op == query.OpEq {
+			return "", query.Errorf(t, "use <, <=, > or >= with a relative %s", t.Field)
+		}
+		return fmt.Sprintf("%s %s %s", f.column, t.Op, c.arg(at)), nil
+	}
+
+	// A calendar day covers [at, at+1d); compare against the matching edge.
+	next := at.Add(24 * time.Hour)
+	switch t.Op {
+	case query.OpEq:
+		return fmt.Sprintf("(%s >= %s AND %s < %s)", f.column, c.arg(at), f.column, c.arg(next)), nil
+	case query.OpLt:
+		return fmt.Sprintf("%s < %s", f.column, c.arg(at)), nil
+	case query.OpLte:
+		return fmt.Sprintf("%s < %s", f.column, c.arg(next)), nil
+	case query.OpGt:
+		return fmt.Sprintf("%s >= %s", f.column, c.arg(next)), nil
+	default:
+		return fmt.Sprintf("%s >= %s", f.column, c.arg(at)), nil
+	}
+}
+
+func lowerAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToLower(v)
+	}
+	return out
+}
+
+// ValidateListQuery checks a filter expression and sort for a task or issue
+// listing without running it, for callers that store them for later use.
+func ValidateListQuery(entityType, raw, sort, order string) error {
+	target, ks := taskQueryTarget, taskKeyset
+	if entityType == "issue" {
+		target, ks = issueQueryTarget, issueKeyset
+	}
+	viewer := uuid.Nil
+	if _, _, _, err := queryClause(raw, target, &viewer, 1); err != nil {
+		return err
+	}
+	_, err := ks.plan(models.PageRequest{Sort: sort, Order: order})
+	return err
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"saas-backend/internal/query"
+
+	"github.com/google/uuid"
+)
+
+func TestQueryClause(t *testing.T) {
+	me := uuid.New()
+	tests := []struct {
+		name     string
+		raw      string
+		target   queryTarget
+		contains []string
+		args     int
+	}{
+		{
+			name:     "enum",
+			raw:      "status:todo,done",
+			target:   taskQueryTarget,
+			contains: []string{"LOWER(t.status) = ANY($3)"},
+			args:     1,
+		},
+		{
+			name:     "negated enum treats NULL as no match",
+			raw:      "status!=done",
+			target:   issueQueryTarget,
+			contains: []string{"NOT COALESCE(LOWER(i.status) = ANY($3), false)"},
+			args:     1,
+		},
+		{
+			name:     "rank comparison",
+			raw:      "priority>=high",
+			target:   taskQueryTarget,
+			contains: []string{">= $3"},
+			args:     1,
+		},
+		{
+			name:     "column reference with me and none",
+			raw:      "creator:me,none",
+			target:   taskQueryTarget,
+			contains: []string{"t.created_by IS NULL", "t.created_by = ANY($3::uuid[])"},
+			args:     1,
+		},
+		{
+			name:   "task assignee goes through task_assignments",
+			raw:    "assignee:ada@example.com",
+			target: taskQueryTarget,
+			contains: []string{
+				"EXISTS (SELECT 1 FROM task_assignments ta WHERE ta.task_id = t.id AND ta.kind = 'assignee' AND ta.user_id IN (SELECT id FROM users WHERE org_id = $1 AND LOWER(email) = ANY($3)))",
+			},
+			args: 1,
+		},
+		{
+			name:     "reviewer none",
+			raw:      "reviewer:none",
+			target:   taskQueryTarget,
+			contains: []string{"NOT EXISTS (SELECT 1 FROM task_assignments ta WHERE ta.task_id = t.id AND ta.kind = 'reviewer')"},
+		},
+		{
+			name:     "issue assignee stays on the column",
+			raw:      "assignee:me",
+			target:   issueQueryTarget,
+			contains: []string{"i.assigned_to = ANY($3::uuid[])"},
+			args:     1,
+		},
+		{
+			name:     "calendar day",
+			raw:      "due:2024-05-01",
+			target:   taskQueryTarget,
+			contains: []string{"(t.due_date >= $3 AND t.due_date < $4)"},
+			args:     2,
+		},
+		{
+			name:     "free text",
+			raw:      "label:backend crash",
+			target:   taskQueryTarget,
+			contains: []string{"EXISTS (SELECT 1 FROM task_labels x", "t.search_vector @@ websearch_to_tsquery('english', $4)"},
+			args:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, args, next, err := queryClause(tt.raw, tt.target, &me, 3)
+			if err != nil {
+				t.Fatalf("queryClause(%q) error: %v", tt.raw, err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(clause, want) {
+					t.Errorf("clause %q does not contain %q", clause, want)
+				}
+			}
+			if len(args) != tt.args || next != 3+tt.args {
+				t.Errorf("got %d args and next index %d, want %d and %d", len(args), next, tt.args, 3+tt.args)
+			}
+		})
+	}
+}
+
+func TestQueryClauseErrors(t *testing.T) {
+	tests := []struct {
+		raw  string
+		me   *uuid.UUID
+		want string
+	}{
+		{"prio:high", nil, `unknown field "prio"`},
+		{"priority:extreme", nil, `unknown priority "extreme"`},
+		{"status<done", nil, `operator "<" is not supported for status`},
+		{"priority>high,low", nil, `operator ">" takes a single value`},
+		{"assignee:me", nil, `"me" is not available here`},
+		{"due:next-week", nil, `invalid date "next-week"`},
+		{"due:7d", nil, "use <, <=, > or >= with a relative due"},
+		{"due>none", nil, `operator ">" cannot be used with none`},
+	}
+	for _, tt := range tests {
+		_, _, _, err := queryClause(tt.raw, taskQueryTarget, tt.me, 1)
+		var qerr *query.Error
+		if !errors.As(err, &qerr) {
+			t.Errorf("queryClause(%q) error = %v, want *query.Error", tt.raw, err)
+			continue
+		}
+		if !strings.Contains(qerr.Msg, tt.want) {
+			t.Errorf("queryClause(%q) error = %q, want it to contain %q", tt.raw, qerr.Msg, tt.want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type RecurringTaskRepository struct {
+	db *sql.DB
+}
+
+func NewRecurringTaskRepository(db *sql.DB) *RecurringTaskRepository {
+	return &RecurringTaskRepository{db: db}
+}
+
+func (r *RecurringTaskRepository) Create(rt *models.RecurringTask) error {
+	query := `
+		INSERT INTO recurring_tasks (id, org_id, project_id, title, description, priority, assigned_to, rrule, dtstart, timezone, due_offset_minutes, is_paused, next_run_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING created_at, updated_at
+	`
+	return r.db.QueryRow(
+		query,
+		rt.ID,
+		rt.OrgID,
+		rt.ProjectID,
+		rt.Title,
+		rt.Description,
+		rt.Priority,
+		rt.AssignedTo,
+		rt.RRule,
+		rt.DTStart,
+		rt.Timezone,
+		rt.DueOffsetMinutes,
+		rt.IsPaused,
+		rt.NextRunAt,
+		rt.CreatedBy,
+	).Scan(&rt.CreatedAt, &rt.UpdatedAt)
+}
+
+const recurringTaskSelect = `
+		SELECT
+			rt.id, rt.org_id, rt.project_id, rt.title, COALESCE(rt.description, ''), rt.priority, rt.assigned_to,
+			rt.rrule, rt.dtstart, rt.timezone, rt.due_offset_minutes, rt.is_paused, rt.next_run_at, rt.last_run_at,
+			rt.created_by, rt.created_at, rt.updated_at,
+			CASE
+				WHEN au.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
+			END AS assigned_to_name
+		FROM recurring_tasks rt
+		LEFT JOIN users au ON au.id = rt.assigned_to
+`
+
+func scanRecurringTask(row rowScanner, rt *models.RecurringTask) error {
+	return row.Scan(
+		&rt.ID,
+		&rt.OrgID,
+		&rt.ProjectID,
+		&rt.Title,
+		&rt.Description,
+		&rt.Priority,
+		&rt.AssignedTo,
+		&rt.RRule,
+		&rt.DTStart,
+		&rt.Timezone,
+		&rt.DueOffsetMinutes,
+		&rt.IsPaused,
+		&rt.NextRunAt,
+		&rt.LastRunAt,
+		&rt.CreatedBy,
+		&rt.CreatedAt,
+		&rt.UpdatedAt,
+		&rt.AssignedToName,
+	)
+}
+
+func (r *RecurringTaskRepository) query(query string, args ...interface{}) ([]models.RecurringTask, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.RecurringTask{}
+	for rows.Next() {
+		var rt models.RecurringTask
+		if err := scanRecurringTask(rows, &rt); err != nil {
+			return nil, err
+		}
+		items = append(items, rt)
+	}
+	return items, rows.Err()
+}
+
+func (r *RecurringTaskRepository) GetByID(orgID, id uuid.UUID) (*models.RecurringTask, error) {
+	query := recurringTaskSelect + `
+		WHERE rt.org_id = $1 AND rt.id = $2
+	`
+	rt := &models.RecurringTask{}
+	err := scanRecurringTask(r.db.QueryRow(query, orgID, id), rt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return rt, err
+}
+
+func (r *RecurringTaskRepository) List(orgID uuid.UUID) ([]models.RecurringTask, error) {
+	query := recurringTaskSelect + `
+		WHERE rt.org_id = $1
+		ORDER BY rt.created_at DESC
+	`
+	return r.query(query, orgID)
+}
+
+// ListDue returns active series across all organizations whose next occurrence is at or before now.
+func (r *RecurringTaskRepository) ListDue(now time.Time, limit int) ([]models.RecurringTask, error) {
+	query := recurringTaskSelect + `
+		WHERE rt.is_paused = false AND rt.next_run_at IS NOT NULL AND rt.next_run_at <= $1
+		ORDER BY rt.next_run_at ASC
+		LIMIT $2
+	`
+	return r.query(query, now, limit)
+}
+
+func (r *RecurringTaskRepository) Update(rt *models.RecurringTask) error {
+	query := `
+		UPDATE recurring_tasks
+		SET title = $1, description = $2, priority = $3, assigned_to = $4, rrule = $5, dtstart = $6,
+			timezone = $7, due_offset_minutes = $8, is_paused = $9, next_run_at = $10, project_id = $11
+		WHERE org_id = $12 AND id = $13
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		rt.Title,
+		rt.Description,
+		rt.Priority,
+		rt.AssignedTo,
+		rt.RRule,
+		rt.DTStart,
+		rt.Timezone,
+		rt.DueOffsetMinutes,
+		rt.IsPaused,
+		rt.NextRunAt,
+		rt.ProjectID,
+		rt.OrgID,
+		rt.ID,
+	).Scan(&rt.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("recurring task not found")
+	}
+	return err
+}
+
+// ClaimRun advances next_run_at from expected to next. It reports false when
+// another scheduler instance (or an edit) moved the series first, so each
+// occurrence is materialized at most once.
+func (r *RecurringTaskRepository) ClaimRun(id uuid.UUID, expected time.Time, next *time.Time, ranAt time.Time) (bool, error) {
+	query := `
+		UPDATE recurring_tasks
+		SET next_run_at = $1, last_run_at = $2
+		WHERE id = $3 AND is_paused = false AND next_run_at = $4
+	`
+	result, err := r.db.Exec(query, next, ranAt, id, expected)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// Pause stops a series whose claimed occurrence could not be created, so a
+// failure that would repeat does not recur on every poll. Nothing changes if
+// the series has moved on since the claim, for instance because it was
+// edited.
+func (r *RecurringTaskRepository) Pause(id uuid.UUID, claimed *time.Time) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE recurring_tasks
+		SET is_paused = true
+		WHERE id = $1 AND next_run_at IS NOT DISTINCT FROM $2
+	`, id, claimed)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+func (r *RecurringTaskRepository) Delete(orgID, id uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM recurring_tasks WHERE org_id = $1 AND id = $2`, orgID, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("recurring task not found")
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SavedViewRepository struct {
+	db *sql.DB
+}
+
+func NewSavedViewRepository(db *sql.DB) *SavedViewRepository {
+	return &SavedViewRepository{db: db}
+}
+
+func mapSavedViewError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("you already have a view with this name")
+	}
+	return err
+}
+
+func (r *SavedViewRepository) Create(view *models.SavedView) error {
+	query := `
+		INSERT INTO saved_views (id, org_id, owner_id, name, entity_type, query, sort, sort_order, columns, group_by, visibility, shared_role)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		view.ID,
+		view.OrgID,
+		view.OwnerID,
+		view.Name,
+		view.EntityType,
+		view.Query,
+		view.Sort,
+		view.Order,
+		pq.Array(view.Columns),
+		view.GroupBy,
+		view.Visibility,
+		view.SharedRole,
+	).Scan(&view.CreatedAt, &view.UpdatedAt)
+	view.PinnedFor = []string{}
+	return mapSavedViewError(err)
+}
+
+const savedViewSelect = `
+		SELECT v.id, v.org_id, v.owner_id, v.name, v.entity_type, v.query, v.sort, v.sort_order, v.columns,
+			v.group_by, v.visibility, v.shared_role,
+			ARRAY(SELECT d.role FROM saved_view_defaults d WHERE d.view_id = v.id ORDER BY d.role) AS pinned_for,
+			v.created_at, v.updated_at
+		FROM saved_views v
+`
+
+func scanSavedView(row rowScanner, v *models.SavedView) error {
+	return row.Scan(
+		&v.ID,
+		&v.OrgID,
+		&v.OwnerID,
+		&v.Name,
+		&v.EntityType,
+		&v.Query,
+		&v.Sort,
+		&v.Order,
+		pq.Array(&v.Columns),
+		&v.GroupBy,
+		&v.Visibility,
+		&v.SharedRole,
+		pq.Array(&v.PinnedFor),
+		&v.CreatedAt,
+		&v.UpdatedAt,
+	)
+}
+
+func (r *SavedViewRepository) GetByID(orgID, viewID uuid.UUID) (*models.SavedView, error) {
+	query := savedViewSelect + `
+		WHERE v.org_id = $1 AND v.id = $2
+	`
+	view := &models.SavedView{}
+	err := scanSavedView(r.db.QueryRow(query, orgID, viewID), view)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return view, err
+}
+
+// ListVisible returns the views a user can see: their own, those shared with
+// the organization, and those shared with their role. An empty entityType
+// lists both kinds.
+func (r *SavedViewRepository) ListVisible(orgID, userID uuid.UUID, role, entityType string) ([]models.SavedView, error) {
+	query := savedViewSelect + `
+		WHERE v.org_id = $1
+			AND (v.owner_id = $2 OR v.visibility = 'org' OR (v.visibility = 'role' AND v.shared_role = $3))
+			AND ($4 = '' OR v.entity_type = $4)
+		ORDER BY v.entity_type, LOWER(v.name), v.id
+	`
+	rows, err := r.db.Query(query, orgID, userID, role, entityType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []models.SavedView{}
+	for rows.Next() {
+		var v models.SavedView
+		if err := scanSavedView(rows, &v); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+// Update saves a view and drops any pin whose role can no longer see it.
+func (r *SavedViewRepository) Update(view *models.SavedView) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `
+		UPDATE saved_views
+		SET name = $1, query = $2, sort = $3, sort_order = $4, columns = $5, group_by = $6, visibility = $7, shared_role = $8
+		WHERE org_id = $9 AND id = $10
+		RETURNING updated_at
+	`
+	err = tx.QueryRow(
+		query,
+		view.Name,
+		view.Query,
+		view.Sort,
+		view.Order,
+		pq.Array(view.Columns),
+		view.GroupBy,
+		view.Visibility,
+		view.SharedRole,
+		view.OrgID,
+		view.ID,
+	).Scan(&view.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("view not found")
+	}
+	if err != nil {
+		return mapSavedViewError(err)
+	}
+
+	rows, err := tx.Query(`
+		DELETE FROM saved_view_defaults
+		WHERE view_id = $1 AND NOT ($2 = 'org' OR ($2 = 'role' AND role = $3))
+		RETURNING role
+	`, view.ID, view.Visibility, view.SharedRole)
+	if err != nil {
+		return err
+	}
+	dropped := map[string]bool{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			rows.Close()
+			return err
+		}
+		dropped[role] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	pinned := []string{}
+	for _, role := range view.PinnedFor {
+		if !dropped[role] {
+			pinned = append(pinned, role)
+		}
+	}
+	view.PinnedFor = pinned
+
+	return tx.Commit()
+}
+
+func (r *SavedViewRepository) Delete(orgID, viewID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM saved_views WHERE org_id = $1 AND id = $2`, orgID, viewID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("view not found")
+	}
+	return nil
+}
+
+// Pin makes a view the default for a role, replacing any earlier pin.
+func (r *SavedViewRepository) Pin(view *models.SavedView, role string, pinnedBy uuid.UUID) error {
+	_, err := r.db.Exec(`
+		INSERT INTO saved_view_defaults (org_id, entity_type, role, view_id, pinned_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (org_id, entity_type, role)
+		DO UPDATE SET view_id = EXCLUDED.view_id, pinned_by = EXCLUDED.pinned_by, created_at = CURRENT_TIMESTAMP
+	`, view.OrgID, view.EntityType, role, view.ID, pinnedBy)
+	return err
+}
+
+// Unpin removes a view as the default for a role. It reports whether the view
+// was pinned.
+func (r *SavedViewRepository) Unpin(orgID, viewID uuid.UUID, role string) (bool, error) {
+	result, err := r.db.Exec(
+		`DELETE FROM saved_view_defaults WHERE org_id = $1 AND view_id = $2 AND role = $3`,
+		orgID, viewID, role,
+	)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// GetDefault returns the view pinned for a role, or nil if there is none.
+func (r *SavedViewRepository) GetDefault(orgID uuid.UUID, entityType, role string) (*models.SavedView, error) {
+	query := savedViewSelect + `
+		JOIN saved_view_defaults pin ON pin.view_id = v.id
+		WHERE pin.org_id = $1 AND pin.entity_type = $2 AND pin.role = $3
+	`
+	view := &models.SavedView{}
+	err := scanSavedView(r.db.QueryRow(query, orgID, entityType, role), view)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return view, err
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type SearchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// searchHeadline highlights matched terms in a short excerpt of the body.
+const searchHeadline = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+// htmlEscaped returns expr with HTML special characters escaped, so that the
+// <mark> tags ts_headline adds are the only markup in its output.
+func htmlEscaped(expr string) string {
+	return fmt.Sprintf(
+		`replace(replace(replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`,
+		expr,
+	)
+}
+
+// searchHits builds a query over every matching task, issue and document the
+// filter allows, with columns type, id, title, body, project_id, rank and
+// updated_at. $1 is the org and $2 the query text.
+func searchHits(filter models.SearchFilter) (string, []interface{}) {
+	args := []interface{}{nil, filter.Query}
+	argIdx := 3
+
+	projectClause := func(alias string) string { return "" }
+	if filter.ProjectID != nil {
+		idx := argIdx
+		args = append(args, *filter.ProjectID)
+		argIdx++
+		projectClause = func(alias string) string {
+			return fmt.Sprintf(" AND %s.project_id = $%d", alias, idx)
+		}
+	}
+	visible := map[string]string{}
+	if filter.VisibleTo != nil {
+		idx := argIdx
+		args = append(args, *filter.VisibleTo)
+		argIdx++
+		visible[models.SearchTask] = taskAssignedClause("t.id", idx, models.AssignmentAssignee, models.AssignmentReviewer, models.AssignmentApprover)
+		visible[models.SearchIssue] = fmt.Sprintf(" AND (i.reported_by = $%d OR i.assigned_to = $%d)", idx, idx)
+		visible[models.SearchDocument] = fmt.Sprintf(
+			" AND (d.uploaded_by = $%d OR EXISTS (SELECT 1 FROM task_assignments ta WHERE ta.task_id = d.task_id AND ta.user_id = $%d))", idx, idx)
+	}
+
+	parts := []string{}
+	if filter.Type == "" || filter.Type == models.SearchTask {
+		parts = append(parts, `
+			SELECT 'task' AS type, t.id, t.title, t.description AS body, t.project_id,
+				ts_rank(t.search_vector, q.query) AS rank, t.updated_at
+			FROM tasks t, q
+			WHERE t.org_id = $1 AND t.deleted_at IS NULL AND t.search_vector @@ q.query`+projectClause("t")+visible[models.SearchTask])
+	}
+	if filter.Type == "" || filter.Type == models.SearchIssue {
+		parts = append(parts, `
+			SELECT 'issue' AS type, i.id, i.title, i.description AS body, i.project_id,
+				ts_rank(i.search_vector, q.query) AS rank, i.updated_at
+			FROM issues i, q
+			WHERE i.org_id = $1 AND i.deleted_at IS NULL AND i.search_vector @@ q.query`+projectClause("i")+visible[models.SearchIssue])
+	}
+	if filter.Type == "" || filter.Type == models.SearchDocument {
+		parts = append(parts, `
+			SELECT 'document' AS type, d.id, COALESCE(d.title, d.filename) AS title, d.extracted_text AS body, d.project_id,
+				ts_rank(d.search_vector, q.query) AS rank, d.updated_at
+			FROM documents d, q
+			WHERE d.org_id = $1 AND d.deleted_at IS NULL AND d.search_vector @@ q.query`+projectClause("d")+visible[models.SearchDocument])
+	}
+
+	query := `
+		WITH q AS (SELECT websearch_to_tsquery('english', $2) AS query),
+		hits AS (` + strings.Join(parts, "\n\t\t\tUNION ALL") + `
+		)`
+	return query, args
+}
+
+// searchKeyset pages hits by relevance. ts_rank returns a real, so cursors
+// carry the rank at that precision.
+var searchKeyset = keyset{
+	idExpr:      "id",
+	defaultSort: "relevance",
+	columns: map[string]sortColumn{
+		"relevance": {expr: "rank", cast: "real", defaultOrder: "desc"},
+	},
+}
+
+// Search returns one page of ranked hits with highlighted titles and
+// snippets, and the cursor for the next page. Highlighting runs only on the
+// page, since ts_headline reparses the text.
+func (r *SearchRepository) Search(orgID uuid.UUID, filter models.SearchFilter, page models.PageRequest) ([]models.SearchHit, string, error) {
+	plan, err := searchKeyset.plan(page)
+	if err != nil {
+		return nil, "", err
+	}
+	query, args := searchHits(filter)
+	args[0] = orgID
+	inner, args := plan.apply(searchKeyset, `SELECT * FROM hits WHERE true`, args, len(args)+1)
+	query += fmt.Sprintf(`
+		SELECT p.type, p.id, p.title,
+			ts_headline('english', %s, q.query, 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+			ts_headline('english', %s, q.query, '%s'),
+			p.rank, p.project_id, p.updated_at
+		FROM (%s) p, q
+		ORDER BY p.rank %s, p.id %s
+	`, htmlEscaped("p.title"), htmlEscaped("LEFT(COALESCE(p.body, ''), 500000)"), searchHeadline, inner, plan.order, plan.order)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var h models.SearchHit
+		if err := rows.Scan(&h.Type, &h.ID, &h.Title, &h.TitleHighlight, &h.Snippet, &h.Rank, &h.ProjectID, &h.UpdatedAt); err != nil {
+			return nil, "", err
+		}
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil || !plan.more(len(hits)) {
+		return hits, "", err
+	}
+	hits = hits[:plan.limit]
+	last := hits[len(hits)-1]
+	return hits, plan.next(strconv.FormatFloat(last.Rank, 'g', -1, 32), last.ID), nil
+}
+
+// Facets counts every hit the filter allows, grouped by type. Types with no
+// hits are reported as zero.
+func (r *SearchRepository) Facets(orgID uuid.UUID, filter models.SearchFilter) (map[string]int, error) {
+	query, args := searchHits(filter)
+	args[0] = orgID
+	query += `
+		SELECT type, COUNT(*) FROM hits GROUP BY type
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	facets := map[string]int{
+		models.SearchTask:     0,
+		models.SearchIssue:    0,
+		models.SearchDocument: 0,
+	}
+	for rows.Next() {
+		var typ string
+		var n int
+		if err := rows.Scan(&typ, &n); err != nil {
+			return nil, err
+		}
+		facets[typ] = n
+	}
+	return facets, rows.Err()
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SprintRepository struct {
+	db *sql.DB
+}
+
+func NewSprintRepository(db *sql.DB) *SprintRepository {
+	return &SprintRepository{db: db}
+}
+
+func (r *SprintRepository) Create(sprint *models.Sprint) error {
+	query := `
+		INSERT INTO sprints (id, org_id, project_id, name, goal, start_date, end_date, state, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at, updated_at
+	`
+	return r.db.QueryRow(
+		query,
+		sprint.ID,
+		sprint.OrgID,
+		sprint.ProjectID,
+		sprint.Name,
+		sprint.Goal,
+		sprint.StartDate,
+		sprint.EndDate,
+		sprint.State,
+		sprint.CreatedBy,
+	).Scan(&sprint.CreatedAt, &sprint.UpdatedAt)
+}
+
+// sprintSelect includes the task roll-up for the sprint's current tasks.
+var sprintSelect = `
+		SELECT
+			s.id, s.org_id, s.project_id, s.name, COALESCE(s.goal, ''), s.start_date, s.end_date, s.state, s.closed_at, s.created_by,
+			s.created_at, s.updated_at,
+			(SELECT COUNT(*) FROM tasks t WHERE t.sprint_id = s.id AND t.deleted_at IS NULL) AS task_count,
+			(SELECT COUNT(*) FROM tasks t WHERE t.sprint_id = s.id AND t.deleted_at IS NULL AND ` + completedStatus("t.status", "t.org_id") + `) AS completed_count,
+			(SELECT COALESCE(SUM(t.estimate_points), 0) FROM tasks t WHERE t.sprint_id = s.id AND t.deleted_at IS NULL) AS total_points,
+			(SELECT COALESCE(SUM(t.estimate_points), 0) FROM tasks t WHERE t.sprint_id = s.id AND t.deleted_at IS NULL AND ` + completedStatus("t.status", "t.org_id") + `) AS completed_points
+		FROM sprints s
+`
+
+func scanSprint(row rowScanner, sprint *models.Sprint) error {
+	return row.Scan(
+		&sprint.ID,
+		&sprint.OrgID,
+		&sprint.ProjectID,
+		&sprint.Name,
+		&sprint.Goal,
+		&sprint.StartDate,
+		&sprint.EndDate,
+		&sprint.State,
+		&sprint.ClosedAt,
+		&sprint.CreatedBy,
+		&sprint.CreatedAt,
+		&sprint.UpdatedAt,
+		&sprint.TaskCount,
+		&sprint.CompletedCount,
+		&sprint.TotalPoints,
+		&sprint.CompletedPoints,
+	)
+}
+
+func (r *SprintRepository) querySprints(query string, args ...interface{}) ([]models.Sprint, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sprints := []models.Sprint{}
+	for rows.Next() {
+		var sprint models.Sprint
+		if err := scanSprint(rows, &sprint); err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, sprint)
+	}
+	return sprints, rows.Err()
+}
+
+func (r *SprintRepository) GetByID(orgID, sprintID uuid.UUID) (*models.Sprint, error) {
+	query := sprintSelect + `
+		WHERE s.org_id = $1 AND s.id = $2
+	`
+	sprint := &models.Sprint{}
+	err := scanSprint(r.db.QueryRow(query, orgID, sprintID), sprint)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return sprint, err
+}
+
+// List returns sprints newest first.
+func (r *SprintRepository) List(orgID uuid.UUID, filter models.SprintListFilter) ([]models.Sprint, error) {
+	query := sprintSelect + `
+		WHERE s.org_id = $1
+	`
+	args := []interface{}{orgID}
+	argIdx := 2
+
+	if filter.ProjectID != nil {
+		query += fmt.Sprintf(" AND s.project_id = $%d", argIdx)
+		args = append(args, *filter.ProjectID)
+		argIdx++
+	}
+	if filter.State != "" {
+		query += fmt.Sprintf(" AND s.state = $%d", argIdx)
+		args = append(args, filter.State)
+		argIdx++
+	}
+	query += " ORDER BY s.start_date DESC, s.created_at DESC"
+
+	return r.querySprints(query, args...)
+}
+
+// NextPlanned returns the earliest planned sprint in the same project scope
+// (a nil projectID means sprints without a project), excluding sprintID.
+func (r *SprintRepository) NextPlanned(orgID uuid.UUID, projectID *uuid.UUID, sprintID uuid.UUID) (*models.Sprint, error) {
+	query := sprintSelect + `
+		WHERE s.org_id = $1 AND s.state = 'planned' AND s.id <> $2 AND s.project_id IS NOT DISTINCT FROM $3::uuid
+		ORDER BY s.start_date ASC, s.created_at ASC
+		LIMIT 1
+	`
+	sprint := &models.Sprint{}
+	err := scanSprint(r.db.QueryRow(query, orgID, sprintID, projectID), sprint)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return sprint, err
+}
+
+func (r *SprintRepository) Update(sprint *models.Sprint) error {
+	query := `
+		UPDATE sprints
+		SET name = $1, goal = $2, start_date = $3, end_date = $4, state = $5, closed_at = $6
+		WHERE org_id = $7 AND id = $8
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		sprint.Name,
+		sprint.Goal,
+		sprint.StartDate,
+		sprint.EndDate,
+		sprint.State,
+		sprint.ClosedAt,
+		sprint.OrgID,
+		sprint.ID,
+	).Scan(&sprint.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("sprint not found")
+	}
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("another sprint is already active")
+	}
+	return err
+}
+
+// AddTasks moves tasks into a sprint, taking them out of any other sprint.
+// SprintMove is one task moved into or out of a sprint. FromSprintID is nil
+// for tasks that came from the backlog.
+type SprintMove struct {
+	TaskID       uuid.UUID
+	FromSprintID *uuid.UUID
+}
+
+// AddTasks moves tasks into a sprint and returns the moves made; tasks
+// already in the sprint are left alone.
+func (r *SprintRepository) AddTasks(orgID, sprintID uuid.UUID, taskIDs []uuid.UUID) ([]SprintMove, error) {
+	rows, err := r.db.Query(`
+		UPDATE tasks t
+		SET sprint_id = $1, updated_at = CURRENT_TIMESTAMP, version = t.version + 1
+		FROM tasks old
+		WHERE old.id = t.id AND t.org_id = $2 AND t.id = ANY($3) AND t.sprint_id IS DISTINCT FROM $1
+		RETURNING t.id, old.sprint_id
+	`, sprintID, orgID, pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	moves := []SprintMove{}
+	for rows.Next() {
+		var m SprintMove
+		if err := rows.Scan(&m.TaskID, &m.FromSprintID); err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+	return moves, rows.Err()
+}
+
+func (r *SprintRepository) RemoveTask(orgID, sprintID, taskID uuid.UUID) error {
+	result, err := r.db.Exec(
+		`UPDATE tasks SET sprint_id = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE org_id = $1 AND sprint_id = $2 AND id = $3`,
+		orgID, sprintID, taskID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("task is not in this sprint")
+	}
+	return nil
+}
+
+// Close marks the sprint closed and moves its unfinished tasks to carryTo, or
+// to the backlog when carryTo is nil. It returns the IDs of the moved tasks.
+func (r *SprintRepository) Close(sprint *models.Sprint, carryTo *uuid.UUID, closedAt time.Time) ([]uuid.UUID, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = tx.QueryRow(
+		`UPDATE sprints SET state = 'closed', closed_at = $1 WHERE org_id = $2 AND id = $3 RETURNING state, closed_at, updated_at`,
+		closedAt, sprint.OrgID, sprint.ID,
+	).Scan(&sprint.State, &sprint.ClosedAt, &sprint.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("sprint not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE tasks
+		SET sprint_id = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE org_id = $2 AND sprint_id = $3 AND deleted_at IS NULL AND NOT ` + completedStatus("status", "org_id") + `
+		RETURNING id
+	`
+	rows, err := tx.Query(query, carryTo, sprint.OrgID, sprint.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	moved := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		moved = append(moved, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return moved, tx.Commit()
+}
+
+// Velocity returns, for the most recent started sprints, the estimate points
+// of tasks completed within each sprint's date window.
+func (r *SprintRepository) Velocity(orgID uuid.UUID, projectID *uuid.UUID, limit int) ([]models.SprintVelocity, error) {
+	query := `
+		SELECT
+			s.id, s.name, s.start_date, s.end_date, s.state,
+			COALESCE(SUM(t.estimate_points) FILTER (WHERE t.completed_at >= s.start_date AND t.completed_at < s.end_date + 1), 0) AS completed_points,
+			COUNT(t.id) FILTER (WHERE t.completed_at >= s.start_date AND t.completed_at < s.end_date + 1) AS completed_tasks
+		FROM sprints s
+		LEFT JOIN tasks t ON t.sprint_id = s.id AND t.deleted_at IS NULL
+		WHERE s.org_id = $1 AND s.state IN ('active', 'closed')
+	`
+	args := []interface{}{orgID}
+	argIdx := 2
+	if projectID != nil {
+		query += fmt.Sprintf(" AND s.project_id = $%d", argIdx)
+		args = append(args, *projectID)
+		argIdx++
+	}
+	query += fmt.Sprintf(`
+		GROUP BY s.id
+		ORDER BY s.start_date DESC
+		LIMIT $%d
+	`, argIdx)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.SprintVelocity{}
+	for rows.Next() {
+		var v models.SprintVelocity
+		var start, end time.Time
+		if err := rows.Scan(&v.SprintID, &v.Name, &start, &end, &v.State, &v.CompletedPoints, &v.CompletedTasks); err != nil {
+			return nil, err
+		}
+		v.StartDate = start.Format("2006-01-02")
+		v.EndDate = end.Format("2006-01-02")
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// taskPeopleSubquery returns the task's users of one assignment kind as a
+// JSON array. Assignees list the primary assignee first.
+func taskPeopleSubquery(kind string) string {
+	return fmt.Sprintf(`COALESCE((
+				SELECT json_agg(json_build_object('user_id', u.id, 'name', CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')))
+					ORDER BY COALESCE(u.id = t.assigned_to, false) DESC, a.created_at, u.id)
+				FROM task_assignments a JOIN users u ON u.id = a.user_id
+				WHERE a.task_id = t.id AND a.kind = '%s'
+			), '[]')`, kind)
+}
+
+func decodeTaskPeople(raw []byte) ([]models.TaskPerson, error) {
+	people := []models.TaskPerson{}
+	if len(raw) == 0 {
+		return people, nil
+	}
+	if err := json.Unmarshal(raw, &people); err != nil {
+		return nil, fmt.Errorf("failed to decode task assignments: %w", err)
+	}
+	return people, nil
+}
+
+// taskAssignedClause matches tasks where the user at placeholder argIdx has
+// one of the given assignment kinds.
+func taskAssignedClause(taskRef string, argIdx int, kinds ...string) string {
+	quoted := ""
+	for i, kind := range kinds {
+		if i > 0 {
+			quoted += ", "
+		}
+		quoted += "'" + kind + "'"
+	}
+	return fmt.Sprintf(" AND EXISTS (SELECT 1 FROM task_assignments ta WHERE ta.task_id = %s AND ta.user_id = $%d AND ta.kind IN (%s))",
+		taskRef, argIdx, quoted)
+}
+
+// saveTaskPeople makes the task's assignment rows match task.Assignees,
+// Reviewers and Approvers. The primary assignee is always kept among the
+// assignees.
+func saveTaskPeople(ex execer, task *models.Task) error {
+	kinds := []string{}
+	userIDs := []uuid.UUID{}
+	add := func(kind string, userID uuid.UUID) {
+		for i := range userIDs {
+			if kinds[i] == kind && userIDs[i] == userID {
+				return
+			}
+		}
+		kinds = append(kinds, kind)
+		userIDs = append(userIDs, userID)
+	}
+	if task.AssignedTo != nil {
+		add(models.AssignmentAssignee, *task.AssignedTo)
+	}
+	for _, p := range task.Assignees {
+		add(models.AssignmentAssignee, p.UserID)
+	}
+	for _, p := range task.Reviewers {
+		add(models.AssignmentReviewer, p.UserID)
+	}
+	for _, p := range task.Approvers {
+		add(models.AssignmentApprover, p.UserID)
+	}
+
+	_, err := ex.Exec(`
+		DELETE FROM task_assignments
+		WHERE task_id = $1 AND (kind, user_id) NOT IN (
+			SELECT * FROM unnest($2::text[], $3::uuid[])
+		)
+	`, task.ID, pq.Array(kinds), pq.Array(userIDs))
+	if err != nil {
+		return err
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	_, err = ex.Exec(`
+		INSERT INTO task_assignments (task_id, kind, user_id)
+		SELECT $1, x.kind, x.user_id FROM unnest($2::text[], $3::uuid[]) AS x (kind, user_id)
+		ON CONFLICT DO NOTHING
+	`, task.ID, pq.Array(kinds), pq.Array(userIDs))
+	return err
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type TaskDependencyRepository struct {
+	db *sql.DB
+}
+
+func NewTaskDependencyRepository(db *sql.DB) *TaskDependencyRepository {
+	return &TaskDependencyRepository{db: db}
+}
+
+// Add records that taskID depends on dependsOnID. Dependency edits are
+// serialized per organization so concurrent inserts cannot form a cycle.
+func (r *TaskDependencyRepository) Add(orgID, taskID, dependsOnID, createdBy uuid.UUID) error {
+	if taskID == dependsOnID {
+		return fmt.Errorf("a task cannot depend on itself")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, "task_dependencies:"+orgID.String()); err != nil {
+		return err
+	}
+
+	// Adding taskID -> dependsOnID closes a cycle if dependsOnID already
+	// (transitively) depends on taskID.
+	cycleQuery := `
+		WITH RECURSIVE chain AS (
+			SELECT depends_on_id FROM task_dependencies WHERE org_id = $1 AND task_id = $2
+			UNION
+			SELECT d.depends_on_id FROM task_dependencies d JOIN chain c ON d.task_id = c.depends_on_id
+		)
+		SELECT EXISTS (SELECT 1 FROM chain WHERE depends_on_id = $3)
+	`
+	var cycle bool
+	if err := tx.QueryRow(cycleQuery, orgID, dependsOnID, taskID).Scan(&cycle); err != nil {
+		return err
+	}
+	if cycle {
+		return fmt.Errorf("dependency would create a cycle")
+	}
+
+	insert := `
+		INSERT INTO task_dependencies (org_id, task_id, depends_on_id, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (task_id, depends_on_id) DO NOTHING
+	`
+	if _, err := tx.Exec(insert, orgID, taskID, dependsOnID, createdBy); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *TaskDependencyRepository) Remove(orgID, taskID, dependsOnID uuid.UUID) error {
+	query := `DELETE FROM task_dependencies WHERE org_id = $1 AND task_id = $2 AND depends_on_id = $3`
+	result, err := r.db.Exec(query, orgID, taskID, dependsOnID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("dependency not found")
+	}
+	return nil
+}
+
+// ListBlockers returns the tasks that taskID depends on.
+func (r *TaskDependencyRepository) ListBlockers(orgID, taskID uuid.UUID) ([]models.TaskRef, error) {
+	query := `
+		SELECT t.id, t.title, t.status
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.depends_on_id
+		WHERE d.org_id = $1 AND d.task_id = $2 AND t.deleted_at IS NULL
+		ORDER BY d.created_at ASC
+	`
+	return r.listRefs(query, orgID, taskID)
+}
+
+// ListDependents returns the tasks that depend on taskID.
+func (r *TaskDependencyRepository) ListDependents(orgID, taskID uuid.UUID) ([]models.TaskRef, error) {
+	query := `
+		SELECT t.id, t.title, t.status
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		WHERE d.org_id = $1 AND d.depends_on_id = $2 AND t.deleted_at IS NULL
+		ORDER BY d.created_at ASC
+	`
+	return r.listRefs(query, orgID, taskID)
+}
+
+func (r *TaskDependencyRepository) listRefs(query string, args ...interface{}) ([]models.TaskRef, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refs := []models.TaskRef{}
+	for rows.Next() {
+		var ref models.TaskRef
+		if err := rows.Scan(&ref.ID, &ref.Title, &ref.Status); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// CountUnapprovedBlockers returns how many of taskID's predecessors are not yet approved.
+func (r *TaskDependencyRepository) CountUnapprovedBlockers(orgID, taskID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.depends_on_id
+		WHERE d.org_id = $1 AND d.task_id = $2 AND NOT ` + signedOffStatus("t.status", "d.org_id") + ` AND t.deleted_at IS NULL
+	`
+	var count int
+	err := r.db.QueryRow(query, orgID, taskID).Scan(&count)
+	return count, err
+}
@@ -7,6 +7,7 @@ import (
 	"saas-backend/internal/models"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type TaskRepository struct {
@@ -17,16 +18,40 @@ func NewTaskRepository(db *sql.DB) *TaskRepository {
 	return &TaskRepository{db: db}
 }
 
-func (r *TaskRepository) Create(task *models.Task) error {
+// Create inserts a task at the bottom of its status column and attaches
+// labelIDs, its assignments and its first history entry in the same
+// transaction, filling task.Labels so callers can index the task without
+// re-reading it.
+func (r *TaskRepository) Create(task *models.Task, labelIDs []uuid.UUID, history *models.HistoryEntry) error {
+	customFields, err := encodeCustomFields(task.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	last, err := lastRank(tx, task.OrgID, task.Status, nil)
+	if err != nil {
+		return err
+	}
+	task.BoardRank = rankBetween(last, "")
+
 	query := `
-		INSERT INTO tasks (id, org_id, title, description, status, priority, assigned_to, created_by, due_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING created_at, updated_at
+		INSERT INTO tasks (id, org_id, project_id, parent_id, recurring_task_id, title, description, status, priority, assigned_to, created_by, due_date, custom_fields, estimate_points, board_rank)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING created_at, updated_at, version
 	`
-	return r.db.QueryRow(
+	err = tx.QueryRow(
 		query,
 		task.ID,
 		task.OrgID,
+		task.ProjectID,
+		task.ParentID,
+		task.RecurringTaskID,
 		task.Title,
 		task.Description,
 		task.Status,
@@ -34,15 +59,73 @@ func (r *TaskRepository) Create(task *models.Task) error {
 		task.AssignedTo,
 		task.CreatedBy,
 		task.DueDate,
-	).Scan(&task.CreatedAt, &task.UpdatedAt)
+		customFields,
+		task.EstimatePoints,
+		task.BoardRank,
+	).Scan(&task.CreatedAt, &task.UpdatedAt, &task.Version)
+	if err != nil {
+		return err
+	}
+
+	if task.Labels, err = attachTaskLabels(tx, task.OrgID, task.ID, labelIDs); err != nil {
+		return err
+	}
+	if err := saveTaskPeople(tx, task); err != nil {
+		return err
+	}
+	if err := insertTaskHistory(tx, task.OrgID, task.ID, history); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *TaskRepository) GetByID(orgID, taskID uuid.UUID) (*models.Task, error) {
-	query := `
+// attachTaskLabels links a new task to org labels and returns them by name.
+func attachTaskLabels(tx *sql.Tx, orgID, taskID uuid.UUID, labelIDs []uuid.UUID) ([]models.Label, error) {
+	labels := []models.Label{}
+	if len(labelIDs) == 0 {
+		return labels, nil
+	}
+	rows, err := tx.Query(`
+		SELECT id, org_id, name, color, created_at, updated_at
+		FROM labels
+		WHERE org_id = $1 AND id = ANY($2)
+		ORDER BY name
+	`, orgID, pq.Array(labelIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var label models.Label
+		if err := rows.Scan(&label.ID, &label.OrgID, &label.Name, &label.Color, &label.CreatedAt, &label.UpdatedAt); err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(labels) != len(labelIDs) {
+		return nil, fmt.Errorf("label not found")
+	}
+
+	for _, label := range labels {
+		if _, err := tx.Exec(`INSERT INTO task_labels (task_id, label_id) VALUES ($1, $2)`, taskID, label.ID); err != nil {
+			return nil, err
+		}
+	}
+	return labels, nil
+}
+
+// taskSelect is the shared projection for task reads. It resolves user display
+// names, assignments, the subtask roll-up counts, labels and logged time so
+// every read returns the same shape.
+var taskSelect = `
 		SELECT
-			t.id, t.org_id, t.title, t.description, t.status, t.priority, t.assigned_to, t.created_by, t.due_date, t.created_at, t.updated_at,
+			t.id, t.org_id, t.project_id, t.parent_id, t.recurring_task_id, t.title, t.description, t.status, t.board_rank, t.priority, t.assigned_to, t.created_by, t.due_date, t.created_at, t.updated_at,
 			t.verified_by, t.verified_at, t.approved_by, t.approved_at,
-			t.document_filename, t.document_path, t.document_summary,
+			t.document_filename, t.document_path, t.document_summary, t.custom_fields,
+			t.sprint_id, t.estimate_points, t.completed_at,
 			CASE
 				WHEN au.id IS NULL THEN NULL
 				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
@@ -58,21 +141,41 @@ func (r *TaskRepository) GetByID(orgID, taskID uuid.UUID) (*models.Task, error)
 			CASE
 				WHEN apu.id IS NULL THEN NULL
 				ELSE CONCAT(COALESCE(apu.first_name, ''), ' ', COALESCE(apu.last_name, ''))
-			END AS approved_by_name
+			END AS approved_by_name,
+			(SELECT COUNT(*) FROM tasks st WHERE st.parent_id = t.id AND st.deleted_at IS NULL) AS subtask_total,
+			(SELECT COUNT(*) FROM tasks st WHERE st.parent_id = t.id AND st.deleted_at IS NULL AND ` + completedStatus("st.status", "t.org_id") + `) AS subtask_completed,
+			(SELECT COUNT(*) FROM tasks st WHERE st.parent_id = t.id AND st.deleted_at IS NULL AND ` + signedOffStatus("st.status", "t.org_id") + `) AS subtask_approved,
+			` + labelsSubquery("task_labels", "task_id", "t.id") + ` AS labels,
+			(SELECT COALESCE(SUM(w.duration_seconds), 0) FROM worklogs w WHERE w.task_id = t.id AND NOT w.is_running) AS time_spent_seconds,
+			t.rework_count, t.version,
+			(SELECT r.notes FROM task_reviews r WHERE r.task_id = t.id AND r.action = 'reject' ORDER BY r.created_at DESC LIMIT 1) AS latest_rejection_note,
+			` + taskPeopleSubquery(models.AssignmentAssignee) + ` AS assignees,
+			` + taskPeopleSubquery(models.AssignmentReviewer) + ` AS reviewers,
+			` + taskPeopleSubquery(models.AssignmentApprover) + ` AS approvers
 		FROM tasks t
 		LEFT JOIN users au ON au.id = t.assigned_to
 		LEFT JOIN users cu ON cu.id = t.created_by
 		LEFT JOIN users vu ON vu.id = t.verified_by
 		LEFT JOIN users apu ON apu.id = t.approved_by
-		WHERE t.org_id = $1 AND t.id = $2
-	`
-	task := &models.Task{}
-	err := r.db.QueryRow(query, orgID, taskID).Scan(
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTask(row rowScanner, task *models.Task) error {
+	var progress models.SubtaskProgress
+	var labels, customFields, assignees, reviewers, approvers []byte
+	err := row.Scan(
 		&task.ID,
 		&task.OrgID,
+		&task.ProjectID,
+		&task.ParentID,
+		&task.RecurringTaskID,
 		&task.Title,
 		&task.Description,
 		&task.Status,
+		&task.BoardRank,
 		&task.Priority,
 		&task.AssignedTo,
 		&task.CreatedBy,
@@ -86,118 +189,275 @@ func (r *TaskRepository) GetByID(orgID, taskID uuid.UUID) (*models.Task, error)
 		&task.DocumentFilename,
 		&task.DocumentPath,
 		&task.DocumentSummary,
+		&customFields,
+		&task.SprintID,
+		&task.EstimatePoints,
+		&task.CompletedAt,
 		&task.AssignedToName,
 		&task.CreatedByName,
 		&task.VerifiedByName,
 		&task.ApprovedByName,
+		&progress.Total,
+		&progress.Completed,
+		&progress.Approved,
+		&labels,
+		&task.TimeSpentSeconds,
+		&task.ReworkCount,
+		&task.Version,
+		&task.LatestRejectionNote,
+		&assignees,
+		&reviewers,
+		&approvers,
 	)
+	if err != nil {
+		return err
+	}
+	if task.Labels, err = decodeLabels(labels); err != nil {
+		return err
+	}
+	if task.CustomFields, err = decodeCustomFields(customFields); err != nil {
+		return err
+	}
+	if task.Assignees, err = decodeTaskPeople(assignees); err != nil {
+		return err
+	}
+	if task.Reviewers, err = decodeTaskPeople(reviewers); err != nil {
+		return err
+	}
+	if task.Approvers, err = decodeTaskPeople(approvers); err != nil {
+		return err
+	}
+	if progress.Total > 0 {
+		progress.Summary = fmt.Sprintf("%d/%d subtasks approved", progress.Approved, progress.Total)
+		task.SubtaskProgress = &progress
+	}
+	return nil
+}
+
+func (r *TaskRepository) queryTasks(query string, args ...interface{}) ([]models.Task, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		var task models.Task
+		if err := scanTask(rows, &task); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+func (r *TaskRepository) GetByID(orgID, taskID uuid.UUID) (*models.Task, error) {
+	query := taskSelect + `
+		WHERE t.org_id = $1 AND t.id = $2 AND t.deleted_at IS NULL
+	`
+	task := &models.Task{}
+	err := scanTask(r.db.QueryRow(query, orgID, taskID), task)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	return task, err
 }
 
-func (r *TaskRepository) List(orgID uuid.UUID, status string, priority string) ([]models.Task, error) {
-	base := `
-		SELECT
-			t.id, t.org_id, t.title, t.description, t.status, t.priority, t.assigned_to, t.created_by, t.due_date, t.created_at, t.updated_at,
-			t.verified_by, t.verified_at, t.approved_by, t.approved_at,
-			t.document_filename, t.document_path, t.document_summary,
-			CASE
-				WHEN au.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
-			END AS assigned_to_name,
-			CASE
-				WHEN cu.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(cu.first_name, ''), ' ', COALESCE(cu.last_name, ''))
-			END AS created_by_name,
-			CASE
-				WHEN vu.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(vu.first_name, ''), ' ', COALESCE(vu.last_name, ''))
-			END AS verified_by_name,
-			CASE
-				WHEN apu.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(apu.first_name, ''), ' ', COALESCE(apu.last_name, ''))
-			END AS approved_by_name
-		FROM tasks t
-		LEFT JOIN users au ON au.id = t.assigned_to
-		LEFT JOIN users cu ON cu.id = t.created_by
-		LEFT JOIN users vu ON vu.id = t.verified_by
-		LEFT JOIN users apu ON apu.id = t.approved_by
-		WHERE t.org_id = $1
+// taskListWhere builds the filtered task query without an ORDER BY, returning
+// the next free placeholder index.
+func taskListWhere(orgID uuid.UUID, filter models.TaskListFilter) (string, []interface{}, int, error) {
+	base := taskSelect + `
+		WHERE t.org_id = $1 AND t.deleted_at IS NULL
 	`
 
 	args := []interface{}{orgID}
 	argIdx := 2
 
-	if status != "" {
-		if status == "completed" {
+	if filter.Status != "" {
+		if filter.Status == "completed" {
 			// Aggregate completed statuses
-			base += " AND t.status IN ('done','verified','approved')"
+			base += " AND " + completedStatus("t.status", "t.org_id")
 		} else {
 			base += fmt.Sprintf(" AND t.status = $%d", argIdx)
-			args = append(args, status)
+			args = append(args, filter.Status)
 			argIdx++
 		}
 	}
-	if priority != "" {
+	if filter.Priority != "" {
 		base += fmt.Sprintf(" AND t.priority = $%d", argIdx)
-		args = append(args, priority)
+		args = append(args, filter.Priority)
+		argIdx++
+	}
+	if filter.AssigneeID != nil {
+		base += taskAssignedClause("t.id", argIdx, models.AssignmentAssignee)
+		args = append(args, *filter.AssigneeID)
+		argIdx++
+	}
+	if filter.VisibleTo != nil {
+		base += taskAssignedClause("t.id", argIdx, models.AssignmentAssignee, models.AssignmentReviewer, models.AssignmentApprover)
+		args = append(args, *filter.VisibleTo)
+		argIdx++
+	}
+	if filter.ParentID != nil {
+		base += fmt.Sprintf(" AND t.parent_id = $%d", argIdx)
+		args = append(args, *filter.ParentID)
+		argIdx++
+	}
+	if filter.TopLevelOnly {
+		base += " AND t.parent_id IS NULL"
+	}
+	if filter.RecurringTaskID != nil {
+		base += fmt.Sprintf(" AND t.recurring_task_id = $%d", argIdx)
+		args = append(args, *filter.RecurringTaskID)
+		argIdx++
+	}
+	if filter.ProjectID != nil {
+		base += fmt.Sprintf(" AND t.project_id = $%d", argIdx)
+		args = append(args, *filter.ProjectID)
+		argIdx++
+	}
+	if filter.SprintID != nil {
+		base += fmt.Sprintf(" AND t.sprint_id = $%d", argIdx)
+		args = append(args, *filter.SprintID)
 		argIdx++
+	} else if filter.Backlog {
+		base += " AND t.sprint_id IS NULL"
 	}
-	base += " ORDER BY t.created_at DESC"
+	if filter.WatcherID != nil {
+		base += watcherFilterClause("task_watchers", "task_id", "t.id", argIdx)
+		args = append(args, *filter.WatcherID)
+		argIdx++
+	}
+	if clause, labelArgs, next := labelFilterClause(filter.Labels, "task_labels", "task_id", "t.id", argIdx); clause != "" {
+		base += clause
+		args = append(args, labelArgs...)
+		argIdx = next
+	}
+	clause, fieldArgs, next, err := customFieldFilterClause(filter.CustomFields, "t.custom_fields", argIdx)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	base += clause
+	args = append(args, fieldArgs...)
+	argIdx = next
+	if filter.Query != "" {
+		clause, queryArgs, next, err := queryClause(filter.Query, taskQueryTarget, filter.Viewer, argIdx)
+		if err != nil {
+			return "", nil, 0, err
+		}
+		base += clause
+		args = append(args, queryArgs...)
+		argIdx = next
+	}
+	return base, args, argIdx, nil
+}
 
-	rows, err := r.db.Query(base, args...)
+func (r *TaskRepository) List(orgID uuid.UUID, filter models.TaskListFilter) ([]models.Task, error) {
+	base, args, _, err := taskListWhere(orgID, filter)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	if filter.SortByRank {
+		base += " ORDER BY t.board_rank ASC, t.created_at ASC"
+	} else {
+		base += " ORDER BY t.created_at DESC"
+	}
 
-	tasks := []models.Task{}
-	for rows.Next() {
-		var task models.Task
-		err := rows.Scan(
-			&task.ID,
-			&task.OrgID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&task.AssignedTo,
-			&task.CreatedBy,
-			&task.DueDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.VerifiedBy,
-			&task.VerifiedAt,
-			&task.ApprovedBy,
-			&task.ApprovedAt,
-			&task.DocumentFilename,
-			&task.DocumentPath,
-			&task.DocumentSummary,
-			&task.AssignedToName,
-			&task.CreatedByName,
-			&task.VerifiedByName,
-			&task.ApprovedByName,
-		)
-		if err != nil {
-			return nil, err
-		}
-		tasks = append(tasks, task)
+	return r.queryTasks(base, args...)
+}
+
+var taskPriorities = []string{"low", "medium", "high", "urgent"}
+
+var taskKeyset = keyset{
+	idExpr:      "t.id",
+	defaultSort: "created_at",
+	columns: map[string]sortColumn{
+		"created_at": {expr: "t.created_at", cast: "timestamptz", defaultOrder: "desc"},
+		"updated_at": {expr: "t.updated_at", cast: "timestamptz", defaultOrder: "desc"},
+		"due_date":   {expr: "COALESCE(t.due_date, 'infinity')", cast: "timestamptz", defaultOrder: "asc"},
+		"priority":   {expr: rankExpr("t.priority", taskPriorities), cast: "int", defaultOrder: "desc"},
+	},
+}
+
+// ListPage returns one page of tasks and the cursor for the next page, which
+// is empty on the last one.
+func (r *TaskRepository) ListPage(orgID uuid.UUID, filter models.TaskListFilter, page models.PageRequest) ([]models.Task, string, error) {
+	plan, err := taskKeyset.plan(page)
+	if err != nil {
+		return nil, "", err
 	}
-	return tasks, rows.Err()
+	base, args, argIdx, err := taskListWhere(orgID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	base, args = plan.apply(taskKeyset, base, args, argIdx)
+
+	tasks, err := r.queryTasks(base, args...)
+	if err != nil || !plan.more(len(tasks)) {
+		return tasks, "", err
+	}
+	tasks = tasks[:plan.limit]
+	last := tasks[len(tasks)-1]
+	var value string
+	switch plan.sort {
+	case "updated_at":
+		value = cursorTime(last.UpdatedAt)
+	case "due_date":
+		value = cursorOptionalTime(last.DueDate)
+	case "priority":
+		value = rankOf(last.Priority, taskPriorities)
+	default:
+		value = cursorTime(last.CreatedAt)
+	}
+	return tasks, plan.next(value, last.ID), nil
+}
+
+// Update saves a task and the history entry describing the change in one
+// transaction.
+func (r *TaskRepository) Update(task *models.Task, history *models.HistoryEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := updateTask(tx, task); err != nil {
+		return err
+	}
+	if err := insertTaskHistory(tx, task.OrgID, task.ID, history); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *TaskRepository) Update(task *models.Task) error {
+// updateTask writes a task and its assignments. q should be a transaction so
+// both land together.
+func updateTask(q queryExecer, task *models.Task) error {
+	customFields, err := encodeCustomFields(task.CustomFields)
+	if err != nil {
+		return err
+	}
+	// A task whose status changes lands at the bottom of its new column;
+	// otherwise it keeps its place.
+	last, err := lastRank(q, task.OrgID, task.Status, &task.ID)
+	if err != nil {
+		return err
+	}
 	query := `
 		UPDATE tasks
 		SET title = $1, description = $2, status = $3, priority = $4, assigned_to = $5, due_date = $6,
+			blocked_from_status = CASE WHEN status = $3 THEN blocked_from_status ELSE NULL END,
+			board_rank = CASE WHEN status = $3 THEN board_rank ELSE $20 END,
 			verified_by = $7, verified_at = $8, approved_by = $9, approved_at = $10,
 			document_filename = $11, document_path = $12, document_summary = $13,
-			updated_at = CURRENT_TIMESTAMP
-		WHERE org_id = $14 AND id = $15
+			project_id = $14, custom_fields = $15, estimate_points = $16,
+			completed_at = CASE WHEN ` + completedStatus("$3", "$17") + ` THEN COALESCE(completed_at, CURRENT_TIMESTAMP) ELSE NULL END,
+			updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE org_id = $17 AND id = $18 AND version = $19 AND deleted_at IS NULL
+		RETURNING board_rank, completed_at, updated_at, version
 	`
-	result, err := r.db.Exec(
+	err = q.QueryRow(
 		query,
 		task.Title,
 		task.Description,
@@ -212,107 +472,388 @@ func (r *TaskRepository) Update(task *models.Task) error {
 		task.DocumentFilename,
 		task.DocumentPath,
 		task.DocumentSummary,
+		task.ProjectID,
+		customFields,
+		task.EstimatePoints,
 		task.OrgID,
 		task.ID,
-	)
+		task.Version,
+		rankBetween(last, ""),
+	).Scan(&task.BoardRank, &task.CompletedAt, &task.UpdatedAt, &task.Version)
+	if err == sql.ErrNoRows {
+		return versionMiss(q, "tasks", "task", task.OrgID, task.ID)
+	}
 	if err != nil {
 		return err
 	}
+	return saveTaskPeople(q, task)
+}
 
-	rows, err := result.RowsAffected()
+// versionMiss explains a versioned update that matched no row: either the
+// record is gone or someone else changed it after it was read.
+func versionMiss(q queryRower, table, entity string, orgID, id uuid.UUID) error {
+	var exists bool
+	err := q.QueryRow(`SELECT EXISTS (SELECT 1 FROM `+table+` WHERE org_id = $1 AND id = $2 AND deleted_at IS NULL)`, orgID, id).Scan(&exists)
 	if err != nil {
 		return err
 	}
-	if rows == 0 {
-		return fmt.Errorf("task not found")
+	if !exists {
+		return fmt.Errorf("%s not found", entity)
 	}
-	return nil
+	return models.ErrVersionConflict
+}
+
+// SetDocumentSummary stores the AI summary of the document at documentPath.
+// It is meant for background writers: only document_summary is written, and
+// nothing changes if the task has since received a different document. It
+// reports whether the task was changed.
+func (r *TaskRepository) SetDocumentSummary(orgID, taskID uuid.UUID, documentPath, summary string) (bool, error) {
+	query := `
+		UPDATE tasks
+		SET document_summary = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE org_id = $2 AND id = $3 AND document_path = $4
+	`
+	result, err := r.db.Exec(query, summary, orgID, taskID, documentPath)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// Discard permanently deletes tasks that were only just created, such as
+// those of a template instantiation that failed part-way, without passing
+// through the trash.
+func (r *TaskRepository) Discard(orgID uuid.UUID, taskIDs []uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM tasks WHERE org_id = $1 AND id = ANY($2)`, orgID, pq.Array(taskIDs))
+	return err
+}
+
+// SoftDelete moves a task to the trash. It stays restorable until the trash
+// purge removes it for good.
+func (r *TaskRepository) SoftDelete(orgID, taskID, deletedBy uuid.UUID) error {
+	return softDelete(r.db, "tasks", "task", orgID, taskID, deletedBy)
+}
+
+// Restore takes a task back out of the trash.
+func (r *TaskRepository) Restore(orgID, taskID uuid.UUID) error {
+	return restore(r.db, "tasks", "task", orgID, taskID)
+}
+
+// ListByAssignee returns the tasks the user is one of the assignees of.
+func (r *TaskRepository) ListByAssignee(orgID, userID uuid.UUID) ([]models.Task, error) {
+	query := taskSelect + `
+		WHERE t.org_id = $1 AND t.deleted_at IS NULL` + taskAssignedClause("t.id", 2, models.AssignmentAssignee) + `
+		ORDER BY t.created_at DESC
+	`
+	return r.queryTasks(query, orgID, userID)
+}
+
+// CountOpenSubtasks returns how many direct children of a task are not yet completed.
+func (r *TaskRepository) CountOpenSubtasks(orgID, taskID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM tasks
+		WHERE org_id = $1 AND parent_id = $2 AND deleted_at IS NULL AND NOT ` + completedStatus("status", "org_id") + `
+	`
+	var count int
+	err := r.db.QueryRow(query, orgID, taskID).Scan(&count)
+	return count, err
+}
+
+// MarkBlocked moves an active task (one in a todo or in-progress status) to
+// "blocked", remembering its current status, and puts it at the bottom of the
+// blocked column. It returns the status the task was blocked from, or "" when
+// the task was not changed.
+func (r *TaskRepository) MarkBlocked(orgID, taskID uuid.UUID) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		UPDATE tasks
+		SET blocked_from_status = status, status = 'blocked', updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE org_id = $1 AND id = $2
+			AND (` + statusInCategory("status", "org_id", models.CategoryTodo) + `
+				OR ` + statusInCategory("status", "org_id", models.CategoryInProgress) + `)
+		RETURNING blocked_from_status
+	`
+	from, err := changedStatus(tx.QueryRow(query, orgID, taskID))
+	if err != nil || from == "" {
+		return "", err
+	}
+	if err := rankAtEnd(tx, orgID, taskID, "blocked"); err != nil {
+		return "", err
+	}
+	return from, tx.Commit()
 }
 
-func (r *TaskRepository) Delete(orgID, taskID uuid.UUID) error {
-	query := `DELETE FROM tasks WHERE org_id = $1 AND id = $2`
-	result, err := r.db.Exec(query, orgID, taskID)
+// ClearBlocked restores an automatically blocked task to the status it had
+// before it was blocked, at the bottom of that column. Tasks blocked by hand
+// are left alone. It returns the restored status, or "" when the task was not
+// changed.
+func (r *TaskRepository) ClearBlocked(orgID, taskID uuid.UUID) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	status, err := changedStatus(tx.QueryRow(`
+		UPDATE tasks
+		SET status = blocked_from_status, blocked_from_status = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE org_id = $1 AND id = $2 AND status = 'blocked' AND blocked_from_status IS NOT NULL
+		RETURNING status
+	`, orgID, taskID))
+	if err != nil || status == "" {
+		return "", err
+	}
+	if err := rankAtEnd(tx, orgID, taskID, status); err != nil {
+		return "", err
+	}
+	return status, tx.Commit()
+}
+
+// changedStatus scans the status returned by a conditional update, mapping
+// "no row updated" to "".
+func changedStatus(row *sql.Row) (string, error) {
+	var status string
+	if err := row.Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return status, nil
+}
+
+// queryRower and execer are satisfied by both *sql.DB and *sql.Tx, so writes
+// can be shared between single-row and transactional bulk paths.
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+type queryExecer interface {
+	queryRower
+	execer
+}
+
+// lastRank returns the highest rank in a status column, or "" when the column
+// is empty. excludeID leaves one task out, typically the one being moved.
+func lastRank(q queryRower, orgID uuid.UUID, status string, excludeID *uuid.UUID) (string, error) {
+	var rank string
+	err := q.QueryRow(`
+		SELECT COALESCE(MAX(board_rank), '')
+		FROM tasks
+		WHERE org_id = $1 AND status = $2 AND ($3::uuid IS NULL OR id <> $3)
+	`, orgID, status, excludeID).Scan(&rank)
+	return rank, err
+}
+
+// rankAtEnd puts a task that has just entered a status column at the bottom
+// of it, as a board move with no neighbors would. q should be the
+// transaction that changed the status.
+func rankAtEnd(q queryExecer, orgID, taskID uuid.UUID, status string) error {
+	last, err := lastRank(q, orgID, status, &taskID)
 	if err != nil {
 		return err
 	}
+	_, err = q.Exec(`UPDATE tasks SET board_rank = $1 WHERE org_id = $2 AND id = $3`, rankBetween(last, ""), orgID, taskID)
+	return err
+}
 
-	rows, err := result.RowsAffected()
+// adjacentRank returns the nearest rank above (below=false) or below
+// (below=true) the given rank in a status column, or "" if there is none.
+func adjacentRank(q queryRower, orgID uuid.UUID, status, rank string, below bool, excludeID uuid.UUID) (string, error) {
+	query := `
+		SELECT COALESCE(MAX(board_rank), '')
+		FROM tasks
+		WHERE org_id = $1 AND status = $2 AND board_rank < $3 AND id <> $4
+	`
+	if below {
+		query = `
+			SELECT COALESCE(MIN(board_rank), '')
+			FROM tasks
+			WHERE org_id = $1 AND status = $2 AND board_rank > $3 AND id <> $4
+		`
+	}
+	var out string
+	err := q.QueryRow(query, orgID, status, rank, excludeID).Scan(&out)
+	return out, err
+}
+
+// rebalanceColumn rewrites a column's ranks evenly, keeping the current order.
+// It is only needed when duplicate ranks leave no room between neighbors.
+func rebalanceColumn(tx *sql.Tx, orgID uuid.UUID, status string) error {
+	_, err := tx.Exec(`
+		UPDATE tasks t
+		SET board_rank = LPAD(ranked.pos::TEXT, 8, '0') || 'i'
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY board_rank, created_at, id) AS pos
+			FROM tasks
+			WHERE org_id = $1 AND status = $2
+		) ranked
+		WHERE t.id = ranked.id
+	`, orgID, status)
+	return err
+}
+
+// TaskMove describes a board move. AfterID and BeforeID are the neighbors the
+// task should sit between in the target column; either may be nil. WIPLimit,
+// when set, caps the target column if the task is entering it.
+type TaskMove struct {
+	FromStatus string
+	ToStatus   string
+	AfterID    *uuid.UUID
+	BeforeID   *uuid.UUID
+	WIPLimit   *int
+	// Review, when set, is recorded with the move.
+	Review *models.TaskReview
+	// Stamps carries the task's verified and approved stamps as they should
+	// read after the move.
+	Stamps *models.Task
+	// History, when set, is recorded with the move.
+	History *models.HistoryEntry
+}
+
+// Move changes a task's status and board position in one transaction. It
+// fails if the task's status changed since the caller read it.
+func (r *TaskRepository) Move(orgID, taskID uuid.UUID, move TaskMove) error {
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
-	if rows == 0 {
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Serialize moves within the target column so WIP counts and neighbor
+	// ranks stay consistent.
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1::text || ':' || $2))`, orgID, move.ToStatus); err != nil {
+		return err
+	}
+
+	var current string
+	err = tx.QueryRow(`SELECT status FROM tasks WHERE org_id = $1 AND id = $2 FOR UPDATE`, orgID, taskID).Scan(&current)
+	if err == sql.ErrNoRows {
 		return fmt.Errorf("task not found")
 	}
-	return nil
-}
+	if err != nil {
+		return err
+	}
+	if current != move.FromStatus {
+		return fmt.Errorf("task status changed, reload the board and try again")
+	}
+
+	if move.WIPLimit != nil && move.ToStatus != move.FromStatus {
+		var count int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM tasks WHERE org_id = $1 AND status = $2 AND deleted_at IS NULL`, orgID, move.ToStatus).Scan(&count); err != nil {
+			return err
+		}
+		if count >= *move.WIPLimit {
+			return fmt.Errorf("WIP limit of %d reached for %q", *move.WIPLimit, move.ToStatus)
+		}
+	}
+
+	rank, err := r.placeInColumn(tx, orgID, taskID, move)
+	if err != nil {
+		return err
+	}
 
-func (r *TaskRepository) ListByAssignee(orgID, userID uuid.UUID) ([]models.Task, error) {
 	query := `
-		SELECT
-			t.id, t.org_id, t.title, t.description, t.status, t.priority, t.assigned_to, t.created_by, t.due_date, t.created_at, t.updated_at,
-			t.verified_by, t.verified_at, t.approved_by, t.approved_at,
-			t.document_filename, t.document_path, t.document_summary,
-			CASE
-				WHEN au.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
-			END AS assigned_to_name,
-			CASE
-				WHEN cu.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(cu.first_name, ''), ' ', COALESCE(cu.last_name, ''))
-			END AS created_by_name,
-			CASE
-				WHEN vu.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(vu.first_name, ''), ' ', COALESCE(vu.last_name, ''))
-			END AS verified_by_name,
-			CASE
-				WHEN apu.id IS NULL THEN NULL
-				ELSE CONCAT(COALESCE(apu.first_name, ''), ' ', COALESCE(apu.last_name, ''))
-			END AS approved_by_name
-		FROM tasks t
-		LEFT JOIN users au ON au.id = t.assigned_to
-		LEFT JOIN users cu ON cu.id = t.created_by
-		LEFT JOIN users vu ON vu.id = t.verified_by
-		LEFT JOIN users apu ON apu.id = t.approved_by
-		WHERE t.org_id = $1 AND t.assigned_to = $2
-		ORDER BY t.created_at DESC
+		UPDATE tasks
+		SET status = $1, board_rank = $2,
+			blocked_from_status = CASE WHEN status = $1 THEN blocked_from_status ELSE NULL END,
+			completed_at = CASE WHEN ` + completedStatus("$1", "$3") + ` THEN COALESCE(completed_at, CURRENT_TIMESTAMP) ELSE NULL END,
+			verified_by = $5, verified_at = $6, approved_by = $7, approved_at = $8,
+			updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE org_id = $3 AND id = $4
 	`
-	rows, err := r.db.Query(query, orgID, userID)
+	_, err = tx.Exec(
+		query, move.ToStatus, rank, orgID, taskID,
+		move.Stamps.VerifiedBy, move.Stamps.VerifiedAt, move.Stamps.ApprovedBy, move.Stamps.ApprovedAt,
+	)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if move.Review != nil {
+		if err := insertTaskReview(tx, move.Review); err != nil {
+			return err
+		}
+	}
+	if err := insertTaskHistory(tx, orgID, taskID, move.History); err != nil {
+		return err
 	}
-	defer rows.Close()
 
-	tasks := []models.Task{}
-	for rows.Next() {
-		var task models.Task
-		err := rows.Scan(
-			&task.ID,
-			&task.OrgID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&task.AssignedTo,
-			&task.CreatedBy,
-			&task.DueDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.VerifiedBy,
-			&task.VerifiedAt,
-			&task.ApprovedBy,
-			&task.ApprovedAt,
-			&task.DocumentFilename,
-			&task.DocumentPath,
-			&task.DocumentSummary,
-			&task.AssignedToName,
-			&task.CreatedByName,
-			&task.VerifiedByName,
-			&task.ApprovedByName,
-		)
+	return tx.Commit()
+}
+
+// placeInColumn computes the rank between the requested neighbors, rebalancing
+// the column once if duplicate ranks leave no gap.
+func (r *TaskRepository) placeInColumn(tx *sql.Tx, orgID, taskID uuid.UUID, move TaskMove) (string, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		prev, next, err := r.neighborRanks(tx, orgID, taskID, move)
 		if err != nil {
-			return nil, err
+			return "", err
+		}
+		if next == "" || prev < next {
+			return rankBetween(prev, next), nil
+		}
+		if move.AfterID != nil && move.BeforeID != nil && prev > next {
+			return "", fmt.Errorf("after_id must come before before_id")
+		}
+		if err := rebalanceColumn(tx, orgID, move.ToStatus); err != nil {
+			return "", err
 		}
-		tasks = append(tasks, task)
 	}
-	return tasks, rows.Err()
+	return "", fmt.Errorf("failed to rank task")
+}
+
+func (r *TaskRepository) neighborRanks(tx *sql.Tx, orgID, taskID uuid.UUID, move TaskMove) (string, string, error) {
+	neighbor := func(id uuid.UUID) (string, error) {
+		if id == taskID {
+			return "", fmt.Errorf("a task cannot be its own neighbor")
+		}
+		var status, rank string
+		err := tx.QueryRow(`SELECT status, board_rank FROM tasks WHERE org_id = $1 AND id = $2`, orgID, id).Scan(&status, &rank)
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("neighbor task not found")
+		}
+		if err != nil {
+			return "", err
+		}
+		if status != move.ToStatus {
+			return "", fmt.Errorf("neighbor task is not in the %q column", move.ToStatus)
+		}
+		return rank, nil
+	}
+
+	var prev, next string
+	var err error
+	switch {
+	case move.AfterID != nil && move.BeforeID != nil:
+		if prev, err = neighbor(*move.AfterID); err != nil {
+			return "", "", err
+		}
+		next, err = neighbor(*move.BeforeID)
+	case move.AfterID != nil:
+		if prev, err = neighbor(*move.AfterID); err != nil {
+			return "", "", err
+		}
+		next, err = adjacentRank(tx, orgID, move.ToStatus, prev, true, taskID)
+	case move.BeforeID != nil:
+		if next, err = neighbor(*move.BeforeID); err != nil {
+			return "", "", err
+		}
+		prev, err = adjacentRank(tx, orgID, move.ToStatus, next, false, taskID)
+	default:
+		prev, err = lastRank(tx, orgID, move.ToStatus, &taskID)
+	}
+	return prev, next, err
 }
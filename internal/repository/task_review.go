@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// insertTaskReview appends an entry to a task's review history.
+func insertTaskReview(q queryRower, review *models.TaskReview) error {
+	return q.QueryRow(`
+		INSERT INTO task_reviews (id, org_id, task_id, actor_id, action, from_status, to_status, notes, document_filename, document_path)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at
+	`,
+		review.ID,
+		review.OrgID,
+		review.TaskID,
+		review.ActorID,
+		review.Action,
+		review.FromStatus,
+		review.ToStatus,
+		review.Notes,
+		review.DocumentFilename,
+		review.DocumentPath,
+	).Scan(&review.CreatedAt)
+}
+
+// UpdateWithReview saves a task and records a review step and the change
+// history in one transaction. A rejection also increments the task's rework
+// count and becomes its latest rejection note.
+func (r *TaskRepository) UpdateWithReview(task *models.Task, review *models.TaskReview, history *models.HistoryEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := updateTask(tx, task); err != nil {
+		return err
+	}
+	if err := insertTaskReview(tx, review); err != nil {
+		return err
+	}
+	if review.Action == models.ReviewReject {
+		err := tx.QueryRow(`
+			UPDATE tasks SET rework_count = rework_count + 1
+			WHERE org_id = $1 AND id = $2
+			RETURNING rework_count
+		`, task.OrgID, task.ID).Scan(&task.ReworkCount)
+		if err != nil {
+			return err
+		}
+		task.LatestRejectionNote = review.Notes
+	}
+	if err := insertTaskHistory(tx, task.OrgID, task.ID, history); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListReviews returns a task's review history, oldest first.
+func (r *TaskRepository) ListReviews(orgID, taskID uuid.UUID) ([]models.TaskReview, error) {
+	rows, err := r.db.Query(`
+		SELECT r.id, r.org_id, r.task_id, r.actor_id, r.action, r.from_status, r.to_status,
+			r.notes, r.document_filename, r.document_path, r.created_at,
+			CASE
+				WHEN u.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, ''))
+			END AS actor_name
+		FROM task_reviews r
+		LEFT JOIN users u ON u.id = r.actor_id
+		WHERE r.org_id = $1 AND r.task_id = $2
+		ORDER BY r.created_at ASC, r.id ASC
+	`, orgID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []models.TaskReview{}
+	for rows.Next() {
+		var review models.TaskReview
+		err := rows.Scan(
+			&review.ID,
+			&review.OrgID,
+			&review.TaskID,
+			&review.ActorID,
+			&review.Action,
+			&review.FromStatus,
+			&review.ToStatus,
+			&review.Notes,
+			&review.DocumentFilename,
+			&review.DocumentPath,
+			&review.CreatedAt,
+			&review.ActorName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
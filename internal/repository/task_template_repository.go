@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type TaskTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewTaskTemplateRepository(db *sql.DB) *TaskTemplateRepository {
+	return &TaskTemplateRepository{db: db}
+}
+
+// Create inserts a template and links labelIDs in one transaction.
+func (r *TaskTemplateRepository) Create(tmpl *models.TaskTemplate, labelIDs []uuid.UUID) error {
+	subtasks, err := json.Marshal(tmpl.Subtasks)
+	if err != nil {
+		return fmt.Errorf("failed to encode subtasks: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		INSERT INTO task_templates (id, org_id, project_id, name, title, description, priority, assigned_to, due_offset_minutes, subtasks, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRow(
+		query,
+		tmpl.ID,
+		tmpl.OrgID,
+		tmpl.ProjectID,
+		tmpl.Name,
+		tmpl.Title,
+		tmpl.Description,
+		tmpl.Priority,
+		tmpl.AssignedTo,
+		tmpl.DueOffsetMinutes,
+		subtasks,
+		tmpl.CreatedBy,
+	).Scan(&tmpl.CreatedAt, &tmpl.UpdatedAt)
+	if err != nil {
+		return mapTaskTemplateError(err)
+	}
+
+	if err := setTemplateLabels(tx, tmpl.OrgID, tmpl.ID, labelIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+var taskTemplateSelect = `
+		SELECT
+			tt.id, tt.org_id, tt.project_id, tt.name, tt.title, COALESCE(tt.description, ''), tt.priority,
+			tt.assigned_to, tt.due_offset_minutes, tt.subtasks, tt.created_by, tt.created_at, tt.updated_at,
+			CASE
+				WHEN au.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(au.first_name, ''), ' ', COALESCE(au.last_name, ''))
+			END AS assigned_to_name,
+			` + labelsSubquery("task_template_labels", "template_id", "tt.id") + ` AS labels
+		FROM task_templates tt
+		LEFT JOIN users au ON au.id = tt.assigned_to
+`
+
+func scanTaskTemplate(row rowScanner, tmpl *models.TaskTemplate) error {
+	var subtasks, labels []byte
+	err := row.Scan(
+		&tmpl.ID,
+		&tmpl.OrgID,
+		&tmpl.ProjectID,
+		&tmpl.Name,
+		&tmpl.Title,
+		&tmpl.Description,
+		&tmpl.Priority,
+		&tmpl.AssignedTo,
+		&tmpl.DueOffsetMinutes,
+		&subtasks,
+		&tmpl.CreatedBy,
+		&tmpl.CreatedAt,
+		&tmpl.UpdatedAt,
+		&tmpl.AssignedToName,
+		&labels,
+	)
+	if err != nil {
+		return err
+	}
+	tmpl.Subtasks = []models.TemplateSubtask{}
+	if len(subtasks) > 0 {
+		if err := json.Unmarshal(subtasks, &tmpl.Subtasks); err != nil {
+			return fmt.Errorf("failed to decode subtasks: %w", err)
+		}
+	}
+	tmpl.Labels, err = decodeLabels(labels)
+	return err
+}
+
+func (r *TaskTemplateRepository) GetByID(orgID, id uuid.UUID) (*models.TaskTemplate, error) {
+	query := taskTemplateSelect + `
+		WHERE tt.org_id = $1 AND tt.id = $2
+	`
+	tmpl := &models.TaskTemplate{}
+	err := scanTaskTemplate(r.db.QueryRow(query, orgID, id), tmpl)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return tmpl, err
+}
+
+func (r *TaskTemplateRepository) List(orgID uuid.UUID) ([]models.TaskTemplate, error) {
+	query := taskTemplateSelect + `
+		WHERE tt.org_id = $1
+		ORDER BY LOWER(tt.name) ASC
+	`
+	rows, err := r.db.Query(query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.TaskTemplate{}
+	for rows.Next() {
+		var tmpl models.TaskTemplate
+		if err := scanTaskTemplate(rows, &tmpl); err != nil {
+			return nil, err
+		}
+		items = append(items, tmpl)
+	}
+	return items, rows.Err()
+}
+
+// Update saves a template and replaces its labels with labelIDs.
+func (r *TaskTemplateRepository) Update(tmpl *models.TaskTemplate, labelIDs []uuid.UUID) error {
+	subtasks, err := json.Marshal(tmpl.Subtasks)
+	if err != nil {
+		return fmt.Errorf("failed to encode subtasks: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		UPDATE task_templates
+		SET name = $1, title = $2, description = $3, priority = $4, assigned_to = $5,
+			due_offset_minutes = $6, subtasks = $7, project_id = $8
+		WHERE org_id = $9 AND id = $10
+		RETURNING updated_at
+	`
+	err = tx.QueryRow(
+		query,
+		tmpl.Name,
+		tmpl.Title,
+		tmpl.Description,
+		tmpl.Priority,
+		tmpl.AssignedTo,
+		tmpl.DueOffsetMinutes,
+		subtasks,
+		tmpl.ProjectID,
+		tmpl.OrgID,
+		tmpl.ID,
+	).Scan(&tmpl.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("task template not found")
+	}
+	if err != nil {
+		return mapTaskTemplateError(err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM task_template_labels WHERE template_id = $1`, tmpl.ID); err != nil {
+		return err
+	}
+	if err := setTemplateLabels(tx, tmpl.OrgID, tmpl.ID, labelIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *TaskTemplateRepository) Delete(orgID, id uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM task_templates WHERE org_id = $1 AND id = $2`, orgID, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("task template not found")
+	}
+	return nil
+}
+
+// setTemplateLabels links org labels to a template, failing if any ID does
+// not name a label in the organization.
+func setTemplateLabels(tx *sql.Tx, orgID, templateID uuid.UUID, labelIDs []uuid.UUID) error {
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	result, err := tx.Exec(`
+		INSERT INTO task_template_labels (template_id, label_id)
+		SELECT $1, id FROM labels WHERE org_id = $2 AND id = ANY($3)
+	`, templateID, orgID, pq.Array(labelIDs))
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows != int64(len(labelIDs)) {
+		return fmt.Errorf("label not found")
+	}
+	return nil
+}
+
+func mapTaskTemplateError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("a task template with this name already exists")
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// versionBump advances the version of tables that have one, so an edit based
+// on a read from before a delete or restore fails rather than overwriting it.
+func versionBump(table string) string {
+	if table == "tasks" || table == "issues" {
+		return ", version = version + 1"
+	}
+	return ""
+}
+
+// softDelete moves one live row of table to the trash.
+func softDelete(ex execer, table, entity string, orgID, id, deletedBy uuid.UUID) error {
+	result, err := ex.Exec(`
+		UPDATE `+table+`
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $3`+versionBump(table)+`
+		WHERE org_id = $1 AND id = $2 AND deleted_at IS NULL
+	`, orgID, id, deletedBy)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s not found", entity)
+	}
+	return nil
+}
+
+// softDeleteMany moves many live rows of table to the trash at once.
+func softDeleteMany(ex execer, table string, orgID uuid.UUID, ids []uuid.UUID, deletedBy uuid.UUID) error {
+	_, err := ex.Exec(`
+		UPDATE `+table+`
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $3`+versionBump(table)+`
+		WHERE org_id = $1 AND id = ANY($2) AND deleted_at IS NULL
+	`, orgID, pq.Array(ids), deletedBy)
+	return err
+}
+
+// restore takes one row of table back out of the trash.
+func restore(ex execer, table, entity string, orgID, id uuid.UUID) error {
+	result, err := ex.Exec(`
+		UPDATE `+table+`
+		SET deleted_at = NULL, deleted_by = NULL`+versionBump(table)+`
+		WHERE org_id = $1 AND id = $2 AND deleted_at IS NOT NULL
+	`, orgID, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s not found in trash", entity)
+	}
+	return nil
+}
+
+// SoftDelete moves a document to the trash.
+func (r *DocumentRepository) SoftDelete(orgID, documentID, deletedBy uuid.UUID) error {
+	return softDelete(r.db, "documents", "document", orgID, documentID, deletedBy)
+}
+
+// Restore takes a document back out of the trash.
+func (r *DocumentRepository) Restore(orgID, documentID uuid.UUID) error {
+	return restore(r.db, "documents", "document", orgID, documentID)
+}
+
+type TrashRepository struct {
+	db *sql.DB
+}
+
+func NewTrashRepository(db *sql.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// trashSources maps each trash entity type to the query listing its deleted
+// rows. Each query takes the org ID as $1.
+var trashSources = map[string]string{
+	models.TrashTask: `
+		SELECT 'task', x.id, x.title, x.deleted_at, x.deleted_by
+		FROM tasks x
+		WHERE x.org_id = $1 AND x.deleted_at IS NOT NULL`,
+	models.TrashIssue: `
+		SELECT 'issue', x.id, x.title, x.deleted_at, x.deleted_by
+		FROM issues x
+		WHERE x.org_id = $1 AND x.deleted_at IS NOT NULL`,
+	models.TrashDocument: `
+		SELECT 'document', x.id, COALESCE(x.title, x.filename), x.deleted_at, x.deleted_by
+		FROM documents x
+		WHERE x.org_id = $1 AND x.deleted_at IS NOT NULL`,
+}
+
+// List returns the organization's deleted items, most recently deleted first.
+// An empty entityType lists every type.
+func (r *TrashRepository) List(orgID uuid.UUID, entityType string) ([]models.TrashItem, error) {
+	parts := []string{}
+	for _, t := range []string{models.TrashTask, models.TrashIssue, models.TrashDocument} {
+		if entityType == "" || entityType == t {
+			parts = append(parts, trashSources[t])
+		}
+	}
+	query := `
+		SELECT items.*,
+			CASE
+				WHEN u.id IS NULL THEN NULL
+				ELSE CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, ''))
+			END AS deleted_by_name
+		FROM (`
+	for i, part := range parts {
+		if i > 0 {
+			query += "\n\t\t\tUNION ALL"
+		}
+		query += part
+	}
+	query += `
+		) AS items (entity_type, id, title, deleted_at, deleted_by)
+		LEFT JOIN users u ON u.id = items.deleted_by
+		ORDER BY items.deleted_at DESC, items.id
+	`
+
+	rows, err := r.db.Query(query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.TrashItem{}
+	for rows.Next() {
+		var item models.TrashItem
+		if err := rows.Scan(&item.EntityType, &item.ID, &item.Title, &item.DeletedAt, &item.DeletedBy, &item.DeletedByName); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// PurgedItem is a trashed row that Purge removed for good.
+type PurgedItem struct {
+	OrgID      uuid.UUID
+	EntityType string
+	ID         uuid.UUID
+	// StoragePath is the uploaded file of a purged document.
+	StoragePath string
+}
+
+// Purge permanently removes everything deleted before cutoff, across all
+// organizations, in one transaction.
+func (r *TrashRepository) Purge(cutoff time.Time) ([]PurgedItem, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	purged := []PurgedItem{}
+	for _, q := range []struct{ entityType, query string }{
+		{models.TrashTask, `DELETE FROM tasks WHERE deleted_at < $1 RETURNING org_id, id, ''`},
+		{models.TrashIssue, `DELETE FROM issues WHERE deleted_at < $1 RETURNING org_id, id, ''`},
+		{models.TrashDocument, `DELETE FROM documents WHERE deleted_at < $1 RETURNING org_id, id, storage_path`},
+	} {
+		rows, err := tx.Query(q.query, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			item := PurgedItem{EntityType: q.entityType}
+			if err := rows.Scan(&item.OrgID, &item.ID, &item.StoragePath); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			purged = append(purged, item)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return purged, tx.Commit()
+}
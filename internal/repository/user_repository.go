@@ -7,6 +7,7 @@ import (
 	"saas-backend/internal/models"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type UserRepository struct {
@@ -185,3 +186,26 @@ func (r *UserRepository) Delete(orgID, userID uuid.UUID) error {
 	}
 	return nil
 }
+
+// ListActiveIDsByEmail resolves email addresses, case-insensitively, to the
+// IDs of active users in the organization. Unknown addresses are skipped.
+func (r *UserRepository) ListActiveIDsByEmail(orgID uuid.UUID, emails []string) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	if len(emails) == 0 {
+		return ids, nil
+	}
+	rows, err := r.db.Query(`SELECT id FROM users WHERE org_id = $1 AND is_active AND LOWER(email) = ANY($2)`, orgID, pq.Array(emails))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WatcherRepository struct {
+	db *sql.DB
+}
+
+func NewWatcherRepository(db *sql.DB) *WatcherRepository {
+	return &WatcherRepository{db: db}
+}
+
+// watcherFilterClause renders a condition selecting entities watched by the
+// user bound to $argIdx.
+func watcherFilterClause(joinTable, fkColumn, entityRef string, argIdx int) string {
+	return fmt.Sprintf(" AND EXISTS (SELECT 1 FROM %s w WHERE w.%s = %s AND w.user_id = $%d)", joinTable, fkColumn, entityRef, argIdx)
+}
+
+// WatchTask adds users as watchers of a task. Users already watching are
+// left as they are.
+func (r *WatcherRepository) WatchTask(taskID uuid.UUID, userIDs ...uuid.UUID) error {
+	return r.watch(`
+		INSERT INTO task_watchers (task_id, user_id)
+		SELECT $1, UNNEST($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`, taskID, userIDs)
+}
+
+func (r *WatcherRepository) UnwatchTask(taskID, userID uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2`, taskID, userID)
+	return err
+}
+
+func (r *WatcherRepository) ListTaskWatchers(taskID uuid.UUID) ([]models.Watcher, error) {
+	return r.list(`
+		SELECT u.id, CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')), u.email, w.created_at
+		FROM task_watchers w
+		JOIN users u ON u.id = w.user_id
+		WHERE w.task_id = $1
+		ORDER BY w.created_at ASC
+	`, taskID)
+}
+
+func (r *WatcherRepository) WatchIssue(issueID uuid.UUID, userIDs ...uuid.UUID) error {
+	return r.watch(`
+		INSERT INTO issue_watchers (issue_id, user_id)
+		SELECT $1, UNNEST($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`, issueID, userIDs)
+}
+
+func (r *WatcherRepository) UnwatchIssue(issueID, userID uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM issue_watchers WHERE issue_id = $1 AND user_id = $2`, issueID, userID)
+	return err
+}
+
+func (r *WatcherRepository) ListIssueWatchers(issueID uuid.UUID) ([]models.Watcher, error) {
+	return r.list(`
+		SELECT u.id, CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')), u.email, w.created_at
+		FROM issue_watchers w
+		JOIN users u ON u.id = w.user_id
+		WHERE w.issue_id = $1
+		ORDER BY w.created_at ASC
+	`, issueID)
+}
+
+func (r *WatcherRepository) watch(query string, entityID uuid.UUID, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := r.db.Exec(query, entityID, pq.Array(userIDs))
+	return err
+}
+
+func (r *WatcherRepository) list(query string, entityID uuid.UUID) ([]models.Watcher, error) {
+	rows, err := r.db.Query(query, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	watchers := []models.Watcher{}
+	for rows.Next() {
+		var w models.Watcher
+		if err := rows.Scan(&w.UserID, &w.Name, &w.Email, &w.WatchedAt); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, w)
+	}
+	return watchers, rows.Err()
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WorkflowRepository struct {
+	db *sql.DB
+}
+
+func NewWorkflowRepository(db *sql.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// Get returns the organization's stored workflow, or nil if none is configured.
+func (r *WorkflowRepository) Get(orgID uuid.UUID) (*models.Workflow, error) {
+	statusRows, err := r.db.Query(`
+		SELECT key, name, position, is_initial, wip_limit, category, COALESCE(review_step, '')
+		FROM workflow_statuses
+		WHERE org_id = $1
+		ORDER BY position ASC, key ASC
+	`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer statusRows.Close()
+
+	wf := &models.Workflow{OrgID: orgID, Statuses: []models.WorkflowStatus{}, Transitions: []models.WorkflowTransition{}}
+	for statusRows.Next() {
+		var st models.WorkflowStatus
+		if err := statusRows.Scan(&st.Key, &st.Name, &st.Position, &st.IsInitial, &st.WIPLimit, &st.Category, &st.ReviewStep); err != nil {
+			return nil, err
+		}
+		wf.Statuses = append(wf.Statuses, st)
+	}
+	if err := statusRows.Err(); err != nil {
+		return nil, err
+	}
+	if len(wf.Statuses) == 0 {
+		return nil, nil
+	}
+
+	transitionRows, err := r.db.Query(`
+		SELECT from_status, to_status, allowed_roles
+		FROM workflow_transitions
+		WHERE org_id = $1
+		ORDER BY from_status ASC, to_status ASC
+	`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer transitionRows.Close()
+
+	for transitionRows.Next() {
+		var tr models.WorkflowTransition
+		if err := transitionRows.Scan(&tr.FromStatus, &tr.ToStatus, pq.Array(&tr.AllowedRoles)); err != nil {
+			return nil, err
+		}
+		wf.Transitions = append(wf.Transitions, tr)
+	}
+	return wf, transitionRows.Err()
+}
+
+// Replace swaps the organization's workflow for a new definition atomically.
+func (r *WorkflowRepository) Replace(orgID uuid.UUID, wf *models.Workflow) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`DELETE FROM workflow_transitions WHERE org_id = $1`, orgID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM workflow_statuses WHERE org_id = $1`, orgID); err != nil {
+		return err
+	}
+
+	for _, st := range wf.Statuses {
+		_, err := tx.Exec(`
+			INSERT INTO workflow_statuses (id, org_id, key, name, position, is_initial, wip_limit, category, review_step)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))
+		`, uuid.New(), orgID, st.Key, st.Name, st.Position, st.IsInitial, st.WIPLimit, st.Category, st.ReviewStep)
+		if err != nil {
+			return err
+		}
+	}
+	for _, tr := range wf.Transitions {
+		_, err := tx.Exec(`
+			INSERT INTO workflow_transitions (id, org_id, from_status, to_status, allowed_roles)
+			VALUES ($1, $2, $3, $4, $5)
+		`, uuid.New(), orgID, tr.FromStatus, tr.ToStatus, pq.Array(tr.AllowedRoles))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListStatusesInUse returns the distinct statuses currently held by the organization's tasks.
+func (r *WorkflowRepository) ListStatusesInUse(orgID uuid.UUID) ([]string, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT status FROM tasks WHERE org_id = $1`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := []string{}
+	for rows.Next() {
+		var st string
+		if err := rows.Scan(&st); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, rows.Err()
+}
+
+// statusInCategory returns a condition that statusExpr is one of the
+// organization's statuses in the given category.
+func statusInCategory(statusExpr, orgExpr, category string) string {
+	return fmt.Sprintf(
+		"%s IN (SELECT ws.key FROM workflow_statuses ws WHERE ws.org_id = %s AND ws.category = '%s')",
+		statusExpr, orgExpr, category,
+	)
+}
+
+// completedStatus returns a condition that statusExpr is a completed status.
+func completedStatus(statusExpr, orgExpr string) string {
+	return statusInCategory(statusExpr, orgExpr, models.CategoryCompleted)
+}
+
+// signedOffStatus returns a condition that statusExpr is the organization's
+// final sign-off: the approve step, or any completed status when the workflow
+// has no approve step.
+func signedOffStatus(statusExpr, orgExpr string) string {
+	return fmt.Sprintf(`%s IN (
+		SELECT ws.key FROM workflow_statuses ws
+		WHERE ws.org_id = %s AND (ws.review_step = 'approve' OR (ws.category = 'completed' AND NOT EXISTS (
+			SELECT 1 FROM workflow_statuses wa WHERE wa.org_id = ws.org_id AND wa.review_step = 'approve'
+		)))
+	)`, statusExpr, orgExpr)
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WorklogRepository struct {
+	db *sql.DB
+}
+
+func NewWorklogRepository(db *sql.DB) *WorklogRepository {
+	return &WorklogRepository{db: db}
+}
+
+func (r *WorklogRepository) Create(w *models.Worklog) error {
+	query := `
+		INSERT INTO worklogs (id, org_id, task_id, user_id, work_date, duration_seconds, started_at, ended_at, is_running, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(
+		query,
+		w.ID,
+		w.OrgID,
+		w.TaskID,
+		w.UserID,
+		w.WorkDate,
+		w.DurationSeconds,
+		w.StartedAt,
+		w.EndedAt,
+		w.IsRunning,
+		w.Note,
+	).Scan(&w.CreatedAt, &w.UpdatedAt)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return fmt.Errorf("a timer is already running")
+	}
+	return err
+}
+
+const worklogSelect = `
+		SELECT
+			w.id, w.org_id, w.task_id, w.user_id, w.work_date, w.duration_seconds, w.started_at, w.ended_at, w.is_running,
+			COALESCE(w.note, ''), w.created_at, w.updated_at,
+			CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')) AS user_name
+		FROM worklogs w
+		LEFT JOIN users u ON u.id = w.user_id
+`
+
+func scanWorklog(row rowScanner, w *models.Worklog) error {
+	return row.Scan(
+		&w.ID,
+		&w.OrgID,
+		&w.TaskID,
+		&w.UserID,
+		&w.WorkDate,
+		&w.DurationSeconds,
+		&w.StartedAt,
+		&w.EndedAt,
+		&w.IsRunning,
+		&w.Note,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+		&w.UserName,
+	)
+}
+
+func (r *WorklogRepository) GetByID(orgID, worklogID uuid.UUID) (*models.Worklog, error) {
+	query := worklogSelect + `
+		WHERE w.org_id = $1 AND w.id = $2
+	`
+	w := &models.Worklog{}
+	err := scanWorklog(r.db.QueryRow(query, orgID, worklogID), w)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+// ListByTask returns a task's worklogs, newest first.
+func (r *WorklogRepository) ListByTask(orgID, taskID uuid.UUID) ([]models.Worklog, error) {
+	query := worklogSelect + `
+		WHERE w.org_id = $1 AND w.task_id = $2
+		ORDER BY w.work_date DESC, w.created_at DESC
+	`
+	rows, err := r.db.Query(query, orgID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	worklogs := []models.Worklog{}
+	for rows.Next() {
+		var w models.Worklog
+		if err := scanWorklog(rows, &w); err != nil {
+			return nil, err
+		}
+		worklogs = append(worklogs, w)
+	}
+	return worklogs, rows.Err()
+}
+
+// GetRunning returns the user's running timer on a task, or nil.
+func (r *WorklogRepository) GetRunning(orgID, taskID, userID uuid.UUID) (*models.Worklog, error) {
+	query := worklogSelect + `
+		WHERE w.org_id = $1 AND w.task_id = $2 AND w.user_id = $3 AND w.is_running
+	`
+	w := &models.Worklog{}
+	err := scanWorklog(r.db.QueryRow(query, orgID, taskID, userID), w)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+// Stop ends a running timer, recording its duration.
+func (r *WorklogRepository) Stop(w *models.Worklog, endedAt time.Time) error {
+	query := `
+		UPDATE worklogs
+		SET is_running = false, ended_at = $1, duration_seconds = GREATEST(EXTRACT(EPOCH FROM ($1 - started_at))::INTEGER, 0)
+		WHERE org_id = $2 AND id = $3 AND is_running
+		RETURNING ended_at, duration_seconds, is_running, updated_at
+	`
+	err := r.db.QueryRow(query, endedAt, w.OrgID, w.ID).Scan(&w.EndedAt, &w.DurationSeconds, &w.IsRunning, &w.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("timer is not running")
+	}
+	return err
+}
+
+func (r *WorklogRepository) Delete(orgID, worklogID uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM worklogs WHERE org_id = $1 AND id = $2`, orgID, worklogID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("worklog not found")
+	}
+	return nil
+}
+
+// ListTimesheetRows sums completed worklogs per user, task and day within the
+// filter's date range.
+func (r *WorklogRepository) ListTimesheetRows(orgID uuid.UUID, filter models.TimesheetFilter) ([]models.TimesheetRow, error) {
+	query := `
+		SELECT
+			w.user_id, CONCAT(COALESCE(u.first_name, ''), ' ', COALESCE(u.last_name, '')) AS user_name,
+			w.task_id, t.title, w.work_date, SUM(w.duration_seconds) AS seconds
+		FROM worklogs w
+		JOIN tasks t ON t.id = w.task_id
+		LEFT JOIN users u ON u.id = w.user_id
+		WHERE w.org_id = $1 AND NOT w.is_running AND w.work_date BETWEEN $2 AND $3 AND t.deleted_at IS NULL
+	`
+	args := []interface{}{orgID, filter.From, filter.To}
+	argIdx := 4
+
+	if filter.UserID != nil {
+		query += fmt.Sprintf(" AND w.user_id = $%d", argIdx)
+		args = append(args, *filter.UserID)
+		argIdx++
+	}
+	if filter.ProjectID != nil {
+		query += fmt.Sprintf(" AND t.project_id = $%d", argIdx)
+		args = append(args, *filter.ProjectID)
+		argIdx++
+	}
+	query += `
+		GROUP BY w.user_id, u.first_name, u.last_name, w.task_id, t.title, w.work_date
+		ORDER BY user_name ASC, w.work_date ASC, t.title ASC
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.TimesheetRow{}
+	for rows.Next() {
+		var row models.TimesheetRow
+		if err := rows.Scan(&row.UserID, &row.UserName, &row.TaskID, &row.TaskTitle, &row.WorkDate, &row.Seconds); err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
@@ -19,6 +19,21 @@ func SetupRoutes(
 	reportHandler *handler.ReportHandler,
 	auditLogHandler *handler.AuditLogHandler,
 	documentHandler *handler.DocumentHandler,
+	commentHandler *handler.CommentHandler,
+	watcherHandler *handler.WatcherHandler,
+	workflowHandler *handler.WorkflowHandler,
+	recurringTaskHandler *handler.RecurringTaskHandler,
+	taskTemplateHandler *handler.TaskTemplateHandler,
+	labelHandler *handler.LabelHandler,
+	issueLinkHandler *handler.IssueLinkHandler,
+	projectHandler *handler.ProjectHandler,
+	customFieldHandler *handler.CustomFieldHandler,
+	worklogHandler *handler.WorklogHandler,
+	sprintHandler *handler.SprintHandler,
+	searchHandler *handler.SearchHandler,
+	savedViewHandler *handler.SavedViewHandler,
+	trashHandler *handler.TrashHandler,
+	approvalPolicyHandler *handler.ApprovalPolicyHandler,
 	ragHandler *rag.Handler,
 ) {
 	// Apply global middleware
@@ -53,23 +68,86 @@ func SetupRoutes(
 			protected.POST("/auth/logout", authHandler.Logout)
 			protected.GET("/auth/me", authHandler.Me)
 
+			// Workflow routes
+			protected.GET("/workflow", workflowHandler.Get)
+			protected.PUT("/workflow", middleware.RequireRole("admin"), workflowHandler.Update)
+
 			// Task routes
 			tasks := protected.Group("/tasks")
 			{
 				tasks.POST("", middleware.RequireRole("admin", "manager"), taskHandler.CreateTask)
 				tasks.GET("", taskHandler.ListTasks)
 				tasks.GET("/my", taskHandler.ListMyTasks)
+				tasks.GET("/board", taskHandler.Board)
+				tasks.POST("/bulk", taskHandler.BulkUpdate)
+				tasks.POST("/from-template/:id", middleware.RequireRole("admin", "manager"), taskTemplateHandler.Instantiate)
 				tasks.GET("/ai-report", middleware.RequireRole("admin"), taskHandler.AdminAIReport)
 				tasks.GET("/:id", taskHandler.GetTask)
 				tasks.PATCH("/:id", taskHandler.UpdateTask)
 				tasks.DELETE("/:id", middleware.RequireRole("admin", "manager"), taskHandler.DeleteTask)
-				// Workflow actions
+				tasks.POST("/:id/restore", middleware.RequireRole("admin"), trashHandler.RestoreTask)
+				tasks.POST("/:id/move", taskHandler.MoveTask)
+				// Subtasks
+				tasks.GET("/:id/subtasks", taskHandler.ListSubtasks)
+				tasks.POST("/:id/subtasks", middleware.RequireRole("admin", "manager"), taskHandler.CreateSubtask)
+				// Dependencies
+				tasks.GET("/:id/dependencies", taskHandler.ListDependencies)
+				tasks.POST("/:id/dependencies", middleware.RequireRole("admin", "manager"), taskHandler.AddDependency)
+				tasks.DELETE("/:id/dependencies/:depends_on_id", middleware.RequireRole("admin", "manager"), taskHandler.RemoveDependency)
+				// Issues linked to the task
+				tasks.GET("/:id/links", issueLinkHandler.ListTaskLinks)
+				// Workflow actions. The workflow and the task's designated
+				// reviewers and approvers decide who may take each step; an
+				// approval policy can require several approvals.
 				tasks.POST("/:id/done", taskHandler.MarkDone)
 				tasks.POST("/:id/verify", taskHandler.VerifyTask)
 				tasks.POST("/:id/approve", taskHandler.ApproveTask)
 				tasks.POST("/:id/reject", taskHandler.RejectTask)
+				tasks.GET("/:id/reviews", taskHandler.ListReviews)
+				tasks.GET("/:id/history", taskHandler.ListHistory)
 				// Documents by task
 				tasks.GET("/:id/documents", documentHandler.ListByTask)
+				// Comments
+				tasks.GET("/:id/comments", commentHandler.ListTaskComments)
+				tasks.POST("/:id/comments", commentHandler.CreateTaskComment)
+				tasks.PATCH("/:id/comments/:comment_id", commentHandler.UpdateTaskComment)
+				tasks.DELETE("/:id/comments/:comment_id", commentHandler.DeleteTaskComment)
+				// Worklogs
+				tasks.GET("/:id/worklogs", worklogHandler.List)
+				tasks.POST("/:id/worklogs", worklogHandler.Create)
+				tasks.POST("/:id/worklogs/start", worklogHandler.StartTimer)
+				tasks.POST("/:id/worklogs/stop", worklogHandler.StopTimer)
+				tasks.DELETE("/:id/worklogs/:worklog_id", worklogHandler.Delete)
+				// Labels
+				tasks.POST("/:id/labels", middleware.RequireRole("admin", "manager"), labelHandler.AttachTaskLabel)
+				tasks.DELETE("/:id/labels/:label_id", middleware.RequireRole("admin", "manager"), labelHandler.DetachTaskLabel)
+				// Watchers
+				tasks.GET("/:id/watchers", watcherHandler.ListTaskWatchers)
+				tasks.POST("/:id/watch", watcherHandler.WatchTask)
+				tasks.DELETE("/:id/watch", watcherHandler.UnwatchTask)
+			}
+
+			// Recurring task routes
+			recurring := protected.Group("/recurring-tasks")
+			recurring.Use(middleware.RequireRole("admin", "manager"))
+			{
+				recurring.POST("", recurringTaskHandler.Create)
+				recurring.GET("", recurringTaskHandler.List)
+				recurring.GET("/:id", recurringTaskHandler.Get)
+				recurring.PATCH("/:id", recurringTaskHandler.Update)
+				recurring.DELETE("/:id", recurringTaskHandler.Delete)
+				recurring.GET("/:id/tasks", recurringTaskHandler.ListTasks)
+			}
+
+			// Task template routes
+			templates := protected.Group("/task-templates")
+			templates.Use(middleware.RequireRole("admin", "manager"))
+			{
+				templates.POST("", taskTemplateHandler.Create)
+				templates.GET("", taskTemplateHandler.List)
+				templates.GET("/:id", taskTemplateHandler.Get)
+				templates.PATCH("/:id", taskTemplateHandler.Update)
+				templates.DELETE("/:id", taskTemplateHandler.Delete)
 			}
 
 			// Issue routes
@@ -77,18 +155,117 @@ func SetupRoutes(
 			{
 				issues.POST("", issueHandler.CreateIssue)
 				issues.GET("", issueHandler.ListIssues)
+				issues.POST("/bulk", issueHandler.BulkUpdate)
 				issues.GET("/:id", issueHandler.GetIssue)
 				issues.PATCH("/:id", issueHandler.UpdateIssue)
 				issues.DELETE("/:id", middleware.RequireRole("admin", "manager"), issueHandler.DeleteIssue)
+				issues.POST("/:id/restore", middleware.RequireRole("admin"), trashHandler.RestoreIssue)
+				issues.GET("/:id/history", issueHandler.ListHistory)
+				// Comments
+				issues.GET("/:id/comments", commentHandler.ListIssueComments)
+				issues.POST("/:id/comments", commentHandler.CreateIssueComment)
+				issues.PATCH("/:id/comments/:comment_id", commentHandler.UpdateIssueComment)
+				issues.DELETE("/:id/comments/:comment_id", commentHandler.DeleteIssueComment)
+				// Labels
+				issues.POST("/:id/labels", labelHandler.AttachIssueLabel)
+				issues.DELETE("/:id/labels/:label_id", labelHandler.DetachIssueLabel)
+				// Watchers
+				issues.GET("/:id/watchers", watcherHandler.ListIssueWatchers)
+				issues.POST("/:id/watch", watcherHandler.WatchIssue)
+				issues.DELETE("/:id/watch", watcherHandler.UnwatchIssue)
+				// Links to tasks and other issues
+				issues.GET("/:id/links", issueLinkHandler.ListIssueLinks)
+				issues.POST("/:id/links", middleware.RequireRole("admin", "manager"), issueLinkHandler.AddLink)
+				issues.DELETE("/:id/links/:link_id", middleware.RequireRole("admin", "manager"), issueLinkHandler.RemoveLink)
+				issues.POST("/:id/fix-task", middleware.RequireRole("admin", "manager"), issueLinkHandler.CreateFixTask)
 			}
 
+			// Label routes
+			labels := protected.Group("/labels")
+			{
+				labels.GET("", labelHandler.List)
+				labels.POST("", middleware.RequireRole("admin", "manager"), labelHandler.Create)
+				labels.PATCH("/:id", middleware.RequireRole("admin", "manager"), labelHandler.Update)
+				labels.DELETE("/:id", middleware.RequireRole("admin", "manager"), labelHandler.Delete)
+			}
+
+			// Project routes. Members see the projects they belong to.
+			projects := protected.Group("/projects")
+			{
+				projects.GET("", projectHandler.List)
+				projects.GET("/:id", projectHandler.Get)
+				projects.POST("", middleware.RequireRole("admin", "manager"), projectHandler.Create)
+				projects.PATCH("/:id", middleware.RequireRole("admin", "manager"), projectHandler.Update)
+				projects.POST("/:id/archive", middleware.RequireRole("admin", "manager"), projectHandler.Archive)
+				projects.POST("/:id/unarchive", middleware.RequireRole("admin", "manager"), projectHandler.Unarchive)
+				projects.GET("/:id/members", projectHandler.ListMembers)
+				projects.POST("/:id/members", middleware.RequireRole("admin", "manager"), projectHandler.AddMember)
+				projects.DELETE("/:id/members/:user_id", middleware.RequireRole("admin", "manager"), projectHandler.RemoveMember)
+			}
+
+			// Custom field definitions. Anyone can read them; only admins define them.
+			customFields := protected.Group("/custom-fields")
+			{
+				customFields.GET("", customFieldHandler.List)
+				customFields.POST("", middleware.RequireRole("admin"), customFieldHandler.Create)
+				customFields.PATCH("/:id", middleware.RequireRole("admin"), customFieldHandler.Update)
+				customFields.DELETE("/:id", middleware.RequireRole("admin"), customFieldHandler.Delete)
+			}
+
+			// Approval policies for task sign-off. Admins and managers can
+			// read them; only admins set them.
+			approvalPolicies := protected.Group("/approval-policies")
+			{
+				approvalPolicies.GET("", middleware.RequireRole("admin", "manager"), approvalPolicyHandler.List)
+				approvalPolicies.GET("/:id", middleware.RequireRole("admin", "manager"), approvalPolicyHandler.Get)
+				approvalPolicies.POST("", middleware.RequireRole("admin"), approvalPolicyHandler.Create)
+				approvalPolicies.PATCH("/:id", middleware.RequireRole("admin"), approvalPolicyHandler.Update)
+				approvalPolicies.DELETE("/:id", middleware.RequireRole("admin"), approvalPolicyHandler.Delete)
+			}
+
+			// Sprints. Anyone can read them; admins and managers plan and run them.
+			sprints := protected.Group("/sprints")
+			{
+				sprints.GET("", sprintHandler.List)
+				sprints.GET("/velocity", sprintHandler.Velocity)
+				sprints.GET("/:id", sprintHandler.Get)
+				sprints.GET("/:id/tasks", sprintHandler.ListTasks)
+				sprints.POST("", middleware.RequireRole("admin", "manager"), sprintHandler.Create)
+				sprints.PATCH("/:id", middleware.RequireRole("admin", "manager"), sprintHandler.Update)
+				sprints.POST("/:id/start", middleware.RequireRole("admin", "manager"), sprintHandler.Start)
+				sprints.POST("/:id/close", middleware.RequireRole("admin", "manager"), sprintHandler.Close)
+				sprints.POST("/:id/tasks", middleware.RequireRole("admin", "manager"), sprintHandler.AddTasks)
+				sprints.DELETE("/:id/tasks/:task_id", middleware.RequireRole("admin", "manager"), sprintHandler.RemoveTask)
+			}
+
+			// Saved views. Anyone can save views; admins and managers pin defaults.
+			views := protected.Group("/views")
+			{
+				views.GET("", savedViewHandler.List)
+				views.GET("/default", savedViewHandler.Default)
+				views.POST("", savedViewHandler.Create)
+				views.GET("/:id", savedViewHandler.Get)
+				views.PATCH("/:id", savedViewHandler.Update)
+				views.DELETE("/:id", savedViewHandler.Delete)
+				views.GET("/:id/run", savedViewHandler.Run)
+				views.POST("/:id/pin", middleware.RequireRole("admin", "manager"), savedViewHandler.Pin)
+				views.DELETE("/:id/pin/:role", middleware.RequireRole("admin", "manager"), savedViewHandler.Unpin)
+			}
+
+			// Keyword search (all authenticated users, works without AI)
+			protected.GET("/search", searchHandler.Search)
+
 			// Reports (admin/manager)
 			reports := protected.Group("/reports")
 			reports.Use(middleware.RequireRole("admin", "manager"))
 			{
 				reports.GET("/weekly-summary", reportHandler.WeeklySummary)
+				reports.GET("/timesheet", reportHandler.Timesheet)
 			}
 
+			// Trash (admin only). Restores live under each entity's routes.
+			protected.GET("/trash", middleware.RequireRole("admin"), trashHandler.List)
+
 			// Audit logs (admin only)
 			audit := protected.Group("/audit-logs")
 			audit.Use(middleware.RequireRole("admin"))
@@ -117,6 +294,8 @@ func SetupRoutes(
 				documents.POST("/:id/verify", documentHandler.Verify)
 				documents.POST("/:id/summary", middleware.RateLimitAI(), middleware.RequireRole("admin", "manager"), documentHandler.GenerateSummary)
 				documents.PATCH("/:id/status", middleware.RequireRole("admin", "manager"), documentHandler.UpdateStatus)
+				documents.DELETE("/:id", middleware.RequireRole("admin", "manager"), documentHandler.Delete)
+				documents.POST("/:id/restore", middleware.RequireRole("admin"), trashHandler.RestoreDocument)
 			}
 
 			// RAG query endpoint (all authenticated users)
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type ApprovalPolicyService struct {
+	approvalRepo *repository.ApprovalRepository
+	projectSvc   *ProjectService
+	auditLogRepo *repository.AuditLogRepository
+}
+
+func NewApprovalPolicyService(approvalRepo *repository.ApprovalRepository, projectSvc *ProjectService, auditLogRepo *repository.AuditLogRepository) *ApprovalPolicyService {
+	return &ApprovalPolicyService{
+		approvalRepo: approvalRepo,
+		projectSvc:   projectSvc,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// validateRequirements checks that each requirement names exactly one of a
+// valid role or a user in the organization.
+func (s *ApprovalPolicyService) validateRequirements(orgID uuid.UUID, requirements []models.ApprovalRequirement) error {
+	for i, req := range requirements {
+		switch {
+		case req.Role != "" && req.UserID != nil, req.Role == "" && req.UserID == nil:
+			return fmt.Errorf("requirement %d must set exactly one of role or user_id", i+1)
+		case req.Role != "":
+			if req.Role != "admin" && req.Role != "manager" && req.Role != "member" {
+				return fmt.Errorf("requirement %d: invalid role: %s", i+1, req.Role)
+			}
+		default:
+			if _, err := s.projectSvc.getOrgUser(orgID, req.UserID.String()); err != nil {
+				return fmt.Errorf("requirement %d: %w", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyMinApprovals sets the policy's approval count. Every requirement
+// needs an approval of its own, so the count defaults to, and may not be
+// below, the number of requirements.
+func applyMinApprovals(policy *models.ApprovalPolicy, min *int) error {
+	if min == nil {
+		if policy.MinApprovals < len(policy.Requirements) {
+			policy.MinApprovals = len(policy.Requirements)
+		}
+		if policy.MinApprovals < 1 {
+			policy.MinApprovals = 1
+		}
+		return nil
+	}
+	if *min < len(policy.Requirements) {
+		return fmt.Errorf("min_approvals cannot be less than the number of requirements (%d)", len(policy.Requirements))
+	}
+	policy.MinApprovals = *min
+	return nil
+}
+
+func (s *ApprovalPolicyService) CreatePolicy(orgID, userID uuid.UUID, req *models.CreateApprovalPolicyRequest) (*models.ApprovalPolicy, error) {
+	projectID, err := s.projectSvc.ResolveProject(orgID, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateRequirements(orgID, req.Requirements); err != nil {
+		return nil, err
+	}
+
+	policy := &models.ApprovalPolicy{
+		ID:           uuid.New(),
+		OrgID:        orgID,
+		ProjectID:    projectID,
+		Requirements: req.Requirements,
+		Sequential:   req.Sequential,
+	}
+	if policy.Requirements == nil {
+		policy.Requirements = []models.ApprovalRequirement{}
+	}
+	var min *int
+	if req.MinApprovals > 0 {
+		min = &req.MinApprovals
+	}
+	if err := applyMinApprovals(policy, min); err != nil {
+		return nil, err
+	}
+
+	if err := s.approvalRepo.Create(policy); err != nil {
+		return nil, fmt.Errorf("failed to create approval policy: %w", err)
+	}
+
+	s.audit(orgID, userID, "create", policy)
+	return policy, nil
+}
+
+func (s *ApprovalPolicyService) ListPolicies(orgID uuid.UUID) ([]models.ApprovalPolicy, error) {
+	policies, err := s.approvalRepo.List(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval policies: %w", err)
+	}
+	return policies, nil
+}
+
+func (s *ApprovalPolicyService) GetPolicy(orgID, policyID uuid.UUID) (*models.ApprovalPolicy, error) {
+	policy, err := s.approvalRepo.GetByID(orgID, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policy: %w", err)
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("approval policy not found")
+	}
+	return policy, nil
+}
+
+func (s *ApprovalPolicyService) UpdatePolicy(orgID, policyID, userID uuid.UUID, req *models.UpdateApprovalPolicyRequest) (*models.ApprovalPolicy, error) {
+	policy, err := s.GetPolicy(orgID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Requirements != nil {
+		if err := s.validateRequirements(orgID, *req.Requirements); err != nil {
+			return nil, err
+		}
+		policy.Requirements = *req.Requirements
+		if policy.Requirements == nil {
+			policy.Requirements = []models.ApprovalRequirement{}
+		}
+	}
+	if req.Sequential != nil {
+		policy.Sequential = *req.Sequential
+	}
+	if err := applyMinApprovals(policy, req.MinApprovals); err != nil {
+		return nil, err
+	}
+
+	if err := s.approvalRepo.Update(policy); err != nil {
+		return nil, fmt.Errorf("failed to update approval policy: %w", err)
+	}
+
+	s.audit(orgID, userID, "update", policy)
+	return policy, nil
+}
+
+func (s *ApprovalPolicyService) DeletePolicy(orgID, policyID, userID uuid.UUID) error {
+	policy, err := s.GetPolicy(orgID, policyID)
+	if err != nil {
+		return err
+	}
+	if err := s.approvalRepo.Delete(orgID, policyID); err != nil {
+		return err
+	}
+
+	s.audit(orgID, userID, "delete", policy)
+	return nil
+}
+
+func (s *ApprovalPolicyService) audit(orgID, userID uuid.UUID, action string, policy *models.ApprovalPolicy) {
+	details := map[string]interface{}{
+		"min_approvals": policy.MinApprovals,
+		"requirements":  len(policy.Requirements),
+		"sequential":    policy.Sequential,
+	}
+	if policy.ProjectID != nil {
+		details["project_id"] = policy.ProjectID.String()
+	}
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "approval_policy",
+		EntityID:   &policy.ID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ type AuthService struct {
 	userRepo         *repository.UserRepository
 	orgRepo          *repository.OrganizationRepository
 	refreshTokenRepo *repository.RefreshTokenRepository
+	workflowService  *WorkflowService
 	cfg              *config.Config
 }
 
@@ -24,12 +26,14 @@ func NewAuthService(
 	userRepo *repository.UserRepository,
 	orgRepo *repository.OrganizationRepository,
 	refreshTokenRepo *repository.RefreshTokenRepository,
+	workflowService *WorkflowService,
 	cfg *config.Config,
 ) *AuthService {
 	return &AuthService{
 		userRepo:         userRepo,
 		orgRepo:          orgRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		workflowService:  workflowService,
 		cfg:              cfg,
 	}
 }
@@ -57,6 +61,11 @@ func (s *AuthService) Register(req *models.RegisterRequest) (*models.AuthRespons
 		return nil, fmt.Errorf("failed to create organization: %w", err)
 	}
 
+	// Seed the default task workflow; tasks fall back to it if this fails.
+	if err := s.workflowService.SeedDefault(org.ID); err != nil {
+		log.Printf("Warning: failed to seed workflow for organization %s: %v", org.ID, err)
+	}
+
 	// Hash password
 	passwordHash, err := utils.HashPassword(req.Password)
 	if err != nil {
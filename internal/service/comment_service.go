@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/rag"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type CommentService struct {
+	commentRepo  *repository.CommentRepository
+	userRepo     *repository.UserRepository
+	auditLogRepo *repository.AuditLogRepository
+	taskService  *TaskService
+	issueService *IssueService
+	ragIndexer   *rag.Indexer
+}
+
+func NewCommentService(
+	commentRepo *repository.CommentRepository,
+	userRepo *repository.UserRepository,
+	auditLogRepo *repository.AuditLogRepository,
+	taskService *TaskService,
+	issueService *IssueService,
+	ragIndexer *rag.Indexer,
+) *CommentService {
+	return &CommentService{
+		commentRepo:  commentRepo,
+		userRepo:     userRepo,
+		auditLogRepo: auditLogRepo,
+		taskService:  taskService,
+		issueService: issueService,
+		ragIndexer:   ragIndexer,
+	}
+}
+
+// authorizeParent applies the visibility rules of the parent task or issue and
+// returns its title so comment content can be indexed with some context.
+func (s *CommentService) authorizeParent(orgID uuid.UUID, entityType string, entityID, userID uuid.UUID, role string) (string, error) {
+	switch entityType {
+	case "task":
+		task, err := s.taskService.GetTaskForRole(orgID, entityID, userID, role)
+		if err != nil {
+			return "", err
+		}
+		return task.Title, nil
+	case "issue":
+		issue, err := s.issueService.GetIssueForRole(orgID, entityID, userID, role)
+		if err != nil {
+			return "", err
+		}
+		return issue.Title, nil
+	default:
+		return "", fmt.Errorf("invalid entity type: %s", entityType)
+	}
+}
+
+// watchParent subscribes the users a comment @mentions, plus any extra users
+// such as its author, to the commented task or issue.
+func (s *CommentService) watchParent(orgID uuid.UUID, entityType string, entityID uuid.UUID, body string, users ...*uuid.UUID) {
+	mentioned, err := s.userRepo.ListActiveIDsByEmail(orgID, mentionedEmails(body))
+	if err != nil {
+		log.Printf("failed to resolve mentions: %v", err)
+	}
+	for i := range mentioned {
+		users = append(users, &mentioned[i])
+	}
+	if entityType == "task" {
+		s.taskService.AddTaskWatchers(entityID, users...)
+	} else {
+		s.issueService.AddIssueWatchers(entityID, users...)
+	}
+}
+
+func commentBelongsTo(comment *models.Comment, entityType string, entityID uuid.UUID) bool {
+	switch entityType {
+	case "task":
+		return comment.TaskID != nil && *comment.TaskID == entityID
+	case "issue":
+		return comment.IssueID != nil && *comment.IssueID == entityID
+	}
+	return false
+}
+
+func (s *CommentService) ListComments(orgID uuid.UUID, entityType string, entityID, userID uuid.UUID, role string) ([]models.Comment, error) {
+	if _, err := s.authorizeParent(orgID, entityType, entityID, userID, role); err != nil {
+		return nil, err
+	}
+
+	var comments []models.Comment
+	var err error
+	if entityType == "task" {
+		comments, err = s.commentRepo.ListByTask(orgID, entityID)
+	} else {
+		comments, err = s.commentRepo.ListByIssue(orgID, entityID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return buildCommentThreads(comments), nil
+}
+
+// buildCommentThreads nests replies under their parents. The input must be in
+// creation order so that replies keep their chronological order.
+func buildCommentThreads(flat []models.Comment) []models.Comment {
+	children := make(map[uuid.UUID][]int, len(flat))
+	roots := make([]int, 0, len(flat))
+	for i, c := range flat {
+		if c.ParentID == nil {
+			roots = append(roots, i)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], i)
+	}
+
+	var build func(idx int) models.Comment
+	build = func(idx int) models.Comment {
+		c := flat[idx]
+		for _, childIdx := range children[c.ID] {
+			c.Replies = append(c.Replies, build(childIdx))
+		}
+		return c
+	}
+
+	threads := make([]models.Comment, 0, len(roots))
+	for _, idx := range roots {
+		threads = append(threads, build(idx))
+	}
+	return threads
+}
+
+func (s *CommentService) CreateComment(orgID uuid.UUID, entityType string, entityID, userID uuid.UUID, role string, req *models.CreateCommentRequest) (*models.Comment, error) {
+	title, err := s.authorizeParent(orgID, entityType, entityID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("comment body cannot be empty")
+	}
+
+	comment := &models.Comment{
+		ID:       uuid.New(),
+		OrgID:    orgID,
+		AuthorID: &userID,
+		Body:     body,
+	}
+	if entityType == "task" {
+		comment.TaskID = &entityID
+	} else {
+		comment.IssueID = &entityID
+	}
+
+	if req.ParentID != nil && *req.ParentID != "" {
+		parentID, err := uuid.Parse(*req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent_id UUID: %w", err)
+		}
+		parent, err := s.commentRepo.GetByID(orgID, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent comment: %w", err)
+		}
+		if parent == nil || !commentBelongsTo(parent, entityType, entityID) {
+			return nil, fmt.Errorf("parent comment not found")
+		}
+		comment.ParentID = &parentID
+	}
+
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	s.watchParent(orgID, entityType, entityID, comment.Body, &userID)
+
+	// Index comment for RAG
+	if s.ragIndexer != nil {
+		s.ragIndexer.IndexComment(context.Background(), orgID, comment.ID, commentIndexContent(entityType, title, comment.Body))
+	}
+
+	// Create audit log
+	details := map[string]interface{}{
+		"entity_type": entityType,
+		"entity_id":   entityID.String(),
+	}
+	if comment.ParentID != nil {
+		details["parent_id"] = comment.ParentID.String()
+	}
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "create",
+		EntityType: "comment",
+		EntityID:   &comment.ID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return comment, nil
+}
+
+func (s *CommentService) UpdateComment(orgID uuid.UUID, entityType string, entityID, commentID, userID uuid.UUID, role string, req *models.UpdateCommentRequest) (*models.Comment, error) {
+	title, err := s.authorizeParent(orgID, entityType, entityID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetByID(orgID, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil || !commentBelongsTo(comment, entityType, entityID) {
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	// Only the author may edit a comment.
+	if comment.AuthorID == nil || *comment.AuthorID != userID {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("comment body cannot be empty")
+	}
+	comment.Body = body
+
+	if err := s.commentRepo.UpdateBody(comment); err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	// Edits subscribe newly mentioned users; the author already watches.
+	s.watchParent(orgID, entityType, entityID, comment.Body)
+
+	// Re-index comment for RAG
+	if s.ragIndexer != nil {
+		s.ragIndexer.IndexComment(context.Background(), orgID, comment.ID, commentIndexContent(entityType, title, comment.Body))
+	}
+
+	// Create audit log
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "update",
+		EntityType: "comment",
+		EntityID:   &comment.ID,
+		Details: map[string]interface{}{
+			"entity_type": entityType,
+			"entity_id":   entityID.String(),
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return comment, nil
+}
+
+func (s *CommentService) DeleteComment(orgID uuid.UUID, entityType string, entityID, commentID, userID uuid.UUID, role string) error {
+	if _, err := s.authorizeParent(orgID, entityType, entityID, userID, role); err != nil {
+		return err
+	}
+
+	comment, err := s.commentRepo.GetByID(orgID, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil || !commentBelongsTo(comment, entityType, entityID) {
+		return fmt.Errorf("comment not found")
+	}
+
+	// Authors delete their own comments; admins may moderate any comment.
+	isAuthor := comment.AuthorID != nil && *comment.AuthorID == userID
+	if !isAuthor && role != "admin" {
+		return fmt.Errorf("insufficient permissions")
+	}
+
+	deletedIDs, err := s.commentRepo.Delete(orgID, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	// Create audit log
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "delete",
+		EntityType: "comment",
+		EntityID:   &commentID,
+		Details: map[string]interface{}{
+			"entity_type":     entityType,
+			"entity_id":       entityID.String(),
+			"deleted_replies": len(deletedIDs) - 1,
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	// Delete the comment and its replies from the RAG index
+	if s.ragIndexer != nil {
+		for _, id := range deletedIDs {
+			s.ragIndexer.DeleteComment(context.Background(), orgID, id)
+		}
+	}
+
+	return nil
+}
+
+func commentIndexContent(entityType, title, body string) string {
+	return fmt.Sprintf("%s\n\n(on %s: %s)", body, entityType, title)
+}
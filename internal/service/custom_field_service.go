@@ -0,0 +1,387 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+const customFieldDateLayout = "2006-01-02"
+
+type CustomFieldService struct {
+	customFieldRepo *repository.CustomFieldRepository
+	userRepo        *repository.UserRepository
+	auditLogRepo    *repository.AuditLogRepository
+}
+
+func NewCustomFieldService(customFieldRepo *repository.CustomFieldRepository, userRepo *repository.UserRepository, auditLogRepo *repository.AuditLogRepository) *CustomFieldService {
+	return &CustomFieldService{
+		customFieldRepo: customFieldRepo,
+		userRepo:        userRepo,
+		auditLogRepo:    auditLogRepo,
+	}
+}
+
+func isSelectField(fieldType string) bool {
+	return fieldType == models.CustomFieldSingleSelect || fieldType == models.CustomFieldMultiSelect
+}
+
+// normalizeOptions trims and de-duplicates select options. Select fields need
+// at least one option; other types take none.
+func normalizeOptions(fieldType string, options []string) ([]string, error) {
+	out := make([]string, 0, len(options))
+	seen := make(map[string]bool, len(options))
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[o] {
+			continue
+		}
+		seen[o] = true
+		out = append(out, o)
+	}
+	if isSelectField(fieldType) && len(out) == 0 {
+		return nil, fmt.Errorf("select fields require at least one option")
+	}
+	if !isSelectField(fieldType) && len(out) > 0 {
+		return nil, fmt.Errorf("options are only allowed on select fields")
+	}
+	return out, nil
+}
+
+func (s *CustomFieldService) CreateField(orgID, userID uuid.UUID, req *models.CreateCustomFieldRequest) (*models.CustomField, error) {
+	key := strings.TrimSpace(req.Key)
+	if !customFieldKeyPattern.MatchString(key) {
+		return nil, fmt.Errorf("key must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	options, err := normalizeOptions(req.FieldType, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	field := &models.CustomField{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		EntityType: req.EntityType,
+		Key:        key,
+		Name:       name,
+		FieldType:  req.FieldType,
+		Options:    options,
+		Position:   req.Position,
+	}
+	if err := s.customFieldRepo.Create(field); err != nil {
+		return nil, fmt.Errorf("failed to create custom field: %w", err)
+	}
+
+	s.audit(orgID, userID, "create", field.ID, map[string]interface{}{
+		"entity_type": field.EntityType,
+		"key":         field.Key,
+		"field_type":  field.FieldType,
+	})
+	return field, nil
+}
+
+// ListFields returns field definitions, optionally for a single entity type.
+func (s *CustomFieldService) ListFields(orgID uuid.UUID, entityType string) ([]models.CustomField, error) {
+	if entityType != "" && entityType != "task" && entityType != "issue" {
+		return nil, fmt.Errorf("invalid entity type: %s", entityType)
+	}
+	fields, err := s.customFieldRepo.List(orgID, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+	return fields, nil
+}
+
+func (s *CustomFieldService) getField(orgID, fieldID uuid.UUID) (*models.CustomField, error) {
+	field, err := s.customFieldRepo.GetByID(orgID, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom field: %w", err)
+	}
+	if field == nil {
+		return nil, fmt.Errorf("custom field not found")
+	}
+	return field, nil
+}
+
+// UpdateField renames, reorders or changes the options of a field. The key and
+// type are fixed once created. Values using a removed option are kept until
+// the entity is next edited.
+func (s *CustomFieldService) UpdateField(orgID, fieldID, userID uuid.UUID, req *models.UpdateCustomFieldRequest) (*models.CustomField, error) {
+	field, err := s.getField(orgID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		field.Name = name
+	}
+	if req.Options != nil {
+		if field.Options, err = normalizeOptions(field.FieldType, req.Options); err != nil {
+			return nil, err
+		}
+	}
+	if req.Position != nil {
+		field.Position = *req.Position
+	}
+
+	if err := s.customFieldRepo.Update(field); err != nil {
+		return nil, fmt.Errorf("failed to update custom field: %w", err)
+	}
+
+	s.audit(orgID, userID, "update", field.ID, map[string]interface{}{"key": field.Key})
+	return field, nil
+}
+
+// DeleteField removes a field and its values from every task or issue.
+func (s *CustomFieldService) DeleteField(orgID, fieldID, userID uuid.UUID) error {
+	field, err := s.getField(orgID, fieldID)
+	if err != nil {
+		return err
+	}
+	if err := s.customFieldRepo.Delete(field); err != nil {
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+
+	s.audit(orgID, userID, "delete", field.ID, map[string]interface{}{
+		"entity_type": field.EntityType,
+		"key":         field.Key,
+	})
+	return nil
+}
+
+func (s *CustomFieldService) fieldsByKey(orgID uuid.UUID, entityType string) (map[string]models.CustomField, error) {
+	fields, err := s.customFieldRepo.List(orgID, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load custom fields: %w", err)
+	}
+	byKey := make(map[string]models.CustomField, len(fields))
+	for _, f := range fields {
+		byKey[f.Key] = f
+	}
+	return byKey, nil
+}
+
+// ApplyValues validates incoming values against the organization's field
+// definitions and merges them into current, returning the new value map.
+// A nil incoming value clears the field.
+func (s *CustomFieldService) ApplyValues(orgID uuid.UUID, entityType string, current, incoming map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(current)+len(incoming))
+	for k, v := range current {
+		merged[k] = v
+	}
+	if len(incoming) == 0 {
+		return merged, nil
+	}
+
+	fields, err := s.fieldsByKey(orgID, entityType)
+	if err != nil {
+		return nil, err
+	}
+	for key, raw := range incoming {
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field: %s", key)
+		}
+		if raw == nil {
+			delete(merged, key)
+			continue
+		}
+		value, err := s.normalizeValue(orgID, field, raw)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = value
+	}
+	return merged, nil
+}
+
+func (s *CustomFieldService) normalizeValue(orgID uuid.UUID, field models.CustomField, raw interface{}) (interface{}, error) {
+	invalid := func(expected string) error {
+		return fmt.Errorf("custom field %s must be %s", field.Key, expected)
+	}
+
+	switch field.FieldType {
+	case models.CustomFieldText:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, invalid("a string")
+		}
+		return str, nil
+	case models.CustomFieldNumber:
+		num, ok := raw.(float64)
+		if !ok {
+			return nil, invalid("a number")
+		}
+		return num, nil
+	case models.CustomFieldDate:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, invalid("a date (YYYY-MM-DD)")
+		}
+		if d, err := time.Parse(customFieldDateLayout, str); err == nil {
+			return d.Format(customFieldDateLayout), nil
+		}
+		if t, err := time.Parse(time.RFC3339, str); err == nil {
+			return t.Format(customFieldDateLayout), nil
+		}
+		return nil, invalid("a date (YYYY-MM-DD)")
+	case models.CustomFieldSingleSelect:
+		str, ok := raw.(string)
+		if !ok || !containsString(field.Options, str) {
+			return nil, invalid("one of: " + strings.Join(field.Options, ", "))
+		}
+		return str, nil
+	case models.CustomFieldMultiSelect:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, invalid("a list of options")
+		}
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			str, ok := item.(string)
+			if !ok || !containsString(field.Options, str) {
+				return nil, invalid("a list of: " + strings.Join(field.Options, ", "))
+			}
+			if !containsString(out, str) {
+				out = append(out, str)
+			}
+		}
+		return out, nil
+	case models.CustomFieldUser:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, invalid("a user ID")
+		}
+		userID, err := uuid.Parse(str)
+		if err != nil {
+			return nil, invalid("a user ID")
+		}
+		user, err := s.userRepo.GetByID(orgID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			return nil, fmt.Errorf("custom field %s: user not found", field.Key)
+		}
+		return userID.String(), nil
+	default:
+		return nil, fmt.Errorf("custom field %s has unsupported type %s", field.Key, field.FieldType)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildFilter turns raw query values (?cf.<key>=value) into a containment
+// filter. Multi-select fields match entities that include the option.
+func (s *CustomFieldService) BuildFilter(orgID uuid.UUID, entityType string, raw map[string]interface{}) (map[string]interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	fields, err := s.fieldsByKey(orgID, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := make(map[string]interface{}, len(raw))
+	for key, v := range raw {
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field: %s", key)
+		}
+		str, _ := v.(string)
+		switch field.FieldType {
+		case models.CustomFieldNumber:
+			num, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, fmt.Errorf("custom field %s must be a number", key)
+			}
+			filter[key] = num
+		case models.CustomFieldMultiSelect:
+			filter[key] = []string{str}
+		default:
+			filter[key] = str
+		}
+	}
+	return filter, nil
+}
+
+// DisplayLines renders values as "Name: value" lines in field order, for RAG
+// indexing. User fields show the user's name.
+func (s *CustomFieldService) DisplayLines(orgID uuid.UUID, entityType string, values map[string]interface{}) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	fields, err := s.customFieldRepo.List(orgID, entityType)
+	if err != nil {
+		return nil
+	}
+
+	lines := make([]string, 0, len(values))
+	for _, f := range fields {
+		v, ok := values[f.Key]
+		if !ok || v == nil {
+			continue
+		}
+		var display string
+		switch val := v.(type) {
+		case []interface{}:
+			parts := make([]string, 0, len(val))
+			for _, item := range val {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			display = strings.Join(parts, ", ")
+		case []string:
+			display = strings.Join(val, ", ")
+		case float64:
+			display = strconv.FormatFloat(val, 'f', -1, 64)
+		default:
+			display = fmt.Sprint(val)
+		}
+		if f.FieldType == models.CustomFieldUser {
+			if userID, err := uuid.Parse(display); err == nil {
+				if user, err := s.userRepo.GetByID(orgID, userID); err == nil && user != nil {
+					display = strings.TrimSpace(user.FirstName + " " + user.LastName)
+				}
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", f.Name, display))
+	}
+	return lines
+}
+
+func (s *CustomFieldService) audit(orgID, userID uuid.UUID, action string, fieldID uuid.UUID, details map[string]interface{}) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "custom_field",
+		EntityID:   &fieldID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"mime/multipart"
 	"os"
 	"path/filepath"
@@ -26,21 +27,28 @@ import (
 
 type DocumentService struct {
 	docRepo       *repository.DocumentRepository
+	projectSvc    *ProjectService
 	geminiService *GeminiService
 	langChainSvc  *ai.LangChainService
 	ragIndexer    *rag.Indexer
+	auditLogRepo  *repository.AuditLogRepository
 	cfg           *config.Config
 }
 
-func NewDocumentService(docRepo *repository.DocumentRepository, geminiService *GeminiService, langChainSvc *ai.LangChainService, ragIndexer *rag.Indexer, cfg *config.Config) *DocumentService {
-	return &DocumentService{docRepo: docRepo, geminiService: geminiService, langChainSvc: langChainSvc, ragIndexer: ragIndexer, cfg: cfg}
+func NewDocumentService(docRepo *repository.DocumentRepository, projectSvc *ProjectService, geminiService *GeminiService, langChainSvc *ai.LangChainService, ragIndexer *rag.Indexer, auditLogRepo *repository.AuditLogRepository, cfg *config.Config) *DocumentService {
+	return &DocumentService{docRepo: docRepo, projectSvc: projectSvc, geminiService: geminiService, langChainSvc: langChainSvc, ragIndexer: ragIndexer, auditLogRepo: auditLogRepo, cfg: cfg}
 }
 
-func (s *DocumentService) Upload(ctx context.Context, orgID, userID uuid.UUID, taskID *uuid.UUID, fh *multipart.FileHeader, title *string) (*models.Document, error) {
+func (s *DocumentService) Upload(ctx context.Context, orgID, userID uuid.UUID, role string, taskID *uuid.UUID, projectID *string, fh *multipart.FileHeader, title *string) (*models.Document, error) {
 	if fh == nil {
 		return nil, fmt.Errorf("missing file")
 	}
 
+	resolvedProjectID, err := s.projectSvc.ResolveProjectForRole(orgID, projectID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := os.MkdirAll(s.cfg.Server.UploadDir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create upload dir: %w", err)
 	}
@@ -90,6 +98,7 @@ func (s *DocumentService) Upload(ctx context.Context, orgID, userID uuid.UUID, t
 	doc := &models.Document{
 		ID:            docID,
 		OrgID:         orgID,
+		ProjectID:     resolvedProjectID,
 		TaskID:        taskID,
 		UploadedBy:    &userID,
 		Title:         title,
@@ -140,8 +149,15 @@ func (s *DocumentService) Upload(ctx context.Context, orgID, userID uuid.UUID, t
 	return doc, nil
 }
 
-func (s *DocumentService) List(ctx context.Context, orgID uuid.UUID, limit int) ([]models.Document, error) {
-	return s.docRepo.List(ctx, orgID, limit)
+// List returns one page of the organization's documents, optionally scoped to
+// a project, with the cursor for the next page.
+func (s *DocumentService) List(ctx context.Context, orgID, userID uuid.UUID, role string, projectID *uuid.UUID, page models.PageRequest) ([]models.Document, string, error) {
+	if projectID != nil {
+		if err := s.projectSvc.AuthorizeScope(orgID, *projectID, userID, role); err != nil {
+			return nil, "", err
+		}
+	}
+	return s.docRepo.List(ctx, orgID, projectID, page)
 }
 
 func (s *DocumentService) ListByTask(ctx context.Context, orgID, taskID uuid.UUID, limit int) ([]models.Document, error) {
@@ -156,6 +172,57 @@ func (s *DocumentService) GetByID(ctx context.Context, orgID, documentID uuid.UU
 	return s.docRepo.GetByID(ctx, orgID, documentID)
 }
 
+// Delete moves a document to the trash and drops it from the RAG index.
+func (s *DocumentService) Delete(ctx context.Context, orgID, documentID, userID uuid.UUID) error {
+	if err := s.docRepo.SoftDelete(orgID, documentID, userID); err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	if s.ragIndexer != nil {
+		s.ragIndexer.DeleteDocument(ctx, orgID, documentID)
+	}
+	s.audit(orgID, userID, "delete", documentID)
+	return nil
+}
+
+// Restore takes a document out of the trash and re-indexes it for RAG.
+func (s *DocumentService) Restore(ctx context.Context, orgID, documentID, userID uuid.UUID) (*models.Document, error) {
+	if err := s.docRepo.Restore(orgID, documentID); err != nil {
+		return nil, fmt.Errorf("failed to restore document: %w", err)
+	}
+	doc, err := s.docRepo.GetByID(ctx, orgID, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("document not found")
+	}
+
+	if s.ragIndexer != nil && doc.ExtractedText != nil && *doc.ExtractedText != "" {
+		title := doc.Filename
+		if doc.Title != nil && *doc.Title != "" {
+			title = *doc.Title
+		}
+		content := fmt.Sprintf("Document: %s\n\nContent:\n%s", title, *doc.ExtractedText)
+		if err := s.ragIndexer.IndexDocument(ctx, orgID, doc.ID, title, content); err != nil {
+			log.Printf("Warning: failed to re-index document %s: %v", doc.ID, err)
+		}
+	}
+	s.audit(orgID, userID, "restore", documentID)
+	return doc, nil
+}
+
+func (s *DocumentService) audit(orgID, userID uuid.UUID, action string, documentID uuid.UUID) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "document",
+		EntityID:   &documentID,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
+
 func (s *DocumentService) UpdateStatus(ctx context.Context, orgID, documentID, verifiedBy uuid.UUID, status, notes string) error {
 	// Validate status
 	if status != "verified" && status != "rejected" {
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// fieldDiff collects the field changes made by one update, in the order the
+// fields were compared.
+type fieldDiff []models.FieldChange
+
+// historyLabels names fields whose column name reads poorly in a sentence.
+var historyLabels = map[string]string{
+	"assigned_to":     "Assignee",
+	"project_id":      "Project",
+	"estimate_points": "Estimate",
+	"sprint_id":       "Sprint",
+}
+
+// fieldLabel turns a field name such as due_date into "Due date".
+func fieldLabel(field string) string {
+	if label, ok := historyLabels[field]; ok {
+		return label
+	}
+	label := strings.ReplaceAll(field, "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func (d *fieldDiff) add(field string, from, to interface{}, text string) {
+	*d = append(*d, models.FieldChange{Field: field, Old: from, New: to, Text: text})
+}
+
+func (d *fieldDiff) changed(field string, from, to interface{}, fromText, toText string) {
+	d.add(field, from, to, fmt.Sprintf("%s changed from %s to %s", fieldLabel(field), orNone(fromText), orNone(toText)))
+}
+
+// str records a short string field.
+func (d *fieldDiff) str(field, from, to string) {
+	if from != to {
+		d.changed(field, from, to, from, to)
+	}
+}
+
+// longText records a field such as a description whose values are too long to
+// repeat in the text.
+func (d *fieldDiff) longText(field, from, to string) {
+	if from != to {
+		d.add(field, from, to, fieldLabel(field)+" changed")
+	}
+}
+
+// ref records a reference to another entity, described by name when one is
+// known.
+func (d *fieldDiff) ref(field string, from, to *uuid.UUID, fromName, toName string) {
+	if sameID(from, to) {
+		return
+	}
+	d.changed(field, from, to, refText(from, fromName), refText(to, toName))
+}
+
+func (d *fieldDiff) timestamp(field string, from, to *time.Time) {
+	if from == nil && to == nil || from != nil && to != nil && from.Equal(*to) {
+		return
+	}
+	d.changed(field, from, to, timeText(from), timeText(to))
+}
+
+func (d *fieldDiff) float(field string, from, to *float64) {
+	if from == nil && to == nil || from != nil && to != nil && *from == *to {
+		return
+	}
+	d.changed(field, from, to, floatText(from), floatText(to))
+}
+
+// people records a change to a list of assigned users. Order is ignored; the
+// primary assignee is covered by assigned_to.
+func (d *fieldDiff) people(field string, from, to []models.TaskPerson) {
+	if len(from) == len(to) && len(addedPeople(from, to)) == 0 {
+		return
+	}
+	d.changed(field, personIDs(from), personIDs(to), peopleText(from), peopleText(to))
+}
+
+// customFields records each added, changed or cleared custom field value as
+// its own custom_fields.<key> change.
+func (d *fieldDiff) customFields(from, to map[string]interface{}) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		ov, nv := from[k], to[k]
+		if reflect.DeepEqual(ov, nv) {
+			continue
+		}
+		text := fmt.Sprintf("Custom field %s changed from %s to %s", k, orNone(valueText(ov)), orNone(valueText(nv)))
+		d.add("custom_fields."+k, ov, nv, text)
+	}
+}
+
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func refText(id *uuid.UUID, name string) string {
+	if id == nil {
+		return ""
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	return id.String()
+}
+
+func timeText(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func floatText(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func valueText(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, ", ")
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func personIDs(people []models.TaskPerson) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(people))
+	for _, p := range people {
+		ids = append(ids, p.UserID)
+	}
+	return ids
+}
+
+func peopleText(people []models.TaskPerson) string {
+	names := make([]string, 0, len(people))
+	for _, p := range people {
+		names = append(names, refText(&p.UserID, p.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+func nameOf(name *string) string {
+	if name == nil {
+		return ""
+	}
+	return *name
+}
+
+// projectName looks up a project's name for a history entry. It returns ""
+// when the project cannot be loaded, so the entry shows its ID instead.
+func (s *ProjectService) projectName(orgID uuid.UUID, projectID *uuid.UUID) string {
+	if projectID == nil {
+		return ""
+	}
+	project, err := s.getProject(orgID, *projectID)
+	if err != nil {
+		return ""
+	}
+	return project.Name
+}
+
+// userName looks up a user's display name for a history entry, returning ""
+// when the user cannot be loaded.
+func (s *ProjectService) userName(orgID uuid.UUID, userID *uuid.UUID) string {
+	if userID == nil {
+		return ""
+	}
+	user, err := s.userRepo.GetByID(orgID, *userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+// namedPeople fills in the names of people resolved from IDs alone, such as
+// those just added by an update.
+func (s *ProjectService) namedPeople(orgID uuid.UUID, people []models.TaskPerson) []models.TaskPerson {
+	named := make([]models.TaskPerson, len(people))
+	for i, p := range people {
+		if p.Name == "" {
+			p.Name = s.userName(orgID, &p.UserID)
+		}
+		named[i] = p
+	}
+	return named
+}
+
+// diffTasks compares a task before and after an update. The after copy is the
+// task as it is about to be written, so names of newly assigned people are
+// looked up here.
+func (s *TaskService) diffTasks(before, after *models.Task) []models.FieldChange {
+	d := fieldDiff{}
+	d.str("title", before.Title, after.Title)
+	d.longText("description", before.Description, after.Description)
+	d.str("status", before.Status, after.Status)
+	d.str("priority", before.Priority, after.Priority)
+	if !sameID(before.AssignedTo, after.AssignedTo) {
+		d.ref("assigned_to", before.AssignedTo, after.AssignedTo,
+			nameOf(before.AssignedToName), s.projectSvc.userName(after.OrgID, after.AssignedTo))
+	}
+	d.people("assignees", before.Assignees, s.projectSvc.namedPeople(after.OrgID, after.Assignees))
+	d.people("reviewers", before.Reviewers, s.projectSvc.namedPeople(after.OrgID, after.Reviewers))
+	d.people("approvers", before.Approvers, s.projectSvc.namedPeople(after.OrgID, after.Approvers))
+	d.timestamp("due_date", before.DueDate, after.DueDate)
+	if !sameID(before.ProjectID, after.ProjectID) {
+		d.ref("project_id", before.ProjectID, after.ProjectID,
+			s.projectSvc.projectName(before.OrgID, before.ProjectID), s.projectSvc.projectName(after.OrgID, after.ProjectID))
+	}
+	d.float("estimate_points", before.EstimatePoints, after.EstimatePoints)
+	d.customFields(before.CustomFields, after.CustomFields)
+	return d
+}
+
+// newHistoryEntry wraps changes made by userID, or by the system when userID
+// is nil, in an entry for the repository to store with the write. It returns
+// nil when nothing changed.
+func newHistoryEntry(userID *uuid.UUID, changes []models.FieldChange) *models.HistoryEntry {
+	if len(changes) == 0 {
+		return nil
+	}
+	return &models.HistoryEntry{ID: uuid.New(), UserID: userID, Changes: changes}
+}
+
+// recordTaskHistory stores changes made outside the task write paths, such as
+// automatic blocking or sprint moves, after the write that made them. Like
+// auto-watching it is best effort: a failure is logged rather than failing
+// the change.
+func (s *TaskService) recordTaskHistory(orgID, taskID uuid.UUID, userID *uuid.UUID, changes []models.FieldChange) {
+	entry := newHistoryEntry(userID, changes)
+	if entry == nil {
+		return
+	}
+	if err := s.historyRepo.AddTaskEntry(orgID, taskID, entry); err != nil {
+		log.Printf("failed to record history for task %s: %v", taskID, err)
+	}
+}
+
+// ListTaskHistoryForRole returns a task's change history, newest first, to
+// users who can see the task.
+func (s *TaskService) ListTaskHistoryForRole(orgID, taskID, userID uuid.UUID, role string) ([]models.HistoryEntry, error) {
+	if _, err := s.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return nil, err
+	}
+	entries, err := s.historyRepo.ListTaskHistory(orgID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+	return entries, nil
+}
+
+// diffIssues compares an issue before and after an update.
+func (s *IssueService) diffIssues(before, after *models.Issue) []models.FieldChange {
+	d := fieldDiff{}
+	d.str("title", before.Title, after.Title)
+	d.longText("description", before.Description, after.Description)
+	d.str("severity", before.Severity, after.Severity)
+	d.str("status", before.Status, after.Status)
+	if !sameID(before.AssignedTo, after.AssignedTo) {
+		d.ref("assigned_to", before.AssignedTo, after.AssignedTo,
+			nameOf(before.AssignedToName), s.projectSvc.userName(after.OrgID, after.AssignedTo))
+	}
+	if !sameID(before.ProjectID, after.ProjectID) {
+		d.ref("project_id", before.ProjectID, after.ProjectID,
+			s.projectSvc.projectName(before.OrgID, before.ProjectID), s.projectSvc.projectName(after.OrgID, after.ProjectID))
+	}
+	d.customFields(before.CustomFields, after.CustomFields)
+	return d
+}
+
+// ListIssueHistoryForRole returns an issue's change history, newest first, to
+// users who can see the issue.
+func (s *IssueService) ListIssueHistoryForRole(orgID, issueID, userID uuid.UUID, role string) ([]models.HistoryEntry, error) {
+	if _, err := s.GetIssueForRole(orgID, issueID, userID, role); err != nil {
+		return nil, err
+	}
+	entries, err := s.historyRepo.ListIssueHistory(orgID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// bulkIssueTargets resolves the issues a bulk request names, either directly
+// or through a filter evaluated with the caller's visibility rules.
+func (s *IssueService) bulkIssueTargets(orgID, userID uuid.UUID, role string, req *models.BulkIssueRequest) ([]uuid.UUID, error) {
+	if (len(req.IDs) > 0) == (req.Filter != nil) {
+		return nil, fmt.Errorf("provide either ids or filter")
+	}
+	if req.Filter == nil {
+		return parseBulkIDs(req.IDs)
+	}
+
+	f := req.Filter
+	filter := models.IssueListFilter{
+		Status:   f.Status,
+		Severity: f.Severity,
+		Labels:   models.LabelFilter{Names: f.Labels},
+		Query:    f.Q,
+	}
+	var err error
+	if filter.ProjectID, err = parseOptionalUUID(f.ProjectID, "project_id"); err != nil {
+		return nil, err
+	}
+	issues, err := s.ListIssuesForRole(orgID, userID, role, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) > models.MaxBulkItems {
+		return nil, fmt.Errorf("filter matches %d issues; at most %d can be changed at once", len(issues), models.MaxBulkItems)
+	}
+	ids := make([]uuid.UUID, 0, len(issues))
+	for _, i := range issues {
+		ids = append(ids, i.ID)
+	}
+	return ids, nil
+}
+
+// BulkUpdateIssuesForRole applies one action to many issues with the same
+// per-issue rules as UpdateIssueForRole, DeleteIssueForRole and issue
+// labelling. Issues that fail are reported and skipped; the rest are written
+// in a single transaction.
+func (s *IssueService) BulkUpdateIssuesForRole(orgID, userID uuid.UUID, role string, req *models.BulkIssueRequest) (*models.BulkResult, error) {
+	ids, err := s.bulkIssueTargets(orgID, userID, role, req)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"action": req.Action}
+	write := repository.BulkIssueWrite{DeletedBy: userID, History: map[uuid.UUID]*models.HistoryEntry{}}
+	var assignee *uuid.UUID
+	switch req.Action {
+	case models.BulkAssign:
+		if assignee, err = bulkAssignee(s.projectSvc, orgID, req.AssignedTo); err != nil {
+			return nil, err
+		}
+		details["assigned_to"] = *req.AssignedTo
+	case models.BulkStatus:
+		if req.Status == "" {
+			return nil, fmt.Errorf("status is required")
+		}
+		details["status"] = req.Status
+	case models.BulkSeverity:
+		if req.Severity == "" {
+			return nil, fmt.Errorf("severity is required")
+		}
+		details["severity"] = req.Severity
+	case models.BulkAddLabel, models.BulkRemoveLabel:
+		labelID, err := uuid.Parse(req.LabelID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label_id UUID: %w", err)
+		}
+		if req.Action == models.BulkAddLabel {
+			write.AddLabelID = &labelID
+		} else {
+			write.RemoveLabelID = &labelID
+		}
+		details["label_id"] = labelID.String()
+	case models.BulkDelete:
+	default:
+		return nil, fmt.Errorf("invalid action: %s", req.Action)
+	}
+
+	isManager := role == "admin" || role == "manager"
+	now := time.Now()
+	rec := newBulkRecorder(req.Action, len(ids))
+	for _, id := range ids {
+		issue, err := s.GetIssueForRole(orgID, id, userID, role)
+		if err != nil {
+			rec.fail(id, err)
+			continue
+		}
+		// Members may retag or re-grade issues they reported; status,
+		// assignment and deletion stay with managers and admins.
+		allowed := isManager
+		if role == "member" {
+			switch req.Action {
+			case models.BulkSeverity, models.BulkAddLabel, models.BulkRemoveLabel:
+				allowed = issue.ReportedBy == userID
+			}
+		}
+		if !allowed {
+			rec.fail(id, fmt.Errorf("insufficient permissions"))
+			continue
+		}
+		before := *issue
+
+		switch req.Action {
+		case models.BulkAssign:
+			issue.AssignedTo = assignee
+			write.Updates = append(write.Updates, issue)
+			write.History[id] = newHistoryEntry(&userID, s.diffIssues(&before, issue))
+		case models.BulkStatus:
+			issue.Status = req.Status
+			if (req.Status == "resolved" || req.Status == "closed") && issue.ResolvedAt == nil {
+				issue.ResolvedAt = &now
+			}
+			write.Updates = append(write.Updates, issue)
+			write.History[id] = newHistoryEntry(&userID, s.diffIssues(&before, issue))
+		case models.BulkSeverity:
+			issue.Severity = req.Severity
+			write.Updates = append(write.Updates, issue)
+			write.History[id] = newHistoryEntry(&userID, s.diffIssues(&before, issue))
+		case models.BulkAddLabel, models.BulkRemoveLabel:
+			write.LabelIssueIDs = append(write.LabelIssueIDs, id)
+		case models.BulkDelete:
+			write.DeleteIDs = append(write.DeleteIDs, id)
+		}
+		rec.ok(id)
+	}
+
+	if err := s.issueRepo.ApplyBulk(orgID, write); err != nil {
+		return nil, fmt.Errorf("bulk update failed: %w", err)
+	}
+
+	for _, id := range rec.succeeded {
+		if req.Action == models.BulkDelete {
+			if s.ragIndexer != nil {
+				s.ragIndexer.DeleteIssue(context.Background(), orgID, id)
+			}
+			continue
+		}
+		if req.Action == models.BulkAssign {
+			s.AddIssueWatchers(id, assignee)
+		}
+		s.reindexIssue(orgID, id)
+	}
+
+	details["issue_ids"] = rec.succeeded
+	details["succeeded"] = rec.result.Succeeded
+	details["failed"] = rec.result.Failed
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "bulk_" + req.Action,
+		EntityType: "issue",
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return rec.result, nil
+}
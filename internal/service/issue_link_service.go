@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// severityPriority maps an issue's severity to the priority of its fix task.
+var severityPriority = map[string]string{
+	"low":      "low",
+	"medium":   "medium",
+	"high":     "high",
+	"critical": "urgent",
+}
+
+type IssueLinkService struct {
+	linkRepo     *repository.IssueLinkRepository
+	auditLogRepo *repository.AuditLogRepository
+	taskService  *TaskService
+	issueService *IssueService
+}
+
+func NewIssueLinkService(
+	linkRepo *repository.IssueLinkRepository,
+	auditLogRepo *repository.AuditLogRepository,
+	taskService *TaskService,
+	issueService *IssueService,
+) *IssueLinkService {
+	return &IssueLinkService{
+		linkRepo:     linkRepo,
+		auditLogRepo: auditLogRepo,
+		taskService:  taskService,
+		issueService: issueService,
+	}
+}
+
+// ListIssueLinksForRole returns an issue's links to users who can see it.
+func (s *IssueLinkService) ListIssueLinksForRole(orgID, issueID, userID uuid.UUID, role string) ([]models.IssueLink, error) {
+	if _, err := s.issueService.GetIssueForRole(orgID, issueID, userID, role); err != nil {
+		return nil, err
+	}
+	links, err := s.linkRepo.ListForIssue(orgID, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	return links, nil
+}
+
+// ListTaskLinksForRole returns the issues linked to a task to users who can
+// see the task.
+func (s *IssueLinkService) ListTaskLinksForRole(orgID, taskID, userID uuid.UUID, role string) ([]models.IssueLink, error) {
+	if _, err := s.taskService.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return nil, err
+	}
+	links, err := s.linkRepo.ListForTask(orgID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	return links, nil
+}
+
+// AddLinkForRole links an issue to the task or issue named in req.
+func (s *IssueLinkService) AddLinkForRole(orgID, issueID, userID uuid.UUID, role string, req *models.CreateIssueLinkRequest) ([]models.IssueLink, error) {
+	if role != "admin" && role != "manager" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	if _, err := s.issueService.GetIssue(orgID, issueID); err != nil {
+		return nil, err
+	}
+
+	hasTask := req.TaskID != nil && *req.TaskID != ""
+	hasIssue := req.IssueID != nil && *req.IssueID != ""
+	if hasTask == hasIssue {
+		return nil, fmt.Errorf("exactly one of task_id or issue_id is required")
+	}
+
+	link := &models.IssueLink{
+		ID:        uuid.New(),
+		IssueID:   issueID,
+		LinkType:  req.LinkType,
+		CreatedBy: &userID,
+	}
+	if hasTask {
+		if req.LinkType == models.LinkDuplicates {
+			return nil, fmt.Errorf("an issue can only duplicate another issue")
+		}
+		taskID, err := uuid.Parse(*req.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task_id UUID: %w", err)
+		}
+		if _, err := s.taskService.GetTask(orgID, taskID); err != nil {
+			return nil, err
+		}
+		link.TaskID = &taskID
+	} else {
+		if req.LinkType == models.LinkFixes {
+			return nil, fmt.Errorf("only a task can fix an issue")
+		}
+		linkedID, err := uuid.Parse(*req.IssueID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue_id UUID: %w", err)
+		}
+		if linkedID == issueID {
+			return nil, fmt.Errorf("an issue cannot link to itself")
+		}
+		if _, err := s.issueService.GetIssue(orgID, linkedID); err != nil {
+			return nil, err
+		}
+		link.LinkedIssueID = &linkedID
+	}
+
+	if err := s.linkRepo.Create(orgID, link); err != nil {
+		return nil, fmt.Errorf("failed to add link: %w", err)
+	}
+
+	s.audit(orgID, userID, "link", link)
+	return s.ListIssueLinksForRole(orgID, issueID, userID, role)
+}
+
+// RemoveLinkForRole deletes one of an issue's links.
+func (s *IssueLinkService) RemoveLinkForRole(orgID, issueID, linkID, userID uuid.UUID, role string) ([]models.IssueLink, error) {
+	if role != "admin" && role != "manager" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	link, err := s.linkRepo.GetByID(orgID, issueID, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link: %w", err)
+	}
+	if link == nil {
+		return nil, fmt.Errorf("link not found")
+	}
+	if err := s.linkRepo.Delete(orgID, linkID); err != nil {
+		return nil, err
+	}
+
+	s.audit(orgID, userID, "unlink", link)
+	return s.ListIssueLinksForRole(orgID, issueID, userID, role)
+}
+
+// CreateFixTaskForRole creates a task that fixes the issue, pre-filled from
+// the issue's title, description and AI summary, and links it with fixes.
+func (s *IssueLinkService) CreateFixTaskForRole(orgID, issueID, userID uuid.UUID, role string, req *models.CreateFixTaskRequest) (*models.Task, error) {
+	issue, err := s.issueService.GetIssueForRole(orgID, issueID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	taskReq := &models.CreateTaskRequest{
+		Title:       issue.Title,
+		Description: issue.Description,
+		Priority:    req.Priority,
+		AssignedTo:  req.AssignedTo,
+		AssigneeIDs: req.AssigneeIDs,
+		DueDate:     req.DueDate,
+		ProjectID:   req.ProjectID,
+	}
+	if req.Title != nil && *req.Title != "" {
+		taskReq.Title = *req.Title
+	}
+	if issue.AISummary != nil && *issue.AISummary != "" {
+		taskReq.Description += "\n\nAI summary:\n" + *issue.AISummary
+	}
+	if taskReq.Priority == "" {
+		taskReq.Priority = severityPriority[issue.Severity]
+	}
+	if taskReq.ProjectID == nil && issue.ProjectID != nil {
+		projectID := issue.ProjectID.String()
+		taskReq.ProjectID = &projectID
+	}
+
+	task, err := s.taskService.CreateTaskForRole(orgID, userID, role, taskReq)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &models.IssueLink{
+		ID:        uuid.New(),
+		IssueID:   issueID,
+		LinkType:  models.LinkFixes,
+		TaskID:    &task.ID,
+		CreatedBy: &userID,
+	}
+	if err := s.linkRepo.Create(orgID, link); err != nil {
+		// Without the link the task would be an orphan nobody asked for.
+		if discardErr := s.taskService.discardTasks(orgID, []uuid.UUID{task.ID}); discardErr != nil {
+			log.Printf("Warning: failed to discard fix task %s: %v", task.ID, discardErr)
+		}
+		return nil, fmt.Errorf("failed to link fix task: %w", err)
+	}
+
+	s.audit(orgID, userID, "create_fix_task", link)
+	return task, nil
+}
+
+func (s *IssueLinkService) audit(orgID, userID uuid.UUID, action string, link *models.IssueLink) {
+	details := map[string]interface{}{
+		"link_id":   link.ID.String(),
+		"link_type": link.LinkType,
+	}
+	if link.TaskID != nil {
+		details["task_id"] = link.TaskID.String()
+	}
+	if link.LinkedIssueID != nil {
+		details["linked_issue_id"] = link.LinkedIssueID.String()
+	}
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "issue",
+		EntityID:   &link.IssueID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
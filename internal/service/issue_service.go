@@ -13,35 +13,59 @@ import (
 )
 
 type IssueService struct {
-	issueRepo     *repository.IssueRepository
-	auditLogRepo  *repository.AuditLogRepository
-	geminiService *GeminiService
-	ragIndexer    *rag.Indexer
+	issueRepo      *repository.IssueRepository
+	watcherRepo    *repository.WatcherRepository
+	historyRepo    *repository.HistoryRepository
+	auditLogRepo   *repository.AuditLogRepository
+	projectSvc     *ProjectService
+	customFieldSvc *CustomFieldService
+	geminiService  *GeminiService
+	ragIndexer     *rag.Indexer
 }
 
 func NewIssueService(
 	issueRepo *repository.IssueRepository,
+	watcherRepo *repository.WatcherRepository,
+	historyRepo *repository.HistoryRepository,
 	auditLogRepo *repository.AuditLogRepository,
+	projectSvc *ProjectService,
+	customFieldSvc *CustomFieldService,
 	geminiService *GeminiService,
 	ragIndexer *rag.Indexer,
 ) *IssueService {
 	return &IssueService{
-		issueRepo:     issueRepo,
-		auditLogRepo:  auditLogRepo,
-		geminiService: geminiService,
-		ragIndexer:    ragIndexer,
+		issueRepo:      issueRepo,
+		watcherRepo:    watcherRepo,
+		historyRepo:    historyRepo,
+		auditLogRepo:   auditLogRepo,
+		projectSvc:     projectSvc,
+		customFieldSvc: customFieldSvc,
+		geminiService:  geminiService,
+		ragIndexer:     ragIndexer,
 	}
 }
 
-func (s *IssueService) CreateIssue(orgID, reportedBy uuid.UUID, req *models.CreateIssueRequest) (*models.Issue, error) {
+func (s *IssueService) CreateIssue(orgID, reportedBy uuid.UUID, role string, req *models.CreateIssueRequest) (*models.Issue, error) {
+	projectID, err := s.projectSvc.ResolveProjectForRole(orgID, req.ProjectID, reportedBy, role)
+	if err != nil {
+		return nil, err
+	}
+	customFields, err := s.customFieldSvc.ApplyValues(orgID, "issue", nil, req.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
 	issue := &models.Issue{
-		ID:          uuid.New(),
-		OrgID:       orgID,
-		Title:       req.Title,
-		Description: req.Description,
-		Severity:    req.Severity,
-		Status:      "open",
-		ReportedBy:  reportedBy,
+		ID:           uuid.New(),
+		OrgID:        orgID,
+		ProjectID:    projectID,
+		Title:        req.Title,
+		Description:  req.Description,
+		Severity:     req.Severity,
+		Status:       "open",
+		ReportedBy:   reportedBy,
+		Labels:       []models.Label{},
+		CustomFields: customFields,
 	}
 
 	if req.AssignedTo != nil && *req.AssignedTo != "" {
@@ -66,9 +90,9 @@ func (s *IssueService) CreateIssue(orgID, reportedBy uuid.UUID, req *models.Crea
 	}
 
 	// Index issue for RAG
-	if s.ragIndexer != nil {
-		s.ragIndexer.IndexIssue(context.Background(), issue.OrgID, issue.ID, issue.Title, issue.Description)
-	}
+	s.indexIssue(issue)
+
+	s.AddIssueWatchers(issue.ID, &reportedBy, issue.AssignedTo)
 
 	// Create audit log
 	auditLog := &models.AuditLog{
@@ -116,22 +140,52 @@ func (s *IssueService) GetIssueForRole(orgID, issueID, userID uuid.UUID, role st
 	return issue, nil
 }
 
-func (s *IssueService) ListIssuesForRole(orgID, userID uuid.UUID, role string, status string, severity string) ([]models.Issue, error) {
-	if role == "member" {
-		issues, err := s.issueRepo.ListForUser(orgID, userID, status, severity)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list issues: %w", err)
-		}
-		return issues, nil
+func (s *IssueService) ListIssuesForRole(orgID, userID uuid.UUID, role string, filter models.IssueListFilter) ([]models.Issue, error) {
+	if err := s.scopeIssueFilter(orgID, userID, role, &filter); err != nil {
+		return nil, err
 	}
 
-	issues, err := s.issueRepo.List(orgID, status, severity)
+	issues, err := s.issueRepo.List(orgID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list issues: %w", err)
 	}
 	return issues, nil
 }
 
+// ListIssuesPageForRole is the paginated form of ListIssuesForRole. It returns
+// the cursor for the next page, which is empty on the last one.
+func (s *IssueService) ListIssuesPageForRole(orgID, userID uuid.UUID, role string, filter models.IssueListFilter, page models.PageRequest) ([]models.Issue, string, error) {
+	if err := s.scopeIssueFilter(orgID, userID, role, &filter); err != nil {
+		return nil, "", err
+	}
+
+	issues, next, err := s.issueRepo.ListPage(orgID, filter, page)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list issues: %w", err)
+	}
+	return issues, next, nil
+}
+
+// scopeIssueFilter authorizes a listing's project scope and applies member
+// visibility.
+func (s *IssueService) scopeIssueFilter(orgID, userID uuid.UUID, role string, filter *models.IssueListFilter) error {
+	if filter.ProjectID != nil {
+		if err := s.projectSvc.AuthorizeScope(orgID, *filter.ProjectID, userID, role); err != nil {
+			return err
+		}
+	}
+	var err error
+	if filter.CustomFields, err = s.customFieldSvc.BuildFilter(orgID, "issue", filter.CustomFields); err != nil {
+		return err
+	}
+	filter.Viewer = &userID
+	if role == "member" {
+		// Members only see issues they reported or are assigned to.
+		filter.VisibleTo = &userID
+	}
+	return nil
+}
+
 func (s *IssueService) UpdateIssueForRole(orgID, issueID, userID uuid.UUID, role string, req *models.UpdateIssueRequest) (*models.Issue, error) {
 	issue, err := s.issueRepo.GetByID(orgID, issueID)
 	if err != nil {
@@ -154,6 +208,11 @@ func (s *IssueService) UpdateIssueForRole(orgID, issueID, userID uuid.UUID, role
 	if role != "admin" && role != "manager" && role != "member" {
 		return nil, fmt.Errorf("insufficient permissions")
 	}
+	if req.IfMatch != nil && *req.IfMatch != issue.Version {
+		return nil, models.ErrVersionConflict
+	}
+
+	before := *issue
 
 	// Update fields if provided
 	if req.Title != nil {
@@ -173,6 +232,7 @@ func (s *IssueService) UpdateIssueForRole(orgID, issueID, userID uuid.UUID, role
 			issue.ResolvedAt = &now
 		}
 	}
+	previousAssignee := issue.AssignedTo
 	if req.AssignedTo != nil {
 		if *req.AssignedTo == "" {
 			issue.AssignedTo = nil
@@ -184,16 +244,35 @@ func (s *IssueService) UpdateIssueForRole(orgID, issueID, userID uuid.UUID, role
 			issue.AssignedTo = &assignedID
 		}
 	}
+	if req.ProjectID != nil {
+		if issue.ProjectID, err = s.projectSvc.ResolveProjectForRole(orgID, req.ProjectID, userID, role); err != nil {
+			return nil, err
+		}
+	}
+	if req.CustomFields != nil {
+		if issue.CustomFields, err = s.customFieldSvc.ApplyValues(orgID, "issue", issue.CustomFields, req.CustomFields); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := s.issueRepo.Update(issue); err != nil {
+	changes := s.diffIssues(&before, issue)
+	if err := s.issueRepo.Update(issue, newHistoryEntry(&userID, changes)); err != nil {
 		return nil, fmt.Errorf("failed to update issue: %w", err)
 	}
 
-	// Re-index issue for RAG
-	if s.ragIndexer != nil {
-		s.ragIndexer.IndexIssue(context.Background(), issue.OrgID, issue.ID, issue.Title, issue.Description)
+	// Reload so the response carries resolved names, such as the new
+	// assignee's.
+	if refreshed, err := s.issueRepo.GetByID(orgID, issueID); err == nil && refreshed != nil {
+		issue = refreshed
 	}
 
+	if issue.AssignedTo != nil && (previousAssignee == nil || *previousAssignee != *issue.AssignedTo) {
+		s.AddIssueWatchers(issue.ID, issue.AssignedTo)
+	}
+
+	// Re-index issue for RAG
+	s.indexIssue(issue)
+
 	// Create audit log
 	auditLog := &models.AuditLog{
 		ID:         uuid.New(),
@@ -202,17 +281,38 @@ func (s *IssueService) UpdateIssueForRole(orgID, issueID, userID uuid.UUID, role
 		Action:     "update",
 		EntityType: "issue",
 		EntityID:   &issue.ID,
+		Details:    map[string]interface{}{"changes": changes},
 	}
 	_ = s.auditLogRepo.Create(auditLog)
 
 	return issue, nil
 }
 
+func (s *IssueService) indexIssue(issue *models.Issue) {
+	if s.ragIndexer != nil {
+		fields := s.customFieldSvc.DisplayLines(issue.OrgID, "issue", issue.CustomFields)
+		s.ragIndexer.IndexIssue(context.Background(), issue.OrgID, issue.ID, issue.Title, issue.Description, labelNames(issue.Labels), fields)
+	}
+}
+
+// reindexIssue refreshes an issue's RAG entry after related data, such as its
+// labels, changed.
+func (s *IssueService) reindexIssue(orgID, issueID uuid.UUID) {
+	if s.ragIndexer == nil {
+		return
+	}
+	issue, err := s.issueRepo.GetByID(orgID, issueID)
+	if err != nil || issue == nil {
+		return
+	}
+	s.indexIssue(issue)
+}
+
 func (s *IssueService) DeleteIssueForRole(orgID, issueID, userID uuid.UUID, role string) error {
 	if role != "admin" && role != "manager" {
 		return fmt.Errorf("insufficient permissions")
 	}
-	if err := s.issueRepo.Delete(orgID, issueID); err != nil {
+	if err := s.issueRepo.SoftDelete(orgID, issueID, userID); err != nil {
 		return fmt.Errorf("failed to delete issue: %w", err)
 	}
 
@@ -234,3 +334,28 @@ func (s *IssueService) DeleteIssueForRole(orgID, issueID, userID uuid.UUID, role
 
 	return nil
 }
+
+// RestoreIssue takes an issue out of the trash and re-indexes it for RAG.
+func (s *IssueService) RestoreIssue(orgID, issueID, userID uuid.UUID) (*models.Issue, error) {
+	if err := s.issueRepo.Restore(orgID, issueID); err != nil {
+		return nil, fmt.Errorf("failed to restore issue: %w", err)
+	}
+
+	issue, err := s.GetIssue(orgID, issueID)
+	if err != nil {
+		return nil, err
+	}
+	s.indexIssue(issue)
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "restore",
+		EntityType: "issue",
+		EntityID:   &issueID,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return issue, nil
+}
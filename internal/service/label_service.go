@@ -0,0 +1,289 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const defaultLabelColor = "#6B7280"
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type LabelService struct {
+	labelRepo    *repository.LabelRepository
+	auditLogRepo *repository.AuditLogRepository
+	taskService  *TaskService
+	issueService *IssueService
+}
+
+func NewLabelService(
+	labelRepo *repository.LabelRepository,
+	auditLogRepo *repository.AuditLogRepository,
+	taskService *TaskService,
+	issueService *IssueService,
+) *LabelService {
+	return &LabelService{
+		labelRepo:    labelRepo,
+		auditLogRepo: auditLogRepo,
+		taskService:  taskService,
+		issueService: issueService,
+	}
+}
+
+func labelNames(labels []models.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, l := range labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func normalizeLabel(name, color string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", fmt.Errorf("label name is required")
+	}
+	if strings.Contains(name, ",") {
+		return "", "", fmt.Errorf("label name cannot contain commas")
+	}
+	if color == "" {
+		color = defaultLabelColor
+	}
+	if !labelColorPattern.MatchString(color) {
+		return "", "", fmt.Errorf("color must be a hex value like #1F2937")
+	}
+	return name, strings.ToUpper(color), nil
+}
+
+func (s *LabelService) CreateLabel(orgID, userID uuid.UUID, req *models.CreateLabelRequest) (*models.Label, error) {
+	name, color, err := normalizeLabel(req.Name, req.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	label := &models.Label{
+		ID:    uuid.New(),
+		OrgID: orgID,
+		Name:  name,
+		Color: color,
+	}
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	s.audit(orgID, userID, "create", label.ID, map[string]interface{}{"name": label.Name})
+	return label, nil
+}
+
+func (s *LabelService) ListLabels(orgID uuid.UUID) ([]models.Label, error) {
+	labels, err := s.labelRepo.List(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	return labels, nil
+}
+
+func (s *LabelService) getLabel(orgID, labelID uuid.UUID) (*models.Label, error) {
+	label, err := s.labelRepo.GetByID(orgID, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+	if label == nil {
+		return nil, fmt.Errorf("label not found")
+	}
+	return label, nil
+}
+
+func (s *LabelService) UpdateLabel(orgID, labelID, userID uuid.UUID, req *models.UpdateLabelRequest) (*models.Label, error) {
+	label, err := s.getLabel(orgID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousName := label.Name
+	name, color := label.Name, label.Color
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.Color != nil {
+		color = *req.Color
+	}
+	if label.Name, label.Color, err = normalizeLabel(name, color); err != nil {
+		return nil, err
+	}
+
+	if err := s.labelRepo.Update(label); err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	if label.Name != previousName {
+		s.reindexTagged(orgID, labelID, nil, nil)
+	}
+
+	s.audit(orgID, userID, "update", label.ID, map[string]interface{}{"name": label.Name})
+	return label, nil
+}
+
+func (s *LabelService) DeleteLabel(orgID, labelID, userID uuid.UUID) error {
+	// Capture tagged entities before the delete cascades their links away.
+	taskIDs, issueIDs, err := s.labelRepo.ListTaggedIDs(labelID)
+	if err != nil {
+		return fmt.Errorf("failed to list labelled items: %w", err)
+	}
+
+	if err := s.labelRepo.Delete(orgID, labelID); err != nil {
+		return err
+	}
+
+	s.reindexTagged(orgID, labelID, taskIDs, issueIDs)
+	s.audit(orgID, userID, "delete", labelID, nil)
+	return nil
+}
+
+// reindexTagged refreshes RAG content for everything carrying a label. When
+// taskIDs and issueIDs are nil they are looked up.
+func (s *LabelService) reindexTagged(orgID, labelID uuid.UUID, taskIDs, issueIDs []uuid.UUID) {
+	if taskIDs == nil && issueIDs == nil {
+		var err error
+		if taskIDs, issueIDs, err = s.labelRepo.ListTaggedIDs(labelID); err != nil {
+			return
+		}
+	}
+	for _, id := range taskIDs {
+		s.taskService.reindexTask(orgID, id)
+	}
+	for _, id := range issueIDs {
+		s.issueService.reindexIssue(orgID, id)
+	}
+}
+
+// authorizeLabelling applies the edit rules of the target task or issue:
+// only managers and admins relabel tasks, while members may also relabel
+// issues they reported.
+func (s *LabelService) authorizeLabelling(orgID uuid.UUID, entityType string, entityID, userID uuid.UUID, role string) error {
+	switch entityType {
+	case "task":
+		if _, err := s.taskService.GetTask(orgID, entityID); err != nil {
+			return err
+		}
+		if role != "admin" && role != "manager" {
+			return fmt.Errorf("insufficient permissions")
+		}
+		return nil
+	case "issue":
+		issue, err := s.issueService.GetIssueForRole(orgID, entityID, userID, role)
+		if err != nil {
+			return err
+		}
+		if role == "member" && issue.ReportedBy != userID {
+			return fmt.Errorf("insufficient permissions")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid entity type: %s", entityType)
+	}
+}
+
+// AttachLabel tags a task or issue and returns its labels afterwards.
+func (s *LabelService) AttachLabel(orgID uuid.UUID, entityType string, entityID, userID uuid.UUID, role string, req *models.AttachLabelRequest) ([]models.Label, error) {
+	labelID, err := uuid.Parse(req.LabelID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label_id UUID: %w", err)
+	}
+	if err := s.authorizeLabelling(orgID, entityType, entityID, userID, role); err != nil {
+		return nil, err
+	}
+	label, err := s.getLabel(orgID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entityType == "task" {
+		err = s.labelRepo.AttachToTask(entityID, labelID)
+	} else {
+		err = s.labelRepo.AttachToIssue(entityID, labelID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach label: %w", err)
+	}
+
+	s.auditEntity(orgID, userID, "add_label", entityType, entityID, label)
+	return s.entityLabels(orgID, entityType, entityID)
+}
+
+// DetachLabel removes a label from a task or issue and returns its labels afterwards.
+func (s *LabelService) DetachLabel(orgID uuid.UUID, entityType string, entityID, labelID, userID uuid.UUID, role string) ([]models.Label, error) {
+	if err := s.authorizeLabelling(orgID, entityType, entityID, userID, role); err != nil {
+		return nil, err
+	}
+	label, err := s.getLabel(orgID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entityType == "task" {
+		err = s.labelRepo.DetachFromTask(entityID, labelID)
+	} else {
+		err = s.labelRepo.DetachFromIssue(entityID, labelID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditEntity(orgID, userID, "remove_label", entityType, entityID, label)
+	return s.entityLabels(orgID, entityType, entityID)
+}
+
+// entityLabels reloads the entity, re-indexes it with its new labels and
+// returns them.
+func (s *LabelService) entityLabels(orgID uuid.UUID, entityType string, entityID uuid.UUID) ([]models.Label, error) {
+	if entityType == "task" {
+		task, err := s.taskService.GetTask(orgID, entityID)
+		if err != nil {
+			return nil, err
+		}
+		s.taskService.indexTask(task)
+		return task.Labels, nil
+	}
+	issue, err := s.issueService.GetIssue(orgID, entityID)
+	if err != nil {
+		return nil, err
+	}
+	s.issueService.indexIssue(issue)
+	return issue.Labels, nil
+}
+
+func (s *LabelService) audit(orgID, userID uuid.UUID, action string, labelID uuid.UUID, details map[string]interface{}) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "label",
+		EntityID:   &labelID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
+
+func (s *LabelService) auditEntity(orgID, userID uuid.UUID, action, entityType string, entityID uuid.UUID, label *models.Label) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   &entityID,
+		Details: map[string]interface{}{
+			"label_id": label.ID.String(),
+			"label":    label.Name,
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type ProjectService struct {
+	projectRepo  *repository.ProjectRepository
+	userRepo     *repository.UserRepository
+	auditLogRepo *repository.AuditLogRepository
+}
+
+func NewProjectService(projectRepo *repository.ProjectRepository, userRepo *repository.UserRepository, auditLogRepo *repository.AuditLogRepository) *ProjectService {
+	return &ProjectService{
+		projectRepo:  projectRepo,
+		userRepo:     userRepo,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// ResolveProject parses a project_id supplied with a task, issue or document
+// and checks the project can take new work: it must exist in the organization
+// and not be archived. A nil or empty raw value means "no project".
+func (s *ProjectService) ResolveProject(orgID uuid.UUID, raw *string) (*uuid.UUID, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	projectID, err := uuid.Parse(*raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project_id UUID: %w", err)
+	}
+
+	project, err := s.getProject(orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.IsArchived {
+		return nil, fmt.Errorf("project is archived")
+	}
+	return &projectID, nil
+}
+
+// ResolveProjectForRole is ResolveProject for user-filed work: members may
+// only file into projects they belong to.
+func (s *ProjectService) ResolveProjectForRole(orgID uuid.UUID, raw *string, userID uuid.UUID, role string) (*uuid.UUID, error) {
+	projectID, err := s.ResolveProject(orgID, raw)
+	if err != nil || projectID == nil || role != "member" {
+		return projectID, err
+	}
+	isMember, err := s.projectRepo.IsMember(*projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check project membership: %w", err)
+	}
+	if !isMember {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	return projectID, nil
+}
+
+// AuthorizeScope checks that a user may read a project-scoped listing.
+// Members can only scope to projects they belong to.
+func (s *ProjectService) AuthorizeScope(orgID, projectID, userID uuid.UUID, role string) error {
+	if _, err := s.GetProjectForRole(orgID, projectID, userID, role); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ProjectService) getProject(orgID, projectID uuid.UUID) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(orgID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+	return project, nil
+}
+
+// getOrgUser checks that a user belongs to the organization.
+func (s *ProjectService) getOrgUser(orgID uuid.UUID, raw string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user UUID: %w", err)
+	}
+	user, err := s.userRepo.GetByID(orgID, userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return uuid.Nil, fmt.Errorf("user not found")
+	}
+	return userID, nil
+}
+
+func (s *ProjectService) CreateProject(orgID, createdBy uuid.UUID, req *models.CreateProjectRequest) (*models.Project, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+
+	ownerID := createdBy
+	if req.OwnerID != nil && *req.OwnerID != "" {
+		var err error
+		if ownerID, err = s.getOrgUser(orgID, *req.OwnerID); err != nil {
+			return nil, err
+		}
+	}
+
+	memberIDs := []uuid.UUID{ownerID}
+	for _, raw := range req.MemberIDs {
+		memberID, err := s.getOrgUser(orgID, raw)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	project := &models.Project{
+		ID:          uuid.New(),
+		OrgID:       orgID,
+		Name:        name,
+		Description: req.Description,
+		OwnerID:     &ownerID,
+	}
+	if err := s.projectRepo.Create(project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	for _, memberID := range memberIDs {
+		if err := s.projectRepo.AddMember(project.ID, memberID); err != nil {
+			return nil, fmt.Errorf("failed to add project member: %w", err)
+		}
+	}
+
+	s.audit(orgID, createdBy, "create", project.ID, map[string]interface{}{"name": project.Name})
+	return s.GetProject(orgID, project.ID)
+}
+
+// GetProject returns a project together with its members.
+func (s *ProjectService) GetProject(orgID, projectID uuid.UUID) (*models.Project, error) {
+	project, err := s.getProject(orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Members, err = s.projectRepo.ListMembers(projectID); err != nil {
+		return nil, fmt.Errorf("failed to list project members: %w", err)
+	}
+	return project, nil
+}
+
+func (s *ProjectService) GetProjectForRole(orgID, projectID, userID uuid.UUID, role string) (*models.Project, error) {
+	project, err := s.GetProject(orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "member" {
+		isMember := false
+		for _, m := range project.Members {
+			if m.UserID == userID {
+				isMember = true
+				break
+			}
+		}
+		if !isMember {
+			return nil, fmt.Errorf("insufficient permissions")
+		}
+	}
+	return project, nil
+}
+
+// ListProjectsForRole lists active projects, plus archived ones when
+// includeArchived is set. Members only see projects they belong to.
+func (s *ProjectService) ListProjectsForRole(orgID, userID uuid.UUID, role string, includeArchived bool) ([]models.Project, error) {
+	var memberID *uuid.UUID
+	if role == "member" {
+		memberID = &userID
+	}
+	projects, err := s.projectRepo.List(orgID, memberID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (s *ProjectService) UpdateProject(orgID, projectID, userID uuid.UUID, req *models.UpdateProjectRequest) (*models.Project, error) {
+	project, err := s.getProject(orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("project name is required")
+		}
+		project.Name = name
+	}
+	if req.Description != nil {
+		project.Description = *req.Description
+	}
+	if req.OwnerID != nil {
+		if *req.OwnerID == "" {
+			project.OwnerID = nil
+		} else {
+			ownerID, err := s.getOrgUser(orgID, *req.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+			project.OwnerID = &ownerID
+			if err := s.projectRepo.AddMember(projectID, ownerID); err != nil {
+				return nil, fmt.Errorf("failed to add project member: %w", err)
+			}
+		}
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	s.audit(orgID, userID, "update", projectID, nil)
+	return s.GetProject(orgID, projectID)
+}
+
+// SetArchived archives or restores a project. Archived projects keep their
+// tasks, issues and documents but accept no new ones.
+func (s *ProjectService) SetArchived(orgID, projectID, userID uuid.UUID, archived bool) (*models.Project, error) {
+	project, err := s.getProject(orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.IsArchived == archived {
+		return s.GetProject(orgID, projectID)
+	}
+
+	project.IsArchived = archived
+	project.ArchivedAt = nil
+	action := "unarchive"
+	if archived {
+		now := time.Now()
+		project.ArchivedAt = &now
+		action = "archive"
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	s.audit(orgID, userID, action, projectID, nil)
+	return s.GetProject(orgID, projectID)
+}
+
+func (s *ProjectService) ListMembersForRole(orgID, projectID, userID uuid.UUID, role string) ([]models.ProjectMember, error) {
+	project, err := s.GetProjectForRole(orgID, projectID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return project.Members, nil
+}
+
+func (s *ProjectService) AddMember(orgID, projectID, userID uuid.UUID, req *models.AddProjectMemberRequest) ([]models.ProjectMember, error) {
+	if _, err := s.getProject(orgID, projectID); err != nil {
+		return nil, err
+	}
+	memberID, err := s.getOrgUser(orgID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepo.AddMember(projectID, memberID); err != nil {
+		return nil, fmt.Errorf("failed to add project member: %w", err)
+	}
+
+	s.audit(orgID, userID, "add_member", projectID, map[string]interface{}{"user_id": memberID.String()})
+	return s.projectRepo.ListMembers(projectID)
+}
+
+func (s *ProjectService) RemoveMember(orgID, projectID, memberID, userID uuid.UUID) ([]models.ProjectMember, error) {
+	project, err := s.getProject(orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.OwnerID != nil && *project.OwnerID == memberID {
+		return nil, fmt.Errorf("cannot remove the project owner")
+	}
+
+	if err := s.projectRepo.RemoveMember(projectID, memberID); err != nil {
+		return nil, err
+	}
+
+	s.audit(orgID, userID, "remove_member", projectID, map[string]interface{}{"user_id": memberID.String()})
+	return s.projectRepo.ListMembers(projectID)
+}
+
+func (s *ProjectService) audit(orgID, userID uuid.UUID, action string, projectID uuid.UUID, details map[string]interface{}) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "project",
+		EntityID:   &projectID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/teambition/rrule-go"
+)
+
+// recurringBatchSize caps how many due series one scheduler tick materializes.
+const recurringBatchSize = 100
+
+type RecurringTaskService struct {
+	recurringRepo *repository.RecurringTaskRepository
+	taskService   *TaskService
+	projectSvc    *ProjectService
+	auditLogRepo  *repository.AuditLogRepository
+}
+
+func NewRecurringTaskService(recurringRepo *repository.RecurringTaskRepository, taskService *TaskService, projectSvc *ProjectService, auditLogRepo *repository.AuditLogRepository) *RecurringTaskService {
+	return &RecurringTaskService{
+		recurringRepo: recurringRepo,
+		taskService:   taskService,
+		projectSvc:    projectSvc,
+		auditLogRepo:  auditLogRepo,
+	}
+}
+
+// parseSchedule builds the occurrence generator for a series. The rule is
+// evaluated in the series' timezone so "every Monday 09:00" survives DST.
+func parseSchedule(rule string, dtstart time.Time, timezone string) (*rrule.RRule, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" || strings.ContainsAny(rule, "\r\n") {
+		return nil, fmt.Errorf("invalid rrule: expected a single RRULE line")
+	}
+	opt, err := rrule.StrToROptionInLocation(rule, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %w", err)
+	}
+	opt.Dtstart = dtstart.In(loc)
+
+	r, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %w", err)
+	}
+	return r, nil
+}
+
+// nextOccurrence returns the first occurrence strictly after t (or at t when
+// inclusive), or nil when the rule is exhausted.
+func nextOccurrence(r *rrule.RRule, t time.Time, inclusive bool) *time.Time {
+	next := r.After(t, inclusive)
+	if next.IsZero() {
+		return nil
+	}
+	return &next
+}
+
+func (s *RecurringTaskService) Create(orgID, createdBy uuid.UUID, req *models.CreateRecurringTaskRequest) (*models.RecurringTask, error) {
+	rt := &models.RecurringTask{
+		ID:               uuid.New(),
+		OrgID:            orgID,
+		Title:            req.Title,
+		Description:      req.Description,
+		Priority:         req.Priority,
+		RRule:            strings.TrimPrefix(strings.TrimSpace(req.RRule), "RRULE:"),
+		DTStart:          time.Now().Truncate(time.Minute),
+		Timezone:         req.Timezone,
+		DueOffsetMinutes: req.DueOffsetMinutes,
+		CreatedBy:        createdBy,
+	}
+	if rt.Priority == "" {
+		rt.Priority = "medium"
+	}
+	if rt.Timezone == "" {
+		rt.Timezone = "UTC"
+	}
+
+	if req.AssignedTo != nil && *req.AssignedTo != "" {
+		assignedID, err := s.projectSvc.getOrgUser(orgID, *req.AssignedTo)
+		if err != nil {
+			return nil, fmt.Errorf("assigned_to: %w", err)
+		}
+		rt.AssignedTo = &assignedID
+	}
+	projectID, err := s.projectSvc.ResolveProject(orgID, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	rt.ProjectID = projectID
+	if req.DTStart != nil && *req.DTStart != "" {
+		dtstart, err := time.Parse(time.RFC3339, *req.DTStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dtstart format: %w", err)
+		}
+		rt.DTStart = dtstart
+	}
+	if rt.DueOffsetMinutes != nil && *rt.DueOffsetMinutes < 0 {
+		return nil, fmt.Errorf("due_offset_minutes must not be negative")
+	}
+
+	schedule, err := parseSchedule(rt.RRule, rt.DTStart, rt.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	rt.NextRunAt = nextOccurrence(schedule, time.Now(), true)
+	if rt.NextRunAt == nil {
+		return nil, fmt.Errorf("rrule has no future occurrences")
+	}
+
+	if err := s.recurringRepo.Create(rt); err != nil {
+		return nil, fmt.Errorf("failed to create recurring task: %w", err)
+	}
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &createdBy,
+		Action:     "create",
+		EntityType: "recurring_task",
+		EntityID:   &rt.ID,
+		Details: map[string]interface{}{
+			"title": rt.Title,
+			"rrule": rt.RRule,
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return s.Get(orgID, rt.ID)
+}
+
+func (s *RecurringTaskService) Get(orgID, id uuid.UUID) (*models.RecurringTask, error) {
+	rt, err := s.recurringRepo.GetByID(orgID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring task: %w", err)
+	}
+	if rt == nil {
+		return nil, fmt.Errorf("recurring task not found")
+	}
+	return rt, nil
+}
+
+func (s *RecurringTaskService) List(orgID uuid.UUID) ([]models.RecurringTask, error) {
+	items, err := s.recurringRepo.List(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring tasks: %w", err)
+	}
+	return items, nil
+}
+
+// ListGeneratedTasks returns the tasks a series has materialized, newest first.
+func (s *RecurringTaskService) ListGeneratedTasks(orgID, id uuid.UUID) ([]models.Task, error) {
+	if _, err := s.Get(orgID, id); err != nil {
+		return nil, err
+	}
+	return s.taskService.ListTasks(orgID, models.TaskListFilter{RecurringTaskID: &id})
+}
+
+func (s *RecurringTaskService) Update(orgID, id, userID uuid.UUID, req *models.UpdateRecurringTaskRequest) (*models.RecurringTask, error) {
+	rt, err := s.Get(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	wasPaused := rt.IsPaused
+	reschedule := false
+
+	if req.Title != nil {
+		rt.Title = *req.Title
+	}
+	if req.Description != nil {
+		rt.Description = *req.Description
+	}
+	if req.Priority != nil {
+		rt.Priority = *req.Priority
+	}
+	if req.AssignedTo != nil {
+		if *req.AssignedTo == "" {
+			rt.AssignedTo = nil
+		} else {
+			assignedID, err := s.projectSvc.getOrgUser(orgID, *req.AssignedTo)
+			if err != nil {
+				return nil, fmt.Errorf("assigned_to: %w", err)
+			}
+			rt.AssignedTo = &assignedID
+		}
+	}
+	if req.ProjectID != nil {
+		if rt.ProjectID, err = s.projectSvc.ResolveProject(orgID, req.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	if req.RRule != nil {
+		rt.RRule = strings.TrimPrefix(strings.TrimSpace(*req.RRule), "RRULE:")
+		reschedule = true
+	}
+	if req.DTStart != nil {
+		dtstart, err := time.Parse(time.RFC3339, *req.DTStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dtstart format: %w", err)
+		}
+		rt.DTStart = dtstart
+		reschedule = true
+	}
+	if req.Timezone != nil {
+		rt.Timezone = *req.Timezone
+		reschedule = true
+	}
+	if req.DueOffsetMinutes != nil {
+		if *req.DueOffsetMinutes < 0 {
+			return nil, fmt.Errorf("due_offset_minutes must not be negative")
+		}
+		rt.DueOffsetMinutes = req.DueOffsetMinutes
+	}
+	if req.IsPaused != nil {
+		rt.IsPaused = *req.IsPaused
+	}
+
+	// Resuming skips occurrences missed while paused rather than backfilling them.
+	if wasPaused && !rt.IsPaused {
+		reschedule = true
+	}
+
+	schedule, err := parseSchedule(rt.RRule, rt.DTStart, rt.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	if reschedule {
+		rt.NextRunAt = nextOccurrence(schedule, time.Now(), true)
+	}
+
+	if err := s.recurringRepo.Update(rt); err != nil {
+		return nil, fmt.Errorf("failed to update recurring task: %w", err)
+	}
+
+	action := "update"
+	if req.IsPaused != nil && *req.IsPaused != wasPaused {
+		action = "resume"
+		if rt.IsPaused {
+			action = "pause"
+		}
+	}
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "recurring_task",
+		EntityID:   &rt.ID,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return s.Get(orgID, id)
+}
+
+// Delete removes a series. Tasks it already generated are kept.
+func (s *RecurringTaskService) Delete(orgID, id, userID uuid.UUID) error {
+	if err := s.recurringRepo.Delete(orgID, id); err != nil {
+		if err.Error() == "recurring task not found" {
+			return err
+		}
+		return fmt.Errorf("failed to delete recurring task: %w", err)
+	}
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "delete",
+		EntityType: "recurring_task",
+		EntityID:   &id,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return nil
+}
+
+// RunScheduler materializes due occurrences every interval until ctx is done.
+func (s *RecurringTaskService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunDue(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue creates one task for each series whose next occurrence has passed.
+// If the scheduler fell behind, only the latest missed occurrence is created.
+// It stops early once ctx is done; unprocessed series are picked up on the
+// next run.
+func (s *RecurringTaskService) RunDue(ctx context.Context, now time.Time) {
+	due, err := s.recurringRepo.ListDue(now, recurringBatchSize)
+	if err != nil {
+		log.Printf("Warning: failed to list due recurring tasks: %v", err)
+		return
+	}
+
+	for i := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		rt := &due[i]
+		if err := s.materialize(rt, now); err != nil {
+			log.Printf("Warning: failed to materialize recurring task %s: %v", rt.ID, err)
+		}
+	}
+}
+
+func (s *RecurringTaskService) materialize(rt *models.RecurringTask, now time.Time) error {
+	schedule, err := parseSchedule(rt.RRule, rt.DTStart, rt.Timezone)
+	if err != nil {
+		return err
+	}
+
+	occurrence := schedule.Before(now, true)
+	if occurrence.IsZero() || occurrence.Before(*rt.NextRunAt) {
+		occurrence = *rt.NextRunAt
+	}
+
+	next := nextOccurrence(schedule, now, false)
+	claimed, err := s.recurringRepo.ClaimRun(rt.ID, *rt.NextRunAt, next, now)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	req := &models.CreateTaskRequest{
+		Title:           rt.Title,
+		Description:     rt.Description,
+		Priority:        rt.Priority,
+		RecurringTaskID: &rt.ID,
+		CreatedFrom:     fmt.Sprintf("recurring task %q", rt.Title),
+	}
+	if rt.AssignedTo != nil {
+		assignedTo := rt.AssignedTo.String()
+		req.AssignedTo = &assignedTo
+	}
+	if rt.ProjectID != nil {
+		projectID := rt.ProjectID.String()
+		req.ProjectID = &projectID
+	}
+	if rt.DueOffsetMinutes != nil {
+		dueDate := occurrence.Add(time.Duration(*rt.DueOffsetMinutes) * time.Minute).Format(time.RFC3339)
+		req.DueDate = &dueDate
+	}
+
+	if _, err := s.taskService.CreateTask(rt.OrgID, rt.CreatedBy, req); err != nil {
+		// The occurrence is skipped. Pause the series so the failure, for
+		// instance an assignee who left the organization, is fixed before
+		// the next one instead of failing on every run.
+		paused, pauseErr := s.recurringRepo.Pause(rt.ID, next)
+		if pauseErr != nil {
+			log.Printf("Warning: failed to pause recurring task %s: %v", rt.ID, pauseErr)
+		}
+		if paused {
+			auditLog := &models.AuditLog{
+				ID:         uuid.New(),
+				OrgID:      rt.OrgID,
+				Action:     "pause",
+				EntityType: "recurring_task",
+				EntityID:   &rt.ID,
+				Details: map[string]interface{}{
+					"occurrence": occurrence.Format(time.RFC3339),
+					"error":      err.Error(),
+				},
+			}
+			_ = s.auditLogRepo.Create(auditLog)
+		}
+		return err
+	}
+	return nil
+}
@@ -15,6 +15,9 @@ type ReportService struct {
 	taskRepo      *repository.TaskRepository
 	issueRepo     *repository.IssueRepository
 	auditLogRepo  *repository.AuditLogRepository
+	worklogRepo   *repository.WorklogRepository
+	workflowSvc   *WorkflowService
+	projectSvc    *ProjectService
 	geminiService *GeminiService
 }
 
@@ -22,27 +25,46 @@ func NewReportService(
 	taskRepo *repository.TaskRepository,
 	issueRepo *repository.IssueRepository,
 	auditLogRepo *repository.AuditLogRepository,
+	worklogRepo *repository.WorklogRepository,
+	workflowSvc *WorkflowService,
+	projectSvc *ProjectService,
 	geminiService *GeminiService,
 ) *ReportService {
 	return &ReportService{
 		taskRepo:      taskRepo,
 		issueRepo:     issueRepo,
 		auditLogRepo:  auditLogRepo,
+		worklogRepo:   worklogRepo,
+		workflowSvc:   workflowSvc,
+		projectSvc:    projectSvc,
 		geminiService: geminiService,
 	}
 }
 
-func (s *ReportService) GenerateWeeklySummary(orgID uuid.UUID, generatedBy uuid.UUID) (string, error) {
+// GenerateWeeklySummary reports on the whole organization, or on a single
+// project when projectID is set.
+func (s *ReportService) GenerateWeeklySummary(orgID uuid.UUID, generatedBy uuid.UUID, projectID *uuid.UUID) (string, error) {
 	if s.geminiService == nil {
 		return "", fmt.Errorf("Gemini service not configured")
 	}
 
+	if projectID != nil {
+		if _, err := s.projectSvc.getProject(orgID, *projectID); err != nil {
+			return "", err
+		}
+	}
+
+	wf, err := s.workflowSvc.GetWorkflow(orgID)
+	if err != nil {
+		return "", err
+	}
+
 	now := time.Now()
-	tasks, err := s.taskRepo.List(orgID, "", "")
+	tasks, err := s.taskRepo.List(orgID, models.TaskListFilter{ProjectID: projectID})
 	if err != nil {
 		return "", fmt.Errorf("failed to list tasks: %w", err)
 	}
-	issues, err := s.issueRepo.List(orgID, "", "")
+	issues, err := s.issueRepo.List(orgID, models.IssueListFilter{ProjectID: projectID})
 	if err != nil {
 		return "", fmt.Errorf("failed to list issues: %w", err)
 	}
@@ -50,10 +72,10 @@ func (s *ReportService) GenerateWeeklySummary(orgID uuid.UUID, generatedBy uuid.
 	delayed := make([]models.Task, 0)
 	blockedCount := 0
 	for _, t := range tasks {
-		if t.Status == "blocked" {
+		if t.Status == BlockedStatus {
 			blockedCount++
 		}
-		if t.DueDate != nil && t.DueDate.Before(now) && t.Status != "approved" {
+		if t.DueDate != nil && t.DueDate.Before(now) && !isSignedOff(wf, t.Status) {
 			delayed = append(delayed, t)
 		}
 	}
@@ -149,6 +171,9 @@ High risk issues list
 		"generated_at":     now.Format(time.RFC3339),
 		"report_type":      "weekly_summary",
 	}
+	if projectID != nil {
+		details["project_id"] = projectID.String()
+	}
 	auditLog := &models.AuditLog{
 		ID:         uuid.New(),
 		OrgID:      orgID,
@@ -162,6 +187,91 @@ High risk issues list
 	return summary, nil
 }
 
+// GenerateTimesheet totals logged time per user, day and task over an
+// inclusive date range. The range defaults to the current week (Monday to
+// Sunday) and may span at most 366 days.
+func (s *ReportService) GenerateTimesheet(orgID uuid.UUID, filter models.TimesheetFilter) (*models.Timesheet, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	from, to := weekStart, weekStart.AddDate(0, 0, 6)
+	if filter.From != "" {
+		d, err := time.Parse(worklogDateLayout, filter.From)
+		if err != nil {
+			return nil, fmt.Errorf("from must be a date (YYYY-MM-DD)")
+		}
+		from = d
+		if filter.To == "" {
+			to = from.AddDate(0, 0, 6)
+		}
+	}
+	if filter.To != "" {
+		d, err := time.Parse(worklogDateLayout, filter.To)
+		if err != nil {
+			return nil, fmt.Errorf("to must be a date (YYYY-MM-DD)")
+		}
+		to = d
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("to must not be before from")
+	}
+	if to.Sub(from) > 366*24*time.Hour {
+		return nil, fmt.Errorf("timesheet period cannot exceed one year")
+	}
+	if filter.ProjectID != nil {
+		if _, err := s.projectSvc.getProject(orgID, *filter.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	filter.From = from.Format(worklogDateLayout)
+	filter.To = to.Format(worklogDateLayout)
+	rows, err := s.worklogRepo.ListTimesheetRows(orgID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build timesheet: %w", err)
+	}
+
+	sheet := &models.Timesheet{From: filter.From, To: filter.To, Users: []models.TimesheetUser{}}
+	userIdx := map[uuid.UUID]int{}
+	dayIdx := map[uuid.UUID]map[string]int{}
+	taskIdx := map[uuid.UUID]map[uuid.UUID]int{}
+	for _, row := range rows {
+		ui, ok := userIdx[row.UserID]
+		if !ok {
+			ui = len(sheet.Users)
+			userIdx[row.UserID] = ui
+			dayIdx[row.UserID] = map[string]int{}
+			taskIdx[row.UserID] = map[uuid.UUID]int{}
+			sheet.Users = append(sheet.Users, models.TimesheetUser{
+				UserID:   row.UserID,
+				UserName: strings.TrimSpace(row.UserName),
+				Days:     []models.TimesheetDay{},
+				Tasks:    []models.TimesheetTask{},
+			})
+		}
+		u := &sheet.Users[ui]
+		u.TotalSeconds += row.Seconds
+		sheet.TotalSeconds += row.Seconds
+
+		date := row.WorkDate.Format(worklogDateLayout)
+		if di, ok := dayIdx[row.UserID][date]; ok {
+			u.Days[di].Seconds += row.Seconds
+		} else {
+			dayIdx[row.UserID][date] = len(u.Days)
+			u.Days = append(u.Days, models.TimesheetDay{Date: date, Seconds: row.Seconds})
+		}
+
+		if ti, ok := taskIdx[row.UserID][row.TaskID]; ok {
+			u.Tasks[ti].Seconds += row.Seconds
+		} else {
+			taskIdx[row.UserID][row.TaskID] = len(u.Tasks)
+			u.Tasks = append(u.Tasks, models.TimesheetTask{TaskID: row.TaskID, Title: row.TaskTitle, Seconds: row.Seconds})
+		}
+	}
+
+	return sheet, nil
+}
+
 func preview(s string, max int) string {
 	if max <= 0 {
 		return ""
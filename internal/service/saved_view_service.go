@@ -0,0 +1,411 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// viewGroupFields lists the fields a saved view can group by.
+var viewGroupFields = map[string][]string{
+	"task":  {"status", "priority", "assignee", "project", "sprint"},
+	"issue": {"status", "severity", "assignee", "project"},
+}
+
+var viewRoles = []string{"admin", "manager", "member"}
+
+type SavedViewService struct {
+	viewRepo     *repository.SavedViewRepository
+	taskService  *TaskService
+	issueService *IssueService
+	auditLogRepo *repository.AuditLogRepository
+}
+
+func NewSavedViewService(viewRepo *repository.SavedViewRepository, taskService *TaskService, issueService *IssueService, auditLogRepo *repository.AuditLogRepository) *SavedViewService {
+	return &SavedViewService{
+		viewRepo:     viewRepo,
+		taskService:  taskService,
+		issueService: issueService,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// canSeeView reports whether a view is the user's own or shared with them.
+func canSeeView(view *models.SavedView, userID uuid.UUID, role string) bool {
+	switch {
+	case view.OwnerID == userID, view.Visibility == models.ViewOrg:
+		return true
+	case view.Visibility == models.ViewRole:
+		return view.SharedRole != nil && *view.SharedRole == role
+	}
+	return false
+}
+
+// visibleToRole reports whether everyone with a role can see a view, which
+// is required before it can be pinned for them.
+func visibleToRole(view *models.SavedView, role string) bool {
+	return view.Visibility == models.ViewOrg ||
+		(view.Visibility == models.ViewRole && view.SharedRole != nil && *view.SharedRole == role)
+}
+
+// canManageView allows owners to change their views, and admins and managers
+// to change shared ones.
+func canManageView(view *models.SavedView, userID uuid.UUID, role string) bool {
+	if view.OwnerID == userID {
+		return true
+	}
+	return view.Visibility != models.ViewPrivate && (role == "admin" || role == "manager")
+}
+
+// validateView normalizes a view's fields and checks its query, sort,
+// grouping and sharing.
+func validateView(view *models.SavedView) error {
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return fmt.Errorf("view name is required")
+	}
+	view.Query = strings.TrimSpace(view.Query)
+	if err := repository.ValidateListQuery(view.EntityType, view.Query, view.Sort, view.Order); err != nil {
+		return err
+	}
+	if view.GroupBy != "" && !contains(viewGroupFields[view.EntityType], view.GroupBy) {
+		return fmt.Errorf("cannot group %ss by %q; use one of %s", view.EntityType, view.GroupBy, strings.Join(viewGroupFields[view.EntityType], ", "))
+	}
+
+	columns := make([]string, 0, len(view.Columns))
+	seen := map[string]bool{}
+	for _, col := range view.Columns {
+		col = strings.TrimSpace(col)
+		if col == "" || seen[col] {
+			continue
+		}
+		seen[col] = true
+		columns = append(columns, col)
+	}
+	view.Columns = columns
+
+	if view.Visibility == "" {
+		view.Visibility = models.ViewPrivate
+	}
+	if view.Visibility == models.ViewRole {
+		if view.SharedRole == nil || !contains(viewRoles, *view.SharedRole) {
+			return fmt.Errorf("shared_role must be one of %s", strings.Join(viewRoles, ", "))
+		}
+	} else {
+		view.SharedRole = nil
+	}
+	return nil
+}
+
+func (s *SavedViewService) getViewForRole(orgID, viewID, userID uuid.UUID, role string) (*models.SavedView, error) {
+	view, err := s.viewRepo.GetByID(orgID, viewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get view: %w", err)
+	}
+	if view == nil || !canSeeView(view, userID, role) {
+		return nil, fmt.Errorf("view not found")
+	}
+	return view, nil
+}
+
+func (s *SavedViewService) CreateView(orgID, userID uuid.UUID, req *models.CreateSavedViewRequest) (*models.SavedView, error) {
+	view := &models.SavedView{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		OwnerID:    userID,
+		Name:       req.Name,
+		EntityType: req.EntityType,
+		Query:      req.Query,
+		Sort:       req.Sort,
+		Order:      req.Order,
+		Columns:    req.Columns,
+		GroupBy:    req.GroupBy,
+		Visibility: req.Visibility,
+		SharedRole: req.SharedRole,
+	}
+	if err := validateView(view); err != nil {
+		return nil, err
+	}
+	if err := s.viewRepo.Create(view); err != nil {
+		return nil, fmt.Errorf("failed to create view: %w", err)
+	}
+
+	s.audit(orgID, userID, "create", view.ID, map[string]interface{}{"name": view.Name, "visibility": view.Visibility})
+	return view, nil
+}
+
+// ListViewsForRole returns the views the user owns or that are shared with
+// them, optionally limited to one entity type.
+func (s *SavedViewService) ListViewsForRole(orgID, userID uuid.UUID, role, entityType string) ([]models.SavedView, error) {
+	if entityType != "" && viewGroupFields[entityType] == nil {
+		return nil, fmt.Errorf("invalid entity_type: %s", entityType)
+	}
+	views, err := s.viewRepo.ListVisible(orgID, userID, role, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+	return views, nil
+}
+
+func (s *SavedViewService) GetViewForRole(orgID, viewID, userID uuid.UUID, role string) (*models.SavedView, error) {
+	return s.getViewForRole(orgID, viewID, userID, role)
+}
+
+// GetDefaultView returns the view pinned for the user's role, or nil.
+func (s *SavedViewService) GetDefaultView(orgID uuid.UUID, role, entityType string) (*models.SavedView, error) {
+	if viewGroupFields[entityType] == nil {
+		return nil, fmt.Errorf("entity_type must be task or issue")
+	}
+	view, err := s.viewRepo.GetDefault(orgID, entityType, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default view: %w", err)
+	}
+	return view, nil
+}
+
+func (s *SavedViewService) UpdateViewForRole(orgID, viewID, userID uuid.UUID, role string, req *models.UpdateSavedViewRequest) (*models.SavedView, error) {
+	view, err := s.getViewForRole(orgID, viewID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageView(view, userID, role) {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	if req.Visibility != nil && *req.Visibility == models.ViewPrivate && view.OwnerID != userID {
+		// A private view would be visible only to its owner, so only the
+		// owner may take a shared view private.
+		return nil, fmt.Errorf("only the owner can make a view private")
+	}
+
+	if req.Name != nil {
+		view.Name = *req.Name
+	}
+	if req.Query != nil {
+		view.Query = *req.Query
+	}
+	if req.Sort != nil {
+		view.Sort = *req.Sort
+	}
+	if req.Order != nil {
+		view.Order = *req.Order
+	}
+	if req.Columns != nil {
+		view.Columns = *req.Columns
+	}
+	if req.GroupBy != nil {
+		view.GroupBy = *req.GroupBy
+	}
+	if req.Visibility != nil {
+		view.Visibility = *req.Visibility
+	}
+	if req.SharedRole != nil {
+		view.SharedRole = req.SharedRole
+	}
+	if err := validateView(view); err != nil {
+		return nil, err
+	}
+	if err := s.viewRepo.Update(view); err != nil {
+		return nil, fmt.Errorf("failed to update view: %w", err)
+	}
+
+	s.audit(orgID, userID, "update", view.ID, nil)
+	return view, nil
+}
+
+func (s *SavedViewService) DeleteViewForRole(orgID, viewID, userID uuid.UUID, role string) error {
+	view, err := s.getViewForRole(orgID, viewID, userID, role)
+	if err != nil {
+		return err
+	}
+	if !canManageView(view, userID, role) {
+		return fmt.Errorf("insufficient permissions")
+	}
+	if err := s.viewRepo.Delete(orgID, viewID); err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+
+	s.audit(orgID, userID, "delete", viewID, map[string]interface{}{"name": view.Name})
+	return nil
+}
+
+// PinView makes a shared view the default for everyone with a role. Only
+// admins and managers pin views.
+func (s *SavedViewService) PinView(orgID, viewID, userID uuid.UUID, role string, req *models.PinSavedViewRequest) (*models.SavedView, error) {
+	if role != "admin" && role != "manager" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	view, err := s.getViewForRole(orgID, viewID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !visibleToRole(view, req.Role) {
+		return nil, fmt.Errorf("share the view with the organization or the %s role before pinning it", req.Role)
+	}
+	if err := s.viewRepo.Pin(view, req.Role, userID); err != nil {
+		return nil, fmt.Errorf("failed to pin view: %w", err)
+	}
+
+	s.audit(orgID, userID, "pin", view.ID, map[string]interface{}{"role": req.Role})
+	return s.getViewForRole(orgID, viewID, userID, role)
+}
+
+func (s *SavedViewService) UnpinView(orgID, viewID, userID uuid.UUID, role, pinnedRole string) (*models.SavedView, error) {
+	if role != "admin" && role != "manager" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	if _, err := s.getViewForRole(orgID, viewID, userID, role); err != nil {
+		return nil, err
+	}
+	removed, err := s.viewRepo.Unpin(orgID, viewID, pinnedRole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpin view: %w", err)
+	}
+	if !removed {
+		return nil, fmt.Errorf("view is not pinned for %s", pinnedRole)
+	}
+
+	s.audit(orgID, userID, "unpin", viewID, map[string]interface{}{"role": pinnedRole})
+	return s.getViewForRole(orgID, viewID, userID, role)
+}
+
+// RunViewForRole returns one page of a view's results under the caller's own
+// visibility rules, so a shared view never shows a member more than their
+// task or issue list would. The view's sort applies; page supplies only the
+// cursor and limit.
+func (s *SavedViewService) RunViewForRole(orgID, viewID, userID uuid.UUID, role string, page models.PageRequest) (*models.ViewResult, error) {
+	view, err := s.getViewForRole(orgID, viewID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	page.Sort = view.Sort
+	page.Order = view.Order
+
+	result := &models.ViewResult{View: view}
+	var next string
+	if view.EntityType == "issue" {
+		issues, cursor, err := s.issueService.ListIssuesPageForRole(orgID, userID, role, models.IssueListFilter{Query: view.Query}, page)
+		if err != nil {
+			return nil, err
+		}
+		next = cursor
+		if view.GroupBy != "" {
+			result.Groups = groupIssues(issues, view.GroupBy)
+		} else {
+			result.Data = issues
+		}
+	} else {
+		tasks, cursor, err := s.taskService.ListTasksPageForRole(orgID, userID, role, models.TaskListFilter{Query: view.Query}, page)
+		if err != nil {
+			return nil, err
+		}
+		next = cursor
+		if view.GroupBy != "" {
+			result.Groups = groupTasks(tasks, view.GroupBy)
+		} else {
+			result.Data = tasks
+		}
+	}
+	if next != "" {
+		result.NextCursor = &next
+	}
+	return result, nil
+}
+
+func optionalKey(id *uuid.UUID) string {
+	if id == nil {
+		return "none"
+	}
+	return id.String()
+}
+
+func groupTasks(tasks []models.Task, by string) []models.ViewGroup {
+	groups := []models.ViewGroup{}
+	index := map[string]int{}
+	items := [][]models.Task{}
+	for _, t := range tasks {
+		var key string
+		switch by {
+		case "status":
+			key = t.Status
+		case "priority":
+			key = t.Priority
+		case "assignee":
+			key = optionalKey(t.AssignedTo)
+		case "project":
+			key = optionalKey(t.ProjectID)
+		case "sprint":
+			key = optionalKey(t.SprintID)
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, models.ViewGroup{Key: key})
+			items = append(items, []models.Task{})
+		}
+		items[i] = append(items[i], t)
+	}
+	for i := range groups {
+		groups[i].Count = len(items[i])
+		groups[i].Items = items[i]
+	}
+	return groups
+}
+
+func groupIssues(issues []models.Issue, by string) []models.ViewGroup {
+	groups := []models.ViewGroup{}
+	index := map[string]int{}
+	items := [][]models.Issue{}
+	for _, iss := range issues {
+		var key string
+		switch by {
+		case "status":
+			key = iss.Status
+		case "severity":
+			key = iss.Severity
+		case "assignee":
+			key = optionalKey(iss.AssignedTo)
+		case "project":
+			key = optionalKey(iss.ProjectID)
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, models.ViewGroup{Key: key})
+			items = append(items, []models.Issue{})
+		}
+		items[i] = append(items[i], iss)
+	}
+	for i := range groups {
+		groups[i].Count = len(items[i])
+		groups[i].Items = items[i]
+	}
+	return groups
+}
+
+func (s *SavedViewService) audit(orgID, userID uuid.UUID, action string, viewID uuid.UUID, details map[string]interface{}) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "saved_view",
+		EntityID:   &viewID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// SearchService runs keyword search over Postgres full-text indexes. Unlike
+// the RAG endpoint it needs no AI provider.
+type SearchService struct {
+	searchRepo *repository.SearchRepository
+	projectSvc *ProjectService
+}
+
+func NewSearchService(searchRepo *repository.SearchRepository, projectSvc *ProjectService) *SearchService {
+	return &SearchService{searchRepo: searchRepo, projectSvc: projectSvc}
+}
+
+// SearchForRole returns ranked hits the caller may see. Members only match
+// the tasks, issues and documents ListTasksForRole and ListIssuesForRole
+// would show them. Facets ignore the type filter so clients can show counts
+// for every tab.
+func (s *SearchService) SearchForRole(orgID, userID uuid.UUID, role string, filter models.SearchFilter, page models.PageRequest) (*models.SearchResponse, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Query == "" {
+		return nil, fmt.Errorf("q is required")
+	}
+	switch filter.Type {
+	case "", models.SearchTask, models.SearchIssue, models.SearchDocument:
+	default:
+		return nil, fmt.Errorf("invalid type: %s", filter.Type)
+	}
+	if filter.ProjectID != nil {
+		if err := s.projectSvc.AuthorizeScope(orgID, *filter.ProjectID, userID, role); err != nil {
+			return nil, err
+		}
+	}
+	if role == "member" {
+		filter.VisibleTo = &userID
+	}
+
+	allTypes := filter
+	allTypes.Type = ""
+	facets, err := s.searchRepo.Facets(orgID, allTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	total := facets[filter.Type]
+	if filter.Type == "" {
+		total = 0
+		for _, n := range facets {
+			total += n
+		}
+	}
+
+	hits, next, err := s.searchRepo.Search(orgID, filter, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	result := &models.SearchResponse{
+		Query:  filter.Query,
+		Total:  total,
+		Facets: facets,
+		Data:   hits,
+	}
+	if next != "" {
+		result.NextCursor = &next
+	}
+	return result, nil
+}
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const sprintDateLayout = "2006-01-02"
+
+type SprintService struct {
+	sprintRepo   *repository.SprintRepository
+	taskService  *TaskService
+	projectSvc   *ProjectService
+	auditLogRepo *repository.AuditLogRepository
+}
+
+func NewSprintService(sprintRepo *repository.SprintRepository, taskService *TaskService, projectSvc *ProjectService, auditLogRepo *repository.AuditLogRepository) *SprintService {
+	return &SprintService{
+		sprintRepo:   sprintRepo,
+		taskService:  taskService,
+		projectSvc:   projectSvc,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+func parseSprintDates(start, end string) (time.Time, time.Time, error) {
+	startDate, err := time.Parse(sprintDateLayout, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("start_date must be a date (YYYY-MM-DD)")
+	}
+	endDate, err := time.Parse(sprintDateLayout, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must be a date (YYYY-MM-DD)")
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must not be before start_date")
+	}
+	return startDate, endDate, nil
+}
+
+func (s *SprintService) CreateSprint(orgID, userID uuid.UUID, req *models.CreateSprintRequest) (*models.Sprint, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("sprint name is required")
+	}
+	startDate, endDate, err := parseSprintDates(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint := &models.Sprint{
+		ID:        uuid.New(),
+		OrgID:     orgID,
+		Name:      name,
+		Goal:      req.Goal,
+		StartDate: startDate,
+		EndDate:   endDate,
+		State:     models.SprintPlanned,
+		CreatedBy: &userID,
+	}
+	if sprint.ProjectID, err = s.projectSvc.ResolveProject(orgID, req.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.sprintRepo.Create(sprint); err != nil {
+		return nil, fmt.Errorf("failed to create sprint: %w", err)
+	}
+
+	s.audit(orgID, userID, "create", sprint.ID, map[string]interface{}{
+		"name":       sprint.Name,
+		"start_date": req.StartDate,
+		"end_date":   req.EndDate,
+	})
+	return sprint, nil
+}
+
+func (s *SprintService) getSprint(orgID, sprintID uuid.UUID) (*models.Sprint, error) {
+	sprint, err := s.sprintRepo.GetByID(orgID, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sprint: %w", err)
+	}
+	if sprint == nil {
+		return nil, fmt.Errorf("sprint not found")
+	}
+	return sprint, nil
+}
+
+// GetSprintForRole returns a sprint. Members can only see sprints of projects
+// they belong to.
+func (s *SprintService) GetSprintForRole(orgID, sprintID, userID uuid.UUID, role string) (*models.Sprint, error) {
+	sprint, err := s.getSprint(orgID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.ProjectID != nil {
+		if err := s.projectSvc.AuthorizeScope(orgID, *sprint.ProjectID, userID, role); err != nil {
+			return nil, err
+		}
+	}
+	return sprint, nil
+}
+
+func (s *SprintService) ListSprintsForRole(orgID, userID uuid.UUID, role string, filter models.SprintListFilter) ([]models.Sprint, error) {
+	if filter.State != "" && filter.State != models.SprintPlanned && filter.State != models.SprintActive && filter.State != models.SprintClosed {
+		return nil, fmt.Errorf("invalid sprint state: %s", filter.State)
+	}
+	if filter.ProjectID != nil {
+		if err := s.projectSvc.AuthorizeScope(orgID, *filter.ProjectID, userID, role); err != nil {
+			return nil, err
+		}
+	}
+	sprints, err := s.sprintRepo.List(orgID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sprints: %w", err)
+	}
+	return sprints, nil
+}
+
+// ListSprintTasksForRole lists a sprint's tasks with the usual task visibility
+// rules applied.
+func (s *SprintService) ListSprintTasksForRole(orgID, sprintID, userID uuid.UUID, role string) ([]models.Task, error) {
+	if _, err := s.GetSprintForRole(orgID, sprintID, userID, role); err != nil {
+		return nil, err
+	}
+	return s.taskService.ListTasksForRole(orgID, userID, role, models.TaskListFilter{SprintID: &sprintID})
+}
+
+func (s *SprintService) UpdateSprint(orgID, sprintID, userID uuid.UUID, req *models.UpdateSprintRequest) (*models.Sprint, error) {
+	sprint, err := s.getSprint(orgID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State == models.SprintClosed {
+		return nil, fmt.Errorf("sprint is closed")
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("sprint name is required")
+		}
+		sprint.Name = name
+	}
+	if req.Goal != nil {
+		sprint.Goal = *req.Goal
+	}
+	if req.StartDate != nil || req.EndDate != nil {
+		start, end := sprint.StartDate.Format(sprintDateLayout), sprint.EndDate.Format(sprintDateLayout)
+		if req.StartDate != nil {
+			start = *req.StartDate
+		}
+		if req.EndDate != nil {
+			end = *req.EndDate
+		}
+		if sprint.StartDate, sprint.EndDate, err = parseSprintDates(start, end); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.sprintRepo.Update(sprint); err != nil {
+		return nil, fmt.Errorf("failed to update sprint: %w", err)
+	}
+
+	s.audit(orgID, userID, "update", sprint.ID, nil)
+	return sprint, nil
+}
+
+// StartSprint activates a planned sprint. Only one sprint per project can be
+// active at a time.
+func (s *SprintService) StartSprint(orgID, sprintID, userID uuid.UUID) (*models.Sprint, error) {
+	sprint, err := s.getSprint(orgID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State != models.SprintPlanned {
+		return nil, fmt.Errorf("only planned sprints can be started")
+	}
+
+	sprint.State = models.SprintActive
+	if err := s.sprintRepo.Update(sprint); err != nil {
+		return nil, fmt.Errorf("failed to start sprint: %w", err)
+	}
+
+	s.audit(orgID, userID, "start", sprint.ID, nil)
+	return sprint, nil
+}
+
+// CloseSprint closes an active sprint and carries its unfinished tasks
+// forward: to req.CarryOverTo if given, otherwise to the next planned sprint
+// in the same project, otherwise back to the backlog.
+func (s *SprintService) CloseSprint(orgID, sprintID, userID uuid.UUID, req *models.CloseSprintRequest) (*models.SprintCloseResult, error) {
+	sprint, err := s.getSprint(orgID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State != models.SprintActive {
+		return nil, fmt.Errorf("only active sprints can be closed")
+	}
+
+	var carryTo *uuid.UUID
+	if req.CarryOverTo != nil && *req.CarryOverTo != "" {
+		targetID, err := uuid.Parse(*req.CarryOverTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid carry_over_to UUID: %w", err)
+		}
+		target, err := s.getSprint(orgID, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if target.ID == sprint.ID || target.State == models.SprintClosed {
+			return nil, fmt.Errorf("carry_over_to must be another open sprint")
+		}
+		if !sameProject(target.ProjectID, sprint.ProjectID) {
+			return nil, fmt.Errorf("carry_over_to must be in the same project")
+		}
+		carryTo = &target.ID
+	} else {
+		next, err := s.sprintRepo.NextPlanned(orgID, sprint.ProjectID, sprint.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find next sprint: %w", err)
+		}
+		if next != nil {
+			carryTo = &next.ID
+		}
+	}
+
+	moved, err := s.sprintRepo.Close(sprint, carryTo, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to close sprint: %w", err)
+	}
+	if refreshed, err := s.sprintRepo.GetByID(orgID, sprintID); err == nil && refreshed != nil {
+		sprint = refreshed
+	}
+	moves := make([]repository.SprintMove, 0, len(moved))
+	for _, id := range moved {
+		moves = append(moves, repository.SprintMove{TaskID: id, FromSprintID: &sprint.ID})
+	}
+	s.recordSprintMoves(orgID, userID, carryTo, moves)
+
+	details := map[string]interface{}{
+		"carried_over":     len(moved),
+		"completed_points": sprint.CompletedPoints,
+	}
+	if carryTo != nil {
+		details["carried_over_to"] = carryTo.String()
+	}
+	s.audit(orgID, userID, "close", sprint.ID, details)
+
+	return &models.SprintCloseResult{Sprint: sprint, CarriedOver: len(moved), CarriedOverTo: carryTo}, nil
+}
+
+func sameProject(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// AddTasks moves tasks into an open sprint. Tasks must belong to the sprint's
+// project when it has one.
+func (s *SprintService) AddTasks(orgID, sprintID, userID uuid.UUID, req *models.SprintTasksRequest) (*models.Sprint, error) {
+	sprint, err := s.getSprint(orgID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State == models.SprintClosed {
+		return nil, fmt.Errorf("sprint is closed")
+	}
+
+	taskIDs := make([]uuid.UUID, 0, len(req.TaskIDs))
+	for _, raw := range req.TaskIDs {
+		taskID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task UUID: %w", err)
+		}
+		task, err := s.taskService.GetTask(orgID, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if sprint.ProjectID != nil && !sameProject(task.ProjectID, sprint.ProjectID) {
+			return nil, fmt.Errorf("task %s is not in the sprint's project", task.ID)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	moves, err := s.sprintRepo.AddTasks(orgID, sprintID, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add tasks to sprint: %w", err)
+	}
+	s.recordSprintMoves(orgID, userID, &sprint.ID, moves)
+
+	s.audit(orgID, userID, "add_tasks", sprint.ID, map[string]interface{}{"task_ids": req.TaskIDs})
+	return s.getSprint(orgID, sprintID)
+}
+
+func (s *SprintService) RemoveTask(orgID, sprintID, taskID, userID uuid.UUID) (*models.Sprint, error) {
+	sprint, err := s.getSprint(orgID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.State == models.SprintClosed {
+		return nil, fmt.Errorf("sprint is closed")
+	}
+	if err := s.sprintRepo.RemoveTask(orgID, sprintID, taskID); err != nil {
+		return nil, err
+	}
+	s.recordSprintMoves(orgID, userID, nil, []repository.SprintMove{{TaskID: taskID, FromSprintID: &sprint.ID}})
+
+	s.audit(orgID, userID, "remove_task", sprint.ID, map[string]interface{}{"task_id": taskID.String()})
+	return s.getSprint(orgID, sprintID)
+}
+
+// Velocity reports completed estimate points for the most recent started
+// sprints, newest first, and their average over closed sprints.
+func (s *SprintService) Velocity(orgID, userID uuid.UUID, role string, projectID *uuid.UUID, limit int) (*models.VelocityReport, error) {
+	if projectID != nil {
+		if err := s.projectSvc.AuthorizeScope(orgID, *projectID, userID, role); err != nil {
+			return nil, err
+		}
+	}
+	if limit <= 0 {
+		limit = 6
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	sprints, err := s.sprintRepo.Velocity(orgID, projectID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute velocity: %w", err)
+	}
+
+	report := &models.VelocityReport{Sprints: sprints}
+	closed := 0
+	total := 0.0
+	for _, v := range sprints {
+		if v.State == models.SprintClosed {
+			closed++
+			total += v.CompletedPoints
+		}
+	}
+	if closed > 0 {
+		report.AveragePoints = total / float64(closed)
+	}
+	return report, nil
+}
+
+// recordSprintMoves adds the sprint change to the history of each moved task;
+// to is nil for moves to the backlog.
+func (s *SprintService) recordSprintMoves(orgID, userID uuid.UUID, to *uuid.UUID, moves []repository.SprintMove) {
+	names := map[uuid.UUID]string{}
+	name := func(id *uuid.UUID) string {
+		if id == nil {
+			return ""
+		}
+		if n, ok := names[*id]; ok {
+			return n
+		}
+		if sprint, err := s.sprintRepo.GetByID(orgID, *id); err == nil && sprint != nil {
+			names[*id] = sprint.Name
+		}
+		return names[*id]
+	}
+	for _, m := range moves {
+		d := fieldDiff{}
+		d.ref("sprint_id", m.FromSprintID, to, name(m.FromSprintID), name(to))
+		s.taskService.recordTaskHistory(orgID, m.TaskID, &userID, d)
+	}
+}
+
+func (s *SprintService) audit(orgID, userID uuid.UUID, action string, sprintID uuid.UUID, details map[string]interface{}) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "sprint",
+		EntityID:   &sprintID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
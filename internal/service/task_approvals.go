@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// signOffAttempts bounds how often a satisfied policy retries moving the
+// task to approved when a concurrent edit bumped its version.
+const signOffAttempts = 3
+
+// approvalPolicyFor returns the approval policy governing the task, or nil.
+func (s *TaskService) approvalPolicyFor(task *models.Task) (*models.ApprovalPolicy, error) {
+	policy, err := s.approvalRepo.GetForProject(task.OrgID, task.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policy: %w", err)
+	}
+	return policy, nil
+}
+
+func meetsRequirement(req models.ApprovalRequirement, userID *uuid.UUID, role string) bool {
+	if req.UserID != nil {
+		return userID != nil && *req.UserID == *userID
+	}
+	return req.Role == role
+}
+
+// evaluateApprovals matches the approvals received, oldest first, against the
+// policy. Under a sequential policy each approval can only meet the next
+// requirement; otherwise it meets the first open requirement naming its user,
+// or failing that its role. Approvals that meet no requirement still count
+// toward MinApprovals.
+func evaluateApprovals(policy *models.ApprovalPolicy, approvals []models.TaskApproval) *models.ApprovalStatus {
+	met := make([]bool, len(policy.Requirements))
+	next := 0
+	for _, a := range approvals {
+		if policy.Sequential {
+			if next < len(met) && meetsRequirement(policy.Requirements[next], a.UserID, a.Role) {
+				met[next] = true
+				next++
+			}
+			continue
+		}
+		match := -1
+		for i, req := range policy.Requirements {
+			if met[i] || !meetsRequirement(req, a.UserID, a.Role) {
+				continue
+			}
+			if req.UserID != nil {
+				match = i
+				break
+			}
+			if match < 0 {
+				match = i
+			}
+		}
+		if match >= 0 {
+			met[match] = true
+		}
+	}
+
+	pending := []models.ApprovalRequirement{}
+	for i, req := range policy.Requirements {
+		if !met[i] {
+			pending = append(pending, req)
+		}
+	}
+	needed := policy.MinApprovals
+	if needed < len(policy.Requirements) {
+		needed = len(policy.Requirements)
+	}
+	remaining := needed - len(approvals)
+	if remaining < len(pending) {
+		remaining = len(pending)
+	}
+	return &models.ApprovalStatus{
+		PolicyID:     policy.ID,
+		MinApprovals: policy.MinApprovals,
+		Sequential:   policy.Sequential,
+		Received:     approvals,
+		Pending:      pending,
+		Remaining:    remaining,
+		Satisfied:    remaining == 0,
+	}
+}
+
+// approvalStatus evaluates the task's current approvals under policy.
+func (s *TaskService) approvalStatus(task *models.Task, policy *models.ApprovalPolicy) (*models.ApprovalStatus, error) {
+	approvals, err := s.approvalRepo.ListTaskApprovals(task.OrgID, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+	return evaluateApprovals(policy, approvals), nil
+}
+
+// attachApprovalStatus fills task.Approval when an approval policy applies.
+func (s *TaskService) attachApprovalStatus(task *models.Task) error {
+	policy, err := s.approvalPolicyFor(task)
+	if err != nil || policy == nil {
+		return err
+	}
+	task.Approval, err = s.approvalStatus(task, policy)
+	return err
+}
+
+// authorizePolicyApproval checks that the user's approval would count: it
+// meets an open requirement (the next one, under a sequential policy), or the
+// policy still needs approvals beyond its requirements and the user may
+// move the task to approved in the usual way.
+func (s *TaskService) authorizePolicyApproval(task *models.Task, policy *models.ApprovalPolicy, status *models.ApprovalStatus, approved string, userID uuid.UUID, role string) error {
+	candidates := status.Pending
+	if policy.Sequential && len(candidates) > 0 {
+		candidates = candidates[:1]
+	}
+	for _, req := range candidates {
+		if meetsRequirement(req, &userID, role) {
+			return nil
+		}
+	}
+	if policy.Sequential && len(status.Pending) > 0 {
+		return fmt.Errorf("insufficient permissions")
+	}
+	if status.Remaining > len(status.Pending) {
+		return s.authorizeStep(task, approved, userID, role)
+	}
+	return fmt.Errorf("insufficient permissions")
+}
+
+// approveUnderPolicy records the user's approval of a task governed by
+// policy, and approves the task once the policy is satisfied. Until then the
+// task keeps its status and the response shows the approvals still pending.
+func (s *TaskService) approveUnderPolicy(task *models.Task, policy *models.ApprovalPolicy, approved string, userID uuid.UUID, role, notes string) (*models.Task, error) {
+	if err := s.workflowSvc.ValidateTransition(task.OrgID, task.Status, approved); err != nil {
+		return nil, err
+	}
+	status, err := s.approvalStatus(task, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// A satisfied policy whose sign-off did not go through earlier only needs
+	// the task moved.
+	if !status.Satisfied {
+		if err := s.authorizePolicyApproval(task, policy, status, approved, userID, role); err != nil {
+			return nil, err
+		}
+
+		approval := &models.TaskApproval{
+			ID:     uuid.New(),
+			TaskID: task.ID,
+			UserID: &userID,
+			Role:   role,
+		}
+		if notes = strings.TrimSpace(notes); notes != "" {
+			approval.Notes = &notes
+		}
+		if err := s.approvalRepo.AddTaskApproval(approval, task.OrgID, task.Status); err != nil {
+			return nil, fmt.Errorf("failed to record approval: %w", err)
+		}
+
+		if status, err = s.approvalStatus(task, policy); err != nil {
+			return nil, err
+		}
+
+		auditLog := &models.AuditLog{
+			ID:         uuid.New(),
+			OrgID:      task.OrgID,
+			UserID:     &userID,
+			Action:     "add_approval",
+			EntityType: "task",
+			EntityID:   &task.ID,
+			Details: map[string]interface{}{
+				"policy_id": policy.ID.String(),
+				"remaining": status.Remaining,
+			},
+		}
+		_ = s.auditLogRepo.Create(auditLog)
+	} else if err := s.authorizeStep(task, approved, userID, role); err != nil {
+		return nil, err
+	}
+
+	if status.Satisfied {
+		fromStatus := task.Status
+		for attempt := 1; ; attempt++ {
+			err := s.signOff(task, approved, userID, notes)
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, models.ErrVersionConflict) || attempt == signOffAttempts {
+				return nil, fmt.Errorf("failed to update task: %w", err)
+			}
+			// Another change landed first; retry unless it moved the task on.
+			if task, err = s.GetTask(task.OrgID, task.ID); err != nil {
+				return nil, err
+			}
+			if task.Status == approved {
+				break
+			}
+			if task.Status != fromStatus {
+				return nil, models.ErrVersionConflict
+			}
+		}
+	}
+
+	task.Approval = status
+	return task, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func approval(userID uuid.UUID, role string) models.TaskApproval {
+	return models.TaskApproval{ID: uuid.New(), UserID: &userID, Role: role}
+}
+
+func TestEvaluateApprovals(t *testing.T) {
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	byRole := func(role string) models.ApprovalRequirement { return models.ApprovalRequirement{Role: role} }
+	byUser := func(id uuid.UUID) models.ApprovalRequirement { return models.ApprovalRequirement{UserID: &id} }
+
+	tests := []struct {
+		name      string
+		policy    models.ApprovalPolicy
+		approvals []models.TaskApproval
+		pending   []models.ApprovalRequirement
+		remaining int
+	}{
+		{
+			name:      "no approvals yet",
+			policy:    models.ApprovalPolicy{MinApprovals: 2, Requirements: []models.ApprovalRequirement{byRole("admin")}},
+			pending:   []models.ApprovalRequirement{byRole("admin")},
+			remaining: 2,
+		},
+		{
+			name:      "minimum count without requirements",
+			policy:    models.ApprovalPolicy{MinApprovals: 2},
+			approvals: []models.TaskApproval{approval(alice, "manager"), approval(bob, "member")},
+			pending:   []models.ApprovalRequirement{},
+		},
+		{
+			name:      "unmatched approval counts toward the minimum only",
+			policy:    models.ApprovalPolicy{MinApprovals: 3, Requirements: []models.ApprovalRequirement{byRole("admin")}},
+			approvals: []models.TaskApproval{approval(alice, "manager"), approval(bob, "manager")},
+			pending:   []models.ApprovalRequirement{byRole("admin")},
+			remaining: 1,
+		},
+		{
+			name:      "requirements raise the minimum",
+			policy:    models.ApprovalPolicy{MinApprovals: 1, Requirements: []models.ApprovalRequirement{byRole("admin"), byRole("manager")}},
+			approvals: []models.TaskApproval{approval(alice, "admin")},
+			pending:   []models.ApprovalRequirement{byRole("manager")},
+			remaining: 1,
+		},
+		{
+			name:      "a named user is matched before a role",
+			policy:    models.ApprovalPolicy{Requirements: []models.ApprovalRequirement{byRole("admin"), byUser(alice)}},
+			approvals: []models.TaskApproval{approval(alice, "admin"), approval(bob, "admin")},
+			pending:   []models.ApprovalRequirement{},
+		},
+		{
+			name:      "one approval meets one requirement",
+			policy:    models.ApprovalPolicy{Requirements: []models.ApprovalRequirement{byRole("admin"), byRole("admin")}},
+			approvals: []models.TaskApproval{approval(alice, "admin")},
+			pending:   []models.ApprovalRequirement{byRole("admin")},
+			remaining: 1,
+		},
+		{
+			name:      "sequential policy in order",
+			policy:    models.ApprovalPolicy{Sequential: true, Requirements: []models.ApprovalRequirement{byRole("manager"), byUser(carol)}},
+			approvals: []models.TaskApproval{approval(alice, "manager"), approval(carol, "admin")},
+			pending:   []models.ApprovalRequirement{},
+		},
+		{
+			name:      "sequential policy out of order",
+			policy:    models.ApprovalPolicy{Sequential: true, Requirements: []models.ApprovalRequirement{byRole("manager"), byUser(carol)}},
+			approvals: []models.TaskApproval{approval(carol, "admin")},
+			pending:   []models.ApprovalRequirement{byRole("manager"), byUser(carol)},
+			remaining: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := evaluateApprovals(&tt.policy, tt.approvals)
+			if !reflect.DeepEqual(status.Pending, tt.pending) {
+				t.Errorf("pending = %+v, want %+v", status.Pending, tt.pending)
+			}
+			if status.Remaining != tt.remaining || status.Satisfied != (tt.remaining == 0) {
+				t.Errorf("remaining = %d, satisfied = %v, want %d", status.Remaining, status.Satisfied, tt.remaining)
+			}
+			if len(status.Received) != len(tt.approvals) {
+				t.Errorf("received %d approvals, want %d", len(status.Received), len(tt.approvals))
+			}
+		})
+	}
+}
+
+func TestMeetsRequirement(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	if !meetsRequirement(models.ApprovalRequirement{Role: "admin"}, &alice, "admin") {
+		t.Error("role requirement should accept a user with the role")
+	}
+	if meetsRequirement(models.ApprovalRequirement{UserID: &alice}, &bob, "admin") {
+		t.Error("user requirement should reject other users whatever their role")
+	}
+	if meetsRequirement(models.ApprovalRequirement{UserID: &alice}, nil, "admin") {
+		t.Error("user requirement should reject approvals from deleted users")
+	}
+}
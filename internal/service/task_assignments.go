@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func hasPerson(people []models.TaskPerson, userID uuid.UUID) bool {
+	for _, p := range people {
+		if p.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// isTaskAssignee reports whether the user is any of the task's assignees.
+func isTaskAssignee(task *models.Task, userID uuid.UUID) bool {
+	return (task.AssignedTo != nil && *task.AssignedTo == userID) || hasPerson(task.Assignees, userID)
+}
+
+// canSeeTask reports whether a member can see the task: they are assigned to
+// it or designated to review or approve it.
+func canSeeTask(task *models.Task, userID uuid.UUID) bool {
+	return isTaskAssignee(task, userID) || hasPerson(task.Reviewers, userID) || hasPerson(task.Approvers, userID)
+}
+
+// resolveTaskPeople parses a list of user IDs for the named field, checking
+// each belongs to the organization and dropping duplicates.
+func (s *TaskService) resolveTaskPeople(orgID uuid.UUID, field string, raw []string) ([]models.TaskPerson, error) {
+	people := make([]models.TaskPerson, 0, len(raw))
+	for _, r := range raw {
+		userID, err := s.projectSvc.getOrgUser(orgID, r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		if !hasPerson(people, userID) {
+			people = append(people, models.TaskPerson{UserID: userID})
+		}
+	}
+	return people, nil
+}
+
+// setAssignees replaces the task's assignees; the first becomes the primary.
+func setAssignees(task *models.Task, people []models.TaskPerson) {
+	task.Assignees = people
+	task.AssignedTo = nil
+	if len(people) > 0 {
+		primary := people[0].UserID
+		task.AssignedTo = &primary
+	}
+}
+
+// setPrimaryAssignee swaps the task's primary assignee for userID, keeping
+// any other assignees. A nil userID removes the primary and promotes the next
+// assignee in its place.
+func setPrimaryAssignee(task *models.Task, userID *uuid.UUID) {
+	people := []models.TaskPerson{}
+	if userID != nil {
+		people = append(people, models.TaskPerson{UserID: *userID})
+	}
+	for _, p := range task.Assignees {
+		if task.AssignedTo != nil && p.UserID == *task.AssignedTo {
+			continue
+		}
+		if userID == nil || p.UserID != *userID {
+			people = append(people, p)
+		}
+	}
+	setAssignees(task, people)
+}
+
+// addedPeople returns the users in after that are not in before.
+func addedPeople(before, after []models.TaskPerson) []*uuid.UUID {
+	added := []*uuid.UUID{}
+	for _, p := range after {
+		if !hasPerson(before, p.UserID) {
+			id := p.UserID
+			added = append(added, &id)
+		}
+	}
+	return added
+}
+
+// taskPeople lists everyone assigned to the task in any capacity.
+func taskPeople(task *models.Task) []models.TaskPerson {
+	people := append([]models.TaskPerson{}, task.Assignees...)
+	people = append(people, task.Reviewers...)
+	return append(people, task.Approvers...)
+}
+
+// designatedFor returns the users the task designates for a review step: its
+// reviewers for verify and its approvers for approve.
+func designatedFor(task *models.Task, step string) []models.TaskPerson {
+	switch step {
+	case models.ReviewVerify:
+		return task.Reviewers
+	case models.ReviewApprove:
+		return task.Approvers
+	}
+	return nil
+}
+
+// pendingStep returns the review step a task in status is waiting on: verify
+// once submitted (or approve when the workflow has no verify step), and
+// approve once verified.
+func pendingStep(wf *models.Workflow, status string) string {
+	switch reviewStepOf(wf, status) {
+	case models.ReviewSubmit:
+		if reviewStatus(wf, models.ReviewVerify) != "" {
+			return models.ReviewVerify
+		}
+		return models.ReviewApprove
+	case models.ReviewVerify:
+		return models.ReviewApprove
+	}
+	return ""
+}
+
+// authorizeRejection lets the users designated for the step a task is waiting
+// on (its reviewers once submitted, its approvers once verified) send it back
+// to to, in addition to anyone the workflow allows.
+func (s *TaskService) authorizeRejection(wf *models.Workflow, task *models.Task, to string, userID uuid.UUID, role string) error {
+	if hasPerson(designatedFor(task, pendingStep(wf, task.Status)), userID) {
+		return s.workflowSvc.ValidateTransition(task.OrgID, task.Status, to)
+	}
+	return s.authorizeTransition(task, to, userID, role)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// MoveTaskForRole moves a task on the board: an optional status transition
+// and a new position between two neighbors, applied together. Entering a
+// column with a WIP limit fails once the column is full.
+func (s *TaskService) MoveTaskForRole(orgID, taskID, userID uuid.UUID, role string, req *models.MoveTaskRequest) (*models.Task, error) {
+	task, err := s.GetTaskForRole(orgID, taskID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	move := repository.TaskMove{
+		FromStatus: task.Status,
+		ToStatus:   task.Status,
+	}
+	if req.Status != "" && req.Status != task.Status {
+		if err := s.authorizeStatusUpdate(task, req.Status, userID, role); err != nil {
+			return nil, err
+		}
+		move.ToStatus = req.Status
+		if move.WIPLimit, err = s.wipLimit(orgID, req.Status); err != nil {
+			return nil, err
+		}
+	}
+	if move.AfterID, err = parseNeighbor(req.AfterID, "after_id"); err != nil {
+		return nil, err
+	}
+	if move.BeforeID, err = parseNeighbor(req.BeforeID, "before_id"); err != nil {
+		return nil, err
+	}
+
+	wf, err := s.workflowSvc.GetWorkflow(orgID)
+	if err != nil {
+		return nil, err
+	}
+	moved := *task
+	moved.Status = move.ToStatus
+	applyReviewStamps(wf, &moved, userID, move.FromStatus)
+	move.Stamps = &moved
+	move.History = newHistoryEntry(&userID, s.diffTasks(task, &moved))
+	if action := reviewActionFor(wf, move.FromStatus, move.ToStatus); action != "" {
+		move.Review = newTaskReview(&moved, userID, action, move.FromStatus, "")
+	}
+
+	if err := s.taskRepo.Move(orgID, taskID, move); err != nil {
+		return nil, err
+	}
+
+	if move.ToStatus != move.FromStatus {
+		s.syncBlockedStatus(orgID, taskID)
+		s.syncDependents(orgID, taskID)
+	}
+
+	task, err = s.GetTask(orgID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "move",
+		EntityType: "task",
+		EntityID:   &task.ID,
+		Details: map[string]interface{}{
+			"from_status": move.FromStatus,
+			"to_status":   move.ToStatus,
+			"board_rank":  task.BoardRank,
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return task, nil
+}
+
+func parseNeighbor(raw *string, field string) (*uuid.UUID, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s UUID: %w", field, err)
+	}
+	return &id, nil
+}
+
+func (s *TaskService) wipLimit(orgID uuid.UUID, status string) (*int, error) {
+	wf, err := s.workflowSvc.GetWorkflow(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range wf.Statuses {
+		if st.Key == status {
+			return st.WIPLimit, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetBoardForRole returns the visible tasks grouped into one column per
+// workflow status, in workflow order, each sorted by board rank. Tasks in a
+// status the workflow no longer defines get a trailing column of their own.
+func (s *TaskService) GetBoardForRole(orgID, userID uuid.UUID, role string, filter models.TaskListFilter) ([]models.BoardColumn, error) {
+	wf, err := s.workflowSvc.GetWorkflow(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.SortByRank = true
+	tasks, err := s.ListTasksForRole(orgID, userID, role, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]models.BoardColumn, 0, len(wf.Statuses))
+	index := make(map[string]int, len(wf.Statuses))
+	for _, st := range wf.Statuses {
+		index[st.Key] = len(columns)
+		columns = append(columns, models.BoardColumn{
+			Status:   st.Key,
+			Name:     st.Name,
+			WIPLimit: st.WIPLimit,
+			Tasks:    []models.Task{},
+		})
+	}
+	for _, t := range tasks {
+		i, ok := index[t.Status]
+		if !ok {
+			i = len(columns)
+			index[t.Status] = i
+			columns = append(columns, models.BoardColumn{Status: t.Status, Name: t.Status, Tasks: []models.Task{}})
+		}
+		columns[i].Tasks = append(columns[i].Tasks, t)
+		columns[i].Count++
+	}
+	return columns, nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// parseBulkIDs parses and de-duplicates the IDs of a bulk request.
+func parseBulkIDs(raw []string) ([]uuid.UUID, error) {
+	if len(raw) > models.MaxBulkItems {
+		return nil, fmt.Errorf("at most %d items can be changed at once", models.MaxBulkItems)
+	}
+	ids := make([]uuid.UUID, 0, len(raw))
+	seen := make(map[uuid.UUID]bool, len(raw))
+	for _, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UUID %q", r)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func parseOptionalUUID(raw *string, field string) (*uuid.UUID, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s UUID: %w", field, err)
+	}
+	return &id, nil
+}
+
+// bulkAssignee resolves the target of an assign action to a user of the
+// organization; an empty string unassigns. It is resolved once, so an unknown
+// user fails the whole request rather than every item.
+func bulkAssignee(projects *ProjectService, orgID uuid.UUID, raw *string) (*uuid.UUID, error) {
+	if raw == nil {
+		return nil, fmt.Errorf("assigned_to is required")
+	}
+	if *raw == "" {
+		return nil, nil
+	}
+	id, err := projects.getOrgUser(orgID, *raw)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// bulkRecorder collects per-item outcomes for a bulk operation.
+type bulkRecorder struct {
+	result    *models.BulkResult
+	succeeded []uuid.UUID
+}
+
+func newBulkRecorder(action string, requested int) *bulkRecorder {
+	return &bulkRecorder{
+		result: &models.BulkResult{
+			Action:    action,
+			Requested: requested,
+			Results:   make([]models.BulkItemResult, 0, requested),
+		},
+		succeeded: make([]uuid.UUID, 0, requested),
+	}
+}
+
+func (r *bulkRecorder) fail(id uuid.UUID, err error) {
+	r.result.Results = append(r.result.Results, models.BulkItemResult{ID: id, Error: err.Error()})
+	r.result.Failed++
+}
+
+func (r *bulkRecorder) ok(id uuid.UUID) {
+	r.result.Results = append(r.result.Results, models.BulkItemResult{ID: id, OK: true})
+	r.result.Succeeded++
+	r.succeeded = append(r.succeeded, id)
+}
+
+// bulkTaskTargets resolves the tasks a bulk request names, either directly or
+// through a filter evaluated with the caller's visibility rules.
+func (s *TaskService) bulkTaskTargets(orgID, userID uuid.UUID, role string, req *models.BulkTaskRequest) ([]uuid.UUID, error) {
+	if (len(req.IDs) > 0) == (req.Filter != nil) {
+		return nil, fmt.Errorf("provide either ids or filter")
+	}
+	if req.Filter == nil {
+		return parseBulkIDs(req.IDs)
+	}
+
+	f := req.Filter
+	filter := models.TaskListFilter{
+		Status:   f.Status,
+		Priority: f.Priority,
+		Labels:   models.LabelFilter{Names: f.Labels},
+		Query:    f.Q,
+	}
+	var err error
+	if filter.AssigneeID, err = parseOptionalUUID(f.AssignedTo, "assigned_to"); err != nil {
+		return nil, err
+	}
+	if filter.ProjectID, err = parseOptionalUUID(f.ProjectID, "project_id"); err != nil {
+		return nil, err
+	}
+	if filter.SprintID, err = parseOptionalUUID(f.SprintID, "sprint_id"); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ListTasksForRole(orgID, userID, role, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) > models.MaxBulkItems {
+		return nil, fmt.Errorf("filter matches %d tasks; at most %d can be changed at once", len(tasks), models.MaxBulkItems)
+	}
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids, nil
+}
+
+// BulkUpdateTasksForRole applies one action to many tasks. Every task goes
+// through the same permission checks as UpdateTaskForRole and
+// DeleteTaskForRole; tasks that fail are reported and skipped, and the rest
+// are written in a single transaction.
+func (s *TaskService) BulkUpdateTasksForRole(orgID, userID uuid.UUID, role string, req *models.BulkTaskRequest) (*models.BulkResult, error) {
+	ids, err := s.bulkTaskTargets(orgID, userID, role, req)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"action": req.Action}
+	write := repository.BulkTaskWrite{DeletedBy: userID, History: map[uuid.UUID]*models.HistoryEntry{}}
+	var assignee *uuid.UUID
+	var wf *models.Workflow
+	switch req.Action {
+	case models.BulkAssign:
+		if assignee, err = bulkAssignee(s.projectSvc, orgID, req.AssignedTo); err != nil {
+			return nil, err
+		}
+		details["assigned_to"] = *req.AssignedTo
+	case models.BulkStatus:
+		if req.Status == "" {
+			return nil, fmt.Errorf("status is required")
+		}
+		if wf, err = s.workflowSvc.GetWorkflow(orgID); err != nil {
+			return nil, err
+		}
+		details["status"] = req.Status
+	case models.BulkPriority:
+		if req.Priority == "" {
+			return nil, fmt.Errorf("priority is required")
+		}
+		details["priority"] = req.Priority
+	case models.BulkAddLabel, models.BulkRemoveLabel:
+		labelID, err := uuid.Parse(req.LabelID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label_id UUID: %w", err)
+		}
+		if req.Action == models.BulkAddLabel {
+			write.AddLabelID = &labelID
+		} else {
+			write.RemoveLabelID = &labelID
+		}
+		details["label_id"] = labelID.String()
+	case models.BulkDelete:
+	default:
+		return nil, fmt.Errorf("invalid action: %s", req.Action)
+	}
+
+	rec := newBulkRecorder(req.Action, len(ids))
+	statusChanged := []uuid.UUID{}
+	dependents := []uuid.UUID{}
+	for _, id := range ids {
+		task, err := s.GetTaskForRole(orgID, id, userID, role)
+		if err != nil {
+			rec.fail(id, err)
+			continue
+		}
+		// Only status changes are open to members (on their own tasks);
+		// everything else needs a manager or admin.
+		if req.Action != models.BulkStatus && role != "admin" && role != "manager" {
+			rec.fail(id, fmt.Errorf("insufficient permissions"))
+			continue
+		}
+		before := *task
+
+		switch req.Action {
+		case models.BulkAssign:
+			setPrimaryAssignee(task, assignee)
+			write.Updates = append(write.Updates, task)
+			write.History[id] = newHistoryEntry(&userID, s.diffTasks(&before, task))
+		case models.BulkStatus:
+			if task.Status == req.Status {
+				break
+			}
+			if err := s.authorizeStatusUpdate(task, req.Status, userID, role); err != nil {
+				rec.fail(id, err)
+				continue
+			}
+			previousStatus := task.Status
+			task.Status = req.Status
+			applyReviewStamps(wf, task, userID, previousStatus)
+			write.Updates = append(write.Updates, task)
+			write.History[id] = newHistoryEntry(&userID, s.diffTasks(&before, task))
+			if action := reviewActionFor(wf, previousStatus, task.Status); action != "" {
+				write.Reviews = append(write.Reviews, newTaskReview(task, userID, action, previousStatus, ""))
+			}
+			statusChanged = append(statusChanged, id)
+		case models.BulkPriority:
+			task.Priority = req.Priority
+			write.Updates = append(write.Updates, task)
+			write.History[id] = newHistoryEntry(&userID, s.diffTasks(&before, task))
+		case models.BulkAddLabel, models.BulkRemoveLabel:
+			write.LabelTaskIDs = append(write.LabelTaskIDs, id)
+		case models.BulkDelete:
+			deps, err := s.depRepo.ListDependents(orgID, id)
+			if err != nil {
+				rec.fail(id, fmt.Errorf("failed to list dependents: %w", err))
+				continue
+			}
+			for _, d := range deps {
+				dependents = append(dependents, d.ID)
+			}
+			write.DeleteIDs = append(write.DeleteIDs, id)
+		}
+		rec.ok(id)
+	}
+
+	if err := s.taskRepo.ApplyBulk(orgID, write); err != nil {
+		return nil, fmt.Errorf("bulk update failed: %w", err)
+	}
+
+	// Follow-up work happens after commit, as it does for single updates.
+	for _, id := range statusChanged {
+		s.syncBlockedStatus(orgID, id)
+		s.syncDependents(orgID, id)
+	}
+	if req.Action == models.BulkDelete {
+		deleted := make(map[uuid.UUID]bool, len(write.DeleteIDs))
+		for _, id := range write.DeleteIDs {
+			deleted[id] = true
+			if s.ragIndexer != nil {
+				s.ragIndexer.DeleteTask(context.Background(), orgID, id)
+			}
+		}
+		for _, id := range dependents {
+			if !deleted[id] {
+				s.syncBlockedStatus(orgID, id)
+			}
+		}
+	} else {
+		for _, id := range rec.succeeded {
+			if req.Action == models.BulkAssign {
+				s.AddTaskWatchers(id, assignee)
+			}
+			s.reindexTask(orgID, id)
+		}
+	}
+
+	details["task_ids"] = rec.succeeded
+	details["succeeded"] = rec.result.Succeeded
+	details["failed"] = rec.result.Failed
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "bulk_" + req.Action,
+		EntityType: "task",
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return rec.result, nil
+}
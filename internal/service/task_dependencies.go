@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// GetTaskDetailForRole returns a task together with its blockers and
+// dependents, and its approval progress when an approval policy applies.
+func (s *TaskService) GetTaskDetailForRole(orgID, taskID, userID uuid.UUID, role string) (*models.Task, error) {
+	task, err := s.GetTaskForRole(orgID, taskID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Blockers, err = s.depRepo.ListBlockers(orgID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blockers: %w", err)
+	}
+	task.Dependents, err = s.depRepo.ListDependents(orgID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependents: %w", err)
+	}
+	if err := s.attachApprovalStatus(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *TaskService) ListDependenciesForRole(orgID, taskID, userID uuid.UUID, role string) (*models.TaskDependenciesResponse, error) {
+	task, err := s.GetTaskDetailForRole(orgID, taskID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TaskDependenciesResponse{
+		Blockers:   task.Blockers,
+		Dependents: task.Dependents,
+	}, nil
+}
+
+// AddDependencyForRole makes taskID wait for the task in req.DependsOnID.
+func (s *TaskService) AddDependencyForRole(orgID, taskID, userID uuid.UUID, role string, req *models.AddTaskDependencyRequest) (*models.TaskDependenciesResponse, error) {
+	if role != "admin" && role != "manager" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+
+	dependsOnID, err := uuid.Parse(req.DependsOnID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid depends_on_id UUID: %w", err)
+	}
+	if _, err := s.GetTask(orgID, taskID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetTask(orgID, dependsOnID); err != nil {
+		return nil, fmt.Errorf("blocking task not found")
+	}
+
+	if err := s.depRepo.Add(orgID, taskID, dependsOnID, userID); err != nil {
+		return nil, fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "add_dependency",
+		EntityType: "task",
+		EntityID:   &taskID,
+		Details: map[string]interface{}{
+			"depends_on_id": dependsOnID.String(),
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	s.syncBlockedStatus(orgID, taskID)
+
+	return s.ListDependenciesForRole(orgID, taskID, userID, role)
+}
+
+func (s *TaskService) RemoveDependencyForRole(orgID, taskID, dependsOnID, userID uuid.UUID, role string) (*models.TaskDependenciesResponse, error) {
+	if role != "admin" && role != "manager" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+
+	if err := s.depRepo.Remove(orgID, taskID, dependsOnID); err != nil {
+		return nil, fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "remove_dependency",
+		EntityType: "task",
+		EntityID:   &taskID,
+		Details: map[string]interface{}{
+			"depends_on_id": dependsOnID.String(),
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	s.syncBlockedStatus(orgID, taskID)
+
+	return s.ListDependenciesForRole(orgID, taskID, userID, role)
+}
+
+// syncBlockedStatus blocks a task while any predecessor is unapproved and
+// restores it once they all clear. It reports whether the status changed.
+func (s *TaskService) syncBlockedStatus(orgID, taskID uuid.UUID) bool {
+	pending, err := s.depRepo.CountUnapprovedBlockers(orgID, taskID)
+	if err != nil {
+		log.Printf("Warning: failed to count blockers for task %s: %v", taskID, err)
+		return false
+	}
+
+	var from, to string
+	action := "auto_block"
+	if pending > 0 {
+		to = BlockedStatus
+		from, err = s.taskRepo.MarkBlocked(orgID, taskID)
+	} else {
+		action = "auto_unblock"
+		from = BlockedStatus
+		to, err = s.taskRepo.ClearBlocked(orgID, taskID)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to update blocked status for task %s: %v", taskID, err)
+		return false
+	}
+
+	changed := from != "" && to != ""
+	if changed {
+		d := fieldDiff{}
+		d.str("status", from, to)
+		s.recordTaskHistory(orgID, taskID, nil, d)
+		auditLog := &models.AuditLog{
+			ID:         uuid.New(),
+			OrgID:      orgID,
+			Action:     action,
+			EntityType: "task",
+			EntityID:   &taskID,
+			Details: map[string]interface{}{
+				"unapproved_blockers": pending,
+			},
+		}
+		_ = s.auditLogRepo.Create(auditLog)
+	}
+	return changed
+}
+
+// syncDependents re-evaluates every task waiting on taskID, and the issues it
+// fixes, typically after taskID was signed off or moved back.
+func (s *TaskService) syncDependents(orgID, taskID uuid.UUID) {
+	s.resolveFixedIssues(orgID, taskID)
+
+	dependents, err := s.depRepo.ListDependents(orgID, taskID)
+	if err != nil {
+		log.Printf("Warning: failed to list dependents of task %s: %v", taskID, err)
+		return
+	}
+	for _, d := range dependents {
+		s.syncBlockedStatus(orgID, d.ID)
+	}
+}
+
+// resolveFixedIssues resolves the issues taskID fixes once all their fixing
+// tasks are approved, when the deployment opts in.
+func (s *TaskService) resolveFixedIssues(orgID, taskID uuid.UUID) {
+	if s.cfg == nil || !s.cfg.Issues.AutoResolve {
+		return
+	}
+	resolved, err := s.linkRepo.ResolveFixedIssues(orgID, taskID)
+	if err != nil {
+		log.Printf("Warning: failed to resolve issues fixed by task %s: %v", taskID, err)
+		return
+	}
+	for _, ri := range resolved {
+		d := fieldDiff{}
+		d.str("status", ri.FromStatus, "resolved")
+		entry := &models.HistoryEntry{ID: uuid.New(), Changes: d}
+		if err := s.historyRepo.AddIssueEntry(orgID, ri.ID, entry); err != nil {
+			log.Printf("failed to record history for issue %s: %v", ri.ID, err)
+		}
+
+		issueID := ri.ID
+		auditLog := &models.AuditLog{
+			ID:         uuid.New(),
+			OrgID:      orgID,
+			Action:     "auto_resolve",
+			EntityType: "issue",
+			EntityID:   &issueID,
+			Details: map[string]interface{}{
+				"fixed_by_task_id": taskID.String(),
+				"from_status":      ri.FromStatus,
+			},
+		}
+		_ = s.auditLogRepo.Create(auditLog)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// reviewActionFor names the review step a status change represents, or ""
+// when it is not one. Rejections are only recorded by RejectTask, which
+// requires a reason.
+func reviewActionFor(wf *models.Workflow, from, to string) string {
+	if from == to {
+		return ""
+	}
+	return reviewStepOf(wf, to)
+}
+
+// applyReviewStamps records who verified or approved a task when it enters
+// the workflow's verify or approve status, whichever path moved it there, and
+// clears both once it leaves the completed statuses. task must already carry
+// its new status.
+func applyReviewStamps(wf *models.Workflow, task *models.Task, actorID uuid.UUID, fromStatus string) {
+	if task.Status == fromStatus {
+		return
+	}
+	if !isCompletedStatus(wf, task.Status) {
+		task.VerifiedBy, task.VerifiedAt = nil, nil
+		task.ApprovedBy, task.ApprovedAt = nil, nil
+		return
+	}
+	now := time.Now()
+	switch reviewStepOf(wf, task.Status) {
+	case models.ReviewVerify:
+		task.VerifiedBy, task.VerifiedAt = &actorID, &now
+	case models.ReviewApprove:
+		task.ApprovedBy, task.ApprovedAt = &actorID, &now
+	}
+}
+
+// reviewTarget returns the status a review step moves tasks to in the
+// organization's workflow.
+func (s *TaskService) reviewTarget(orgID uuid.UUID, step string) (string, error) {
+	wf, err := s.workflowSvc.GetWorkflow(orgID)
+	if err != nil {
+		return "", err
+	}
+	if to := reviewStatus(wf, step); to != "" {
+		return to, nil
+	}
+	return "", fmt.Errorf("workflow has no %s step", step)
+}
+
+// newTaskReview builds the history entry for a review step on task, which
+// must already carry its new status. The task's current document is
+// recorded with the entry.
+func newTaskReview(task *models.Task, actorID uuid.UUID, action, fromStatus, notes string) *models.TaskReview {
+	review := &models.TaskReview{
+		ID:               uuid.New(),
+		OrgID:            task.OrgID,
+		TaskID:           task.ID,
+		ActorID:          &actorID,
+		Action:           action,
+		FromStatus:       fromStatus,
+		ToStatus:         task.Status,
+		DocumentFilename: task.DocumentFilename,
+		DocumentPath:     task.DocumentPath,
+	}
+	if notes = strings.TrimSpace(notes); notes != "" {
+		review.Notes = &notes
+	}
+	return review
+}
+
+// updateWithReview saves task, recording a review step if its status change
+// from before is one, and the fields the change touched in the task's
+// history. It returns those changes.
+func (s *TaskService) updateWithReview(before, task *models.Task, actorID uuid.UUID, notes string) ([]models.FieldChange, error) {
+	wf, err := s.workflowSvc.GetWorkflow(task.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	applyReviewStamps(wf, task, actorID, before.Status)
+	changes := s.diffTasks(before, task)
+	history := newHistoryEntry(&actorID, changes)
+	if action := reviewActionFor(wf, before.Status, task.Status); action != "" {
+		err = s.taskRepo.UpdateWithReview(task, newTaskReview(task, actorID, action, before.Status, notes), history)
+	} else {
+		err = s.taskRepo.Update(task, history)
+	}
+	return changes, err
+}
+
+// ListReviewsForRole returns a task's review history, oldest first, to users
+// who can see the task.
+func (s *TaskService) ListReviewsForRole(orgID, taskID, userID uuid.UUID, role string) ([]models.TaskReview, error) {
+	if _, err := s.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return nil, err
+	}
+	reviews, err := s.taskRepo.ListReviews(orgID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	return reviews, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"saas-backend/config"
 	"saas-backend/internal/ai"
 	"saas-backend/internal/models"
 	"saas-backend/internal/rag"
@@ -16,29 +17,59 @@ import (
 )
 
 type TaskService struct {
-	taskRepo      *repository.TaskRepository
-	auditLogRepo  *repository.AuditLogRepository
-	geminiService *GeminiService
-	langChainSvc  *ai.LangChainService
-	ragIndexer    *rag.Indexer
+	taskRepo       *repository.TaskRepository
+	depRepo        *repository.TaskDependencyRepository
+	watcherRepo    *repository.WatcherRepository
+	historyRepo    *repository.HistoryRepository
+	approvalRepo   *repository.ApprovalRepository
+	linkRepo       *repository.IssueLinkRepository
+	auditLogRepo   *repository.AuditLogRepository
+	workflowSvc    *WorkflowService
+	projectSvc     *ProjectService
+	customFieldSvc *CustomFieldService
+	geminiService  *GeminiService
+	langChainSvc   *ai.LangChainService
+	ragIndexer     *rag.Indexer
+	cfg            *config.Config
 }
 
-func NewTaskService(taskRepo *repository.TaskRepository, auditLogRepo *repository.AuditLogRepository, geminiService *GeminiService, langChainSvc *ai.LangChainService, ragIndexer *rag.Indexer) *TaskService {
+func NewTaskService(taskRepo *repository.TaskRepository, depRepo *repository.TaskDependencyRepository, watcherRepo *repository.WatcherRepository, historyRepo *repository.HistoryRepository, approvalRepo *repository.ApprovalRepository, linkRepo *repository.IssueLinkRepository, auditLogRepo *repository.AuditLogRepository, workflowSvc *WorkflowService, projectSvc *ProjectService, customFieldSvc *CustomFieldService, geminiService *GeminiService, langChainSvc *ai.LangChainService, ragIndexer *rag.Indexer, cfg *config.Config) *TaskService {
 	return &TaskService{
-		taskRepo:      taskRepo,
-		auditLogRepo:  auditLogRepo,
-		geminiService: geminiService,
-		langChainSvc:  langChainSvc,
-		ragIndexer:    ragIndexer,
+		taskRepo:       taskRepo,
+		depRepo:        depRepo,
+		watcherRepo:    watcherRepo,
+		historyRepo:    historyRepo,
+		approvalRepo:   approvalRepo,
+		linkRepo:       linkRepo,
+		auditLogRepo:   auditLogRepo,
+		workflowSvc:    workflowSvc,
+		projectSvc:     projectSvc,
+		customFieldSvc: customFieldSvc,
+		geminiService:  geminiService,
+		langChainSvc:   langChainSvc,
+		ragIndexer:     ragIndexer,
+		cfg:            cfg,
 	}
 }
 
-func (s *TaskService) GenerateAdminTaskReport(orgID uuid.UUID) (string, error) {
+// GenerateAdminTaskReport summarizes the organization's task workload, or a
+// single project's when projectID is set.
+func (s *TaskService) GenerateAdminTaskReport(orgID uuid.UUID, projectID *uuid.UUID) (string, error) {
 	if s.langChainSvc == nil && s.geminiService == nil {
 		return "", fmt.Errorf("AI service not configured")
 	}
 
-	tasks, err := s.taskRepo.List(orgID, "", "")
+	if projectID != nil {
+		if _, err := s.projectSvc.getProject(orgID, *projectID); err != nil {
+			return "", err
+		}
+	}
+
+	wf, err := s.workflowSvc.GetWorkflow(orgID)
+	if err != nil {
+		return "", err
+	}
+	tasks, err := s.taskRepo.List(orgID, models.TaskListFilter{ProjectID: projectID})
 	if err != nil {
 		return "", fmt.Errorf("failed to list tasks: %w", err)
 	}
@@ -48,13 +79,15 @@ func (s *TaskService) GenerateAdminTaskReport(orgID uuid.UUID) (string, error) {
 	now := time.Now()
 	overdueCount := 0
 	dueSoonCount := 0
+	var timeSpent int64
 
 	for _, t := range tasks {
 		statusCounts[t.Status]++
 		priorityCounts[t.Priority]++
+		timeSpent += t.TimeSpentSeconds
 
 		if t.DueDate != nil {
-			if t.DueDate.Before(now) && t.Status != "approved" {
+			if t.DueDate.Before(now) && !isSignedOff(wf, t.Status) {
 				overdueCount++
 			} else if t.DueDate.After(now) && t.DueDate.Before(now.Add(48*time.Hour)) {
 				dueSoonCount++
@@ -82,15 +115,16 @@ func (s *TaskService) GenerateAdminTaskReport(orgID uuid.UUID) (string, error) {
 		if t.DueDate != nil {
 			due = t.DueDate.Format(time.RFC3339)
 		}
-		lines = append(lines, fmt.Sprintf("%d. [%s/%s] %s (assignee: %s, due: %s)", i+1, t.Status, t.Priority, t.Title, assignee, due))
+		lines = append(lines, fmt.Sprintf("%d. [%s/%s] %s (assignee: %s, due: %s, time spent: %.1fh)", i+1, t.Status, t.Priority, t.Title, assignee, due, float64(t.TimeSpentSeconds)/3600))
 	}
 
 	taskData := fmt.Sprintf(`Counts by status: %v
 Counts by priority: %v
 Overdue (non-approved): %d
 Due within 48h: %d
+Total time logged: %.1fh
 Most recently updated tasks:
-%s`, statusCounts, priorityCounts, overdueCount, dueSoonCount, strings.Join(lines, "\n"))
+%s`, statusCounts, priorityCounts, overdueCount, dueSoonCount, float64(timeSpent)/3600, strings.Join(lines, "\n"))
 
 	var report string
 	if s.langChainSvc != nil {
@@ -135,26 +169,45 @@ Data:
 }
 
 func (s *TaskService) CreateTask(orgID, createdBy uuid.UUID, req *models.CreateTaskRequest) (*models.Task, error) {
+	initialStatus, err := s.workflowSvc.InitialStatus(orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	task := &models.Task{
-		ID:          uuid.New(),
-		OrgID:       orgID,
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      "todo",
-		Priority:    req.Priority,
-		CreatedBy:   createdBy,
+		ID:              uuid.New(),
+		OrgID:           orgID,
+		Title:           req.Title,
+		Description:     req.Description,
+		Status:          initialStatus,
+		Priority:        req.Priority,
+		CreatedBy:       createdBy,
+		RecurringTaskID: req.RecurringTaskID,
+		EstimatePoints:  req.EstimatePoints,
+		Labels:          []models.Label{},
 	}
 
 	if task.Priority == "" {
 		task.Priority = "medium"
 	}
 
+	assigneeIDs := req.AssigneeIDs
 	if req.AssignedTo != nil && *req.AssignedTo != "" {
-		assignedID, err := uuid.Parse(*req.AssignedTo)
-		if err != nil {
+		if _, err := uuid.Parse(*req.AssignedTo); err != nil {
 			return nil, fmt.Errorf("invalid assigned_to UUID: %w", err)
 		}
-		task.AssignedTo = &assignedID
+		assigneeIDs = append([]string{*req.AssignedTo}, assigneeIDs...)
+	}
+	assignees, err := s.resolveTaskPeople(orgID, "assignees", assigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+	setAssignees(task, assignees)
+	if task.Reviewers, err = s.resolveTaskPeople(orgID, "reviewer_ids", req.ReviewerIDs); err != nil {
+		return nil, err
+	}
+	if task.Approvers, err = s.resolveTaskPeople(orgID, "approver_ids", req.ApproverIDs); err != nil {
+		return nil, err
 	}
 
 	if req.DueDate != nil && *req.DueDate != "" {
@@ -165,14 +218,59 @@ func (s *TaskService) CreateTask(orgID, createdBy uuid.UUID, req *models.CreateT
 		task.DueDate = &dueDate
 	}
 
-	if err := s.taskRepo.Create(task); err != nil {
+	if task.ProjectID, err = s.projectSvc.ResolveProject(orgID, req.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if task.CustomFields, err = s.customFieldSvc.ApplyValues(orgID, "task", nil, req.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil && *req.ParentID != "" {
+		parentID, err := uuid.Parse(*req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent_id UUID: %w", err)
+		}
+		parent, err := s.taskRepo.GetByID(orgID, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent task: %w", err)
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("parent task not found")
+		}
+		task.ParentID = &parentID
+		// Subtasks live in their parent's project unless one is given explicitly.
+		if task.ProjectID == nil {
+			task.ProjectID = parent.ProjectID
+		}
+	}
+
+	labelIDs := make([]uuid.UUID, 0, len(req.LabelIDs))
+	seenLabels := make(map[uuid.UUID]bool, len(req.LabelIDs))
+	for _, raw := range req.LabelIDs {
+		labelID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label_ids UUID: %w", err)
+		}
+		if !seenLabels[labelID] {
+			seenLabels[labelID] = true
+			labelIDs = append(labelIDs, labelID)
+		}
+	}
+
+	created := "Task created"
+	if req.CreatedFrom != "" {
+		created += " from " + req.CreatedFrom
+	}
+	history := newHistoryEntry(&createdBy, []models.FieldChange{{Field: "created", Text: created}})
+	if err := s.taskRepo.Create(task, labelIDs, history); err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
 	// Index task for RAG
-	if s.ragIndexer != nil {
-		s.ragIndexer.IndexTask(context.Background(), task.OrgID, task.ID, task.Title, task.Description)
-	}
+	s.indexTask(task)
+
+	s.AddTaskWatchers(task.ID, append([]*uuid.UUID{&createdBy}, addedPeople(nil, taskPeople(task))...)...)
 
 	// Create audit log
 	auditLog := &models.AuditLog{
@@ -186,6 +284,15 @@ func (s *TaskService) CreateTask(orgID, createdBy uuid.UUID, req *models.CreateT
 			"title": task.Title,
 		},
 	}
+	if task.ParentID != nil {
+		auditLog.Details["parent_id"] = task.ParentID.String()
+	}
+	if task.RecurringTaskID != nil {
+		auditLog.Details["recurring_task_id"] = task.RecurringTaskID.String()
+	}
+	if task.ProjectID != nil {
+		auditLog.Details["project_id"] = task.ProjectID.String()
+	}
 	_ = s.auditLogRepo.Create(auditLog)
 
 	return task, nil
@@ -215,51 +322,110 @@ func (s *TaskService) GetTaskForRole(orgID, taskID, userID uuid.UUID, role strin
 		return nil, err
 	}
 
-	if role == "member" {
-		if task.AssignedTo == nil || *task.AssignedTo != userID {
-			return nil, fmt.Errorf("insufficient permissions")
-		}
+	if role == "member" && !canSeeTask(task, userID) {
+		return nil, fmt.Errorf("insufficient permissions")
 	}
 
 	return task, nil
 }
 
-func (s *TaskService) ListTasks(orgID uuid.UUID, status string, priority string) ([]models.Task, error) {
-	tasks, err := s.taskRepo.List(orgID, status, priority)
+func (s *TaskService) ListTasks(orgID uuid.UUID, filter models.TaskListFilter) ([]models.Task, error) {
+	tasks, err := s.taskRepo.List(orgID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
 	return tasks, nil
 }
 
-func (s *TaskService) ListTasksForRole(orgID, userID uuid.UUID, role string, status string, priority string) ([]models.Task, error) {
-	if role != "member" {
-		return s.ListTasks(orgID, status, priority)
+func (s *TaskService) ListTasksForRole(orgID, userID uuid.UUID, role string, filter models.TaskListFilter) ([]models.Task, error) {
+	if err := s.scopeTaskFilter(orgID, userID, role, &filter); err != nil {
+		return nil, err
 	}
+	return s.ListTasks(orgID, filter)
+}
 
-	assigned, err := s.taskRepo.ListByAssignee(orgID, userID)
+// ListTasksPageForRole is the paginated form of ListTasksForRole. It returns
+// the cursor for the next page, which is empty on the last one.
+func (s *TaskService) ListTasksPageForRole(orgID, userID uuid.UUID, role string, filter models.TaskListFilter, page models.PageRequest) ([]models.Task, string, error) {
+	if err := s.scopeTaskFilter(orgID, userID, role, &filter); err != nil {
+		return nil, "", err
+	}
+	tasks, next, err := s.taskRepo.ListPage(orgID, filter, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list tasks: %w", err)
+		return nil, "", fmt.Errorf("failed to list tasks: %w", err)
 	}
+	return tasks, next, nil
+}
 
-	filtered := make([]models.Task, 0, len(assigned))
-	for _, t := range assigned {
-		if status != "" {
-			if status == "completed" {
-				if t.Status != "done" && t.Status != "verified" && t.Status != "approved" {
-					continue
-				}
-			} else if t.Status != status {
-				continue
-			}
+// scopeTaskFilter authorizes a listing's project scope and applies member
+// visibility.
+func (s *TaskService) scopeTaskFilter(orgID, userID uuid.UUID, role string, filter *models.TaskListFilter) error {
+	if filter.ProjectID != nil {
+		if err := s.projectSvc.AuthorizeScope(orgID, *filter.ProjectID, userID, role); err != nil {
+			return err
 		}
-		if priority != "" && t.Priority != priority {
+	}
+	var err error
+	if filter.CustomFields, err = s.customFieldSvc.BuildFilter(orgID, "task", filter.CustomFields); err != nil {
+		return err
+	}
+	filter.Viewer = &userID
+	if role == "member" {
+		// Members only see tasks they are assigned to or designated to
+		// review or approve.
+		filter.VisibleTo = &userID
+	}
+	return nil
+}
+
+// CreateSubtaskForRole creates a task under an existing parent task.
+func (s *TaskService) CreateSubtaskForRole(orgID, parentID, createdBy uuid.UUID, role string, req *models.CreateTaskRequest) (*models.Task, error) {
+	parentIDStr := parentID.String()
+	req.ParentID = &parentIDStr
+	return s.CreateTaskForRole(orgID, createdBy, role, req)
+}
+
+// ListSubtasksForRole lists the direct children of a task the user can see,
+// applying the same visibility rules as ListTasksForRole.
+func (s *TaskService) ListSubtasksForRole(orgID, parentID, userID uuid.UUID, role string) ([]models.Task, error) {
+	if _, err := s.GetTaskForRole(orgID, parentID, userID, role); err != nil {
+		return nil, err
+	}
+	return s.ListTasksForRole(orgID, userID, role, models.TaskListFilter{ParentID: &parentID})
+}
+
+// BuildTaskTree nests tasks under their parents. Tasks whose parent is not part
+// of the input are returned as roots, so filtered lists still render sensibly.
+func BuildTaskTree(tasks []models.Task) []models.Task {
+	present := make(map[uuid.UUID]bool, len(tasks))
+	for _, t := range tasks {
+		present[t.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]int, len(tasks))
+	roots := make([]int, 0, len(tasks))
+	for i, t := range tasks {
+		if t.ParentID != nil && present[*t.ParentID] && *t.ParentID != t.ID {
+			children[*t.ParentID] = append(children[*t.ParentID], i)
 			continue
 		}
-		filtered = append(filtered, t)
+		roots = append(roots, i)
+	}
+
+	var build func(idx int) models.Task
+	build = func(idx int) models.Task {
+		t := tasks[idx]
+		for _, childIdx := range children[t.ID] {
+			t.Subtasks = append(t.Subtasks, build(childIdx))
+		}
+		return t
 	}
 
-	return filtered, nil
+	tree := make([]models.Task, 0, len(roots))
+	for _, idx := range roots {
+		tree = append(tree, build(idx))
+	}
+	return tree
 }
 
 func (s *TaskService) ListMyTasks(orgID, userID uuid.UUID) ([]models.Task, error) {
@@ -278,6 +444,12 @@ func (s *TaskService) UpdateTask(orgID, taskID, userID uuid.UUID, req *models.Up
 	if task == nil {
 		return nil, fmt.Errorf("task not found")
 	}
+	if req.IfMatch != nil && *req.IfMatch != task.Version {
+		return nil, models.ErrVersionConflict
+	}
+
+	before := *task
+	previousStatus := task.Status
 
 	// Update fields if provided
 	if req.Title != nil {
@@ -292,15 +464,32 @@ func (s *TaskService) UpdateTask(orgID, taskID, userID uuid.UUID, req *models.Up
 	if req.Priority != nil {
 		task.Priority = *req.Priority
 	}
+	if req.AssigneeIDs != nil {
+		assignees, err := s.resolveTaskPeople(orgID, "assignee_ids", *req.AssigneeIDs)
+		if err != nil {
+			return nil, err
+		}
+		setAssignees(task, assignees)
+	}
 	if req.AssignedTo != nil {
 		if *req.AssignedTo == "" {
-			task.AssignedTo = nil
+			setPrimaryAssignee(task, nil)
 		} else {
-			assignedID, err := uuid.Parse(*req.AssignedTo)
+			assignedID, err := s.projectSvc.getOrgUser(orgID, *req.AssignedTo)
 			if err != nil {
-				return nil, fmt.Errorf("invalid assigned_to UUID: %w", err)
+				return nil, fmt.Errorf("assigned_to: %w", err)
 			}
-			task.AssignedTo = &assignedID
+			setPrimaryAssignee(task, &assignedID)
+		}
+	}
+	if req.ReviewerIDs != nil {
+		if task.Reviewers, err = s.resolveTaskPeople(orgID, "reviewer_ids", *req.ReviewerIDs); err != nil {
+			return nil, err
+		}
+	}
+	if req.ApproverIDs != nil {
+		if task.Approvers, err = s.resolveTaskPeople(orgID, "approver_ids", *req.ApproverIDs); err != nil {
+			return nil, err
 		}
 	}
 	if req.DueDate != nil {
@@ -314,16 +503,46 @@ func (s *TaskService) UpdateTask(orgID, taskID, userID uuid.UUID, req *models.Up
 			task.DueDate = &dueDate
 		}
 	}
+	if req.ProjectID != nil {
+		if task.ProjectID, err = s.projectSvc.ResolveProject(orgID, req.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	if req.EstimatePoints != nil {
+		task.EstimatePoints = req.EstimatePoints
+	}
+	if req.CustomFields != nil {
+		if task.CustomFields, err = s.customFieldSvc.ApplyValues(orgID, "task", task.CustomFields, req.CustomFields); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := s.taskRepo.Update(task); err != nil {
+	changes, err := s.updateWithReview(&before, task, userID, "")
+	if err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
+	// Reload so the response carries resolved names, such as the new
+	// assignee's.
+	if refreshed, err := s.taskRepo.GetByID(orgID, taskID); err == nil && refreshed != nil {
+		task = refreshed
+	}
 
-	// Re-index task for RAG
-	if s.ragIndexer != nil {
-		s.ragIndexer.IndexTask(context.Background(), task.OrgID, task.ID, task.Title, task.Description)
+	if task.Status != previousStatus {
+		if s.syncBlockedStatus(orgID, taskID) {
+			if refreshed, err := s.taskRepo.GetByID(orgID, taskID); err == nil && refreshed != nil {
+				task = refreshed
+			}
+		}
+		s.syncDependents(orgID, taskID)
 	}
 
+	if added := addedPeople(taskPeople(&before), taskPeople(task)); len(added) > 0 {
+		s.AddTaskWatchers(taskID, added...)
+	}
+
+	// Re-index task for RAG
+	s.indexTask(task)
+
 	// Create audit log
 	auditLog := &models.AuditLog{
 		ID:         uuid.New(),
@@ -332,6 +551,7 @@ func (s *TaskService) UpdateTask(orgID, taskID, userID uuid.UUID, req *models.Up
 		Action:     "update",
 		EntityType: "task",
 		EntityID:   &task.ID,
+		Details:    map[string]interface{}{"changes": changes},
 	}
 	_ = s.auditLogRepo.Create(auditLog)
 
@@ -344,12 +564,19 @@ func (s *TaskService) UpdateTaskForRole(orgID, taskID, userID uuid.UUID, role st
 		return nil, err
 	}
 
+	if req.Status != nil && *req.Status != task.Status {
+		if err := s.authorizeStatusUpdate(task, *req.Status, userID, role); err != nil {
+			return nil, err
+		}
+	}
+
 	if role == "member" {
-		if task.AssignedTo == nil || *task.AssignedTo != userID {
+		if !isTaskAssignee(task, userID) {
 			return nil, fmt.Errorf("insufficient permissions")
 		}
 		// Members can only update status and description (used as comments/notes).
-		if req.Title != nil || req.Priority != nil || req.AssignedTo != nil || req.DueDate != nil {
+		if req.Title != nil || req.Priority != nil || req.AssignedTo != nil || req.AssigneeIDs != nil || req.ReviewerIDs != nil || req.ApproverIDs != nil ||
+			req.DueDate != nil || req.ProjectID != nil || req.EstimatePoints != nil || req.CustomFields != nil {
 			return nil, fmt.Errorf("insufficient permissions")
 		}
 		return s.UpdateTask(orgID, taskID, userID, req)
@@ -362,11 +589,23 @@ func (s *TaskService) UpdateTaskForRole(orgID, taskID, userID uuid.UUID, role st
 	return s.UpdateTask(orgID, taskID, userID, req)
 }
 
+// DeleteTask moves a task to the trash. Its dependency edges are kept for a
+// restore, but a deleted task no longer blocks anything.
 func (s *TaskService) DeleteTask(orgID, taskID, userID uuid.UUID) error {
-	if err := s.taskRepo.Delete(orgID, taskID); err != nil {
+	// Capture dependents while the task is still visible to the lookup.
+	dependents, err := s.depRepo.ListDependents(orgID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to list dependents: %w", err)
+	}
+
+	if err := s.taskRepo.SoftDelete(orgID, taskID, userID); err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
+	for _, d := range dependents {
+		s.syncBlockedStatus(orgID, d.ID)
+	}
+
 	// Create audit log
 	auditLog := &models.AuditLog{
 		ID:         uuid.New(),
@@ -386,6 +625,20 @@ func (s *TaskService) DeleteTask(orgID, taskID, userID uuid.UUID) error {
 	return nil
 }
 
+// discardTasks undoes the creation of tasks nobody has seen yet: they are
+// deleted for good rather than trashed, and dropped from the RAG index.
+func (s *TaskService) discardTasks(orgID uuid.UUID, taskIDs []uuid.UUID) error {
+	if err := s.taskRepo.Discard(orgID, taskIDs); err != nil {
+		return err
+	}
+	if s.ragIndexer != nil {
+		for _, id := range taskIDs {
+			s.ragIndexer.DeleteTask(context.Background(), orgID, id)
+		}
+	}
+	return nil
+}
+
 func (s *TaskService) DeleteTaskForRole(orgID, taskID, userID uuid.UUID, role string) error {
 	if role != "admin" && role != "manager" {
 		return fmt.Errorf("insufficient permissions")
@@ -393,8 +646,134 @@ func (s *TaskService) DeleteTaskForRole(orgID, taskID, userID uuid.UUID, role st
 	return s.DeleteTask(orgID, taskID, userID)
 }
 
+// RestoreTask takes a task out of the trash, re-applies its dependencies in
+// both directions and re-indexes it for RAG.
+func (s *TaskService) RestoreTask(orgID, taskID, userID uuid.UUID) (*models.Task, error) {
+	if err := s.taskRepo.Restore(orgID, taskID); err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	s.syncBlockedStatus(orgID, taskID)
+	s.syncDependents(orgID, taskID)
+
+	task, err := s.GetTask(orgID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	s.indexTask(task)
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "restore",
+		EntityType: "task",
+		EntityID:   &taskID,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return task, nil
+}
+
+func (s *TaskService) indexTask(task *models.Task) {
+	if s.ragIndexer != nil {
+		fields := s.customFieldSvc.DisplayLines(task.OrgID, "task", task.CustomFields)
+		s.ragIndexer.IndexTask(context.Background(), task.OrgID, task.ID, task.Title, task.Description, labelNames(task.Labels), fields)
+	}
+}
+
+// reindexTask refreshes a task's RAG entry after related data, such as its
+// labels, changed.
+func (s *TaskService) reindexTask(orgID, taskID uuid.UUID) {
+	if s.ragIndexer == nil {
+		return
+	}
+	task, err := s.taskRepo.GetByID(orgID, taskID)
+	if err != nil || task == nil {
+		return
+	}
+	s.indexTask(task)
+}
+
+// authorizeTransition is the gate every user-driven status change goes
+// through. It checks the change against the organization's workflow and
+// refuses to complete a task with open subtasks when strict roll-up is on. A
+// task under an approval policy only reaches the approve step's status
+// through ApproveTask, once the policy is satisfied.
+func (s *TaskService) authorizeTransition(task *models.Task, to string, userID uuid.UUID, role string) error {
+	wf, err := s.workflowSvc.GetWorkflow(task.OrgID)
+	if err != nil {
+		return err
+	}
+	if isCompletedStatus(wf, to) && !isCompletedStatus(wf, task.Status) {
+		if err := s.ensureSubtasksComplete(task.OrgID, task.ID); err != nil {
+			return err
+		}
+	}
+	if reviewStepOf(wf, to) == models.ReviewApprove {
+		policy, err := s.approvalPolicyFor(task)
+		if err != nil {
+			return err
+		}
+		if policy != nil {
+			return fmt.Errorf("task requires sign-off under an approval policy; use the approve action")
+		}
+	}
+	return s.authorizeStep(task, to, userID, role)
+}
+
+// authorizeStatusUpdate gates status changes made by a general update: a
+// PATCH, a board move or a bulk update. Sending completed work back needs a
+// reason and counts as rework, so it has to go through RejectTask.
+func (s *TaskService) authorizeStatusUpdate(task *models.Task, to string, userID uuid.UUID, role string) error {
+	wf, err := s.workflowSvc.GetWorkflow(task.OrgID)
+	if err != nil {
+		return err
+	}
+	if isCompletedStatus(wf, task.Status) && !isCompletedStatus(wf, to) {
+		return fmt.Errorf("use the reject action to send a completed task back for rework")
+	}
+	return s.authorizeTransition(task, to, userID, role)
+}
+
+// authorizeStep checks whether the user may move the task to status to. When
+// the task designates reviewers or approvers, only they may move it to the
+// workflow's verify or approve status respectively.
+func (s *TaskService) authorizeStep(task *models.Task, to string, userID uuid.UUID, role string) error {
+	wf, err := s.workflowSvc.GetWorkflow(task.OrgID)
+	if err != nil {
+		return err
+	}
+	if designated := designatedFor(task, reviewStepOf(wf, to)); len(designated) > 0 {
+		if err := s.workflowSvc.ValidateTransition(task.OrgID, task.Status, to); err != nil {
+			return err
+		}
+		if !hasPerson(designated, userID) {
+			return fmt.Errorf("insufficient permissions")
+		}
+		return nil
+	}
+	return s.workflowSvc.AuthorizeTransition(task.OrgID, task.Status, to, role, isTaskAssignee(task, userID))
+}
+
+// ensureSubtasksComplete refuses completion of a parent with open children
+// when the deployment opts into strict roll-up.
+func (s *TaskService) ensureSubtasksComplete(orgID, taskID uuid.UUID) error {
+	if s.cfg == nil || !s.cfg.Tasks.RequireSubtasksComplete {
+		return nil
+	}
+	open, err := s.taskRepo.CountOpenSubtasks(orgID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to check subtasks: %w", err)
+	}
+	if open > 0 {
+		return fmt.Errorf("task has %d open subtasks", open)
+	}
+	return nil
+}
+
 // MarkDone - Member marks task as done
-func (s *TaskService) MarkDone(orgID, taskID, userID uuid.UUID) (*models.Task, error) {
+func (s *TaskService) MarkDone(orgID, taskID, userID uuid.UUID, role, notes string) (*models.Task, error) {
 	task, err := s.taskRepo.GetByID(orgID, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
@@ -402,17 +781,17 @@ func (s *TaskService) MarkDone(orgID, taskID, userID uuid.UUID) (*models.Task, e
 	if task == nil {
 		return nil, fmt.Errorf("task not found")
 	}
-	if task.AssignedTo == nil || *task.AssignedTo != userID {
-		return nil, fmt.Errorf("insufficient permissions")
+	done, err := s.reviewTarget(orgID, models.ReviewSubmit)
+	if err != nil {
+		return nil, err
 	}
-
-	// Only allow marking as done if in_progress or todo
-	if task.Status != "todo" && task.Status != "in_progress" {
-		return nil, fmt.Errorf("task must be in todo or in_progress status to mark as done")
+	if err := s.authorizeTransition(task, done, userID, role); err != nil {
+		return nil, err
 	}
 
-	task.Status = "done"
-	if err := s.taskRepo.Update(task); err != nil {
+	before := *task
+	task.Status = done
+	if _, err := s.updateWithReview(&before, task, userID, notes); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
@@ -431,7 +810,7 @@ func (s *TaskService) MarkDone(orgID, taskID, userID uuid.UUID) (*models.Task, e
 }
 
 // MarkDoneWithDocument - Member marks task as done with document upload
-func (s *TaskService) MarkDoneWithDocument(orgID, taskID, userID uuid.UUID, filename, filepath, content string) (*models.Task, error) {
+func (s *TaskService) MarkDoneWithDocument(orgID, taskID, userID uuid.UUID, role, notes string, filename, filepath, content string) (*models.Task, error) {
 	task, err := s.taskRepo.GetByID(orgID, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
@@ -439,27 +818,27 @@ func (s *TaskService) MarkDoneWithDocument(orgID, taskID, userID uuid.UUID, file
 	if task == nil {
 		return nil, fmt.Errorf("task not found")
 	}
-	if task.AssignedTo == nil || *task.AssignedTo != userID {
-		return nil, fmt.Errorf("insufficient permissions")
+	done, err := s.reviewTarget(orgID, models.ReviewSubmit)
+	if err != nil {
+		return nil, err
 	}
-
-	// Only allow marking as done if in_progress or todo
-	if task.Status != "todo" && task.Status != "in_progress" {
-		return nil, fmt.Errorf("task must be in todo or in_progress status to mark as done")
+	if err := s.authorizeTransition(task, done, userID, role); err != nil {
+		return nil, err
 	}
 
 	// Update task immediately with document info
-	task.Status = "done"
+	before := *task
+	task.Status = done
 	task.DocumentFilename = &filename
 	task.DocumentPath = &filepath
-	
+
 	// Set initial processing message
 	if content != "" {
 		processingMsg := "⏳ AI summary is being generated..."
 		task.DocumentSummary = &processingMsg
 	}
 
-	if err := s.taskRepo.Update(task); err != nil {
+	if _, err := s.updateWithReview(&before, task, userID, notes); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
@@ -469,6 +848,7 @@ func (s *TaskService) MarkDoneWithDocument(orgID, taskID, userID uuid.UUID, file
 		capturedOrgID := orgID
 		capturedTaskID := taskID
 		capturedFilename := filename
+		capturedPath := filepath
 		capturedContent := content
 		capturedTitle := task.Title
 
@@ -526,30 +906,21 @@ Provide ONLY the summary text, no additional formatting or preamble.`, capturedT
 				}
 			}
 
-			// Update task with generated summary
-			if summary != "" {
-				updatedTask, err := s.taskRepo.GetByID(capturedOrgID, capturedTaskID)
-				if err != nil {
-					fmt.Printf("Failed to fetch task %s for summary update: %v\n", capturedTaskID, err)
-					return
-				}
-				if updatedTask != nil {
-					updatedTask.DocumentSummary = &summary
-					if err := s.taskRepo.Update(updatedTask); err != nil {
-						fmt.Printf("Failed to update task %s with AI summary: %v\n", capturedTaskID, err)
-					} else {
-						fmt.Printf("✓ Successfully updated task %s with AI summary\n", capturedTaskID)
-					}
-				}
-			} else {
-				// Failed to generate - update with fallback message
+			if summary == "" {
 				fmt.Printf("No summary generated for task %s, using fallback\n", capturedTaskID)
-				updatedTask, err := s.taskRepo.GetByID(capturedOrgID, capturedTaskID)
-				if err == nil && updatedTask != nil {
-					fallback := fmt.Sprintf("Document uploaded: %s", capturedFilename)
-					updatedTask.DocumentSummary = &fallback
-					s.taskRepo.Update(updatedTask)
-				}
+				summary = fmt.Sprintf("Document uploaded: %s", capturedFilename)
+			}
+
+			// Write only the summary, and only while the task still has this
+			// document, so edits made in the meantime are never reverted.
+			updated, err := s.taskRepo.SetDocumentSummary(capturedOrgID, capturedTaskID, capturedPath, summary)
+			switch {
+			case err != nil:
+				fmt.Printf("Failed to update task %s with AI summary: %v\n", capturedTaskID, err)
+			case !updated:
+				fmt.Printf("Task %s has a newer document, discarding AI summary\n", capturedTaskID)
+			default:
+				fmt.Printf("✓ Successfully updated task %s with AI summary\n", capturedTaskID)
 			}
 		}()
 	}
@@ -580,7 +951,7 @@ Provide ONLY the summary text, no additional formatting or preamble.`, capturedT
 }
 
 // VerifyTask - Manager verifies a completed task
-func (s *TaskService) VerifyTask(orgID, taskID, userID uuid.UUID) (*models.Task, error) {
+func (s *TaskService) VerifyTask(orgID, taskID, userID uuid.UUID, role, notes string) (*models.Task, error) {
 	task, err := s.taskRepo.GetByID(orgID, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
@@ -589,17 +960,18 @@ func (s *TaskService) VerifyTask(orgID, taskID, userID uuid.UUID) (*models.Task,
 		return nil, fmt.Errorf("task not found")
 	}
 
-	// Only allow verifying if status is "done"
-	if task.Status != "done" {
-		return nil, fmt.Errorf("task must be marked as done before verification")
+	verified, err := s.reviewTarget(orgID, models.ReviewVerify)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeTransition(task, verified, userID, role); err != nil {
+		return nil, err
 	}
 
-	now := time.Now()
-	task.Status = "verified"
-	task.VerifiedBy = &userID
-	task.VerifiedAt = &now
+	before := *task
+	task.Status = verified
 
-	if err := s.taskRepo.Update(task); err != nil {
+	if _, err := s.updateWithReview(&before, task, userID, notes); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
@@ -618,7 +990,7 @@ func (s *TaskService) VerifyTask(orgID, taskID, userID uuid.UUID) (*models.Task,
 }
 
 // ApproveTask - Admin approves a verified task
-func (s *TaskService) ApproveTask(orgID, taskID, userID uuid.UUID) (*models.Task, error) {
+func (s *TaskService) ApproveTask(orgID, taskID, userID uuid.UUID, role, notes string) (*models.Task, error) {
 	task, err := s.taskRepo.GetByID(orgID, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
@@ -627,24 +999,40 @@ func (s *TaskService) ApproveTask(orgID, taskID, userID uuid.UUID) (*models.Task
 		return nil, fmt.Errorf("task not found")
 	}
 
-	// Only allow approving if status is "verified"
-	if task.Status != "verified" {
-		return nil, fmt.Errorf("task must be verified before approval")
+	approved, err := s.reviewTarget(orgID, models.ReviewApprove)
+	if err != nil {
+		return nil, err
 	}
+	policy, err := s.approvalPolicyFor(task)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		return s.approveUnderPolicy(task, policy, approved, userID, role, notes)
+	}
+	if err := s.authorizeStep(task, approved, userID, role); err != nil {
+		return nil, err
+	}
+	if err := s.signOff(task, approved, userID, notes); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return task, nil
+}
 
-	now := time.Now()
-	task.Status = "approved"
-	task.ApprovedBy = &userID
-	task.ApprovedAt = &now
+// signOff moves an authorized task to the approve step's status.
+func (s *TaskService) signOff(task *models.Task, approved string, userID uuid.UUID, notes string) error {
+	before := *task
+	task.Status = approved
 
-	if err := s.taskRepo.Update(task); err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
+	if _, err := s.updateWithReview(&before, task, userID, notes); err != nil {
+		*task = before
+		return err
 	}
 
 	// Audit log
 	auditLog := &models.AuditLog{
 		ID:         uuid.New(),
-		OrgID:      orgID,
+		OrgID:      task.OrgID,
 		UserID:     &userID,
 		Action:     "approve",
 		EntityType: "task",
@@ -652,11 +1040,19 @@ func (s *TaskService) ApproveTask(orgID, taskID, userID uuid.UUID) (*models.Task
 	}
 	_ = s.auditLogRepo.Create(auditLog)
 
-	return task, nil
+	s.syncDependents(task.OrgID, task.ID)
+	return nil
 }
 
-// RejectTask - Manager/Admin rejects a task back to in_progress
-func (s *TaskService) RejectTask(orgID, taskID, userID uuid.UUID) (*models.Task, error) {
+// RejectTask - Manager/Admin rejects a completed task back to the workflow's
+// rework status. The reason is recorded in the review history so the
+// assignee knows what to fix.
+func (s *TaskService) RejectTask(orgID, taskID, userID uuid.UUID, role, reason string) (*models.Task, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("a rejection reason is required")
+	}
+
 	task, err := s.taskRepo.GetByID(orgID, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
@@ -665,18 +1061,28 @@ func (s *TaskService) RejectTask(orgID, taskID, userID uuid.UUID) (*models.Task,
 		return nil, fmt.Errorf("task not found")
 	}
 
-	// Only allow rejecting if status is "done" or "verified"
-	if task.Status != "done" && task.Status != "verified" {
-		return nil, fmt.Errorf("can only reject tasks that are done or verified")
+	// Rejection sends submitted work back; a task that has not been completed
+	// yet has nothing to reject even if the workflow allows the move.
+	wf, err := s.workflowSvc.GetWorkflow(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !isCompletedStatus(wf, task.Status) {
+		return nil, fmt.Errorf("task has not been submitted for review")
+	}
+	rework := reworkStatus(wf)
+	if err := s.authorizeRejection(wf, task, rework, userID, role); err != nil {
+		return nil, err
 	}
 
-	task.Status = "in_progress"
-	task.VerifiedBy = nil
-	task.VerifiedAt = nil
-	task.ApprovedBy = nil
-	task.ApprovedAt = nil
+	before := *task
+	previousStatus := task.Status
+	task.Status = rework
+	applyReviewStamps(wf, task, userID, previousStatus)
 
-	if err := s.taskRepo.Update(task); err != nil {
+	review := newTaskReview(task, userID, models.ReviewReject, previousStatus, reason)
+	history := newHistoryEntry(&userID, s.diffTasks(&before, task))
+	if err := s.taskRepo.UpdateWithReview(task, review, history); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
@@ -688,8 +1094,22 @@ func (s *TaskService) RejectTask(orgID, taskID, userID uuid.UUID) (*models.Task,
 		Action:     "reject",
 		EntityType: "task",
 		EntityID:   &task.ID,
+		Details: map[string]interface{}{
+			"reason":       reason,
+			"from_status":  previousStatus,
+			"rework_count": task.ReworkCount,
+		},
 	}
 	_ = s.auditLogRepo.Create(auditLog)
 
+	// The task left its completed status, so it may be blocked again and
+	// its dependents lose their approved predecessor.
+	if s.syncBlockedStatus(orgID, taskID) {
+		if refreshed, err := s.taskRepo.GetByID(orgID, taskID); err == nil && refreshed != nil {
+			task = refreshed
+		}
+	}
+	s.syncDependents(orgID, taskID)
+
 	return task, nil
 }
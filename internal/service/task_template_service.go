@@ -0,0 +1,415 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// placeholderPattern matches {{name}} in template titles and descriptions.
+// Whitespace inside the braces is ignored.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+var templatePriorities = []string{"low", "medium", "high", "urgent"}
+
+type TaskTemplateService struct {
+	templateRepo *repository.TaskTemplateRepository
+	taskService  *TaskService
+	projectSvc   *ProjectService
+	auditLogRepo *repository.AuditLogRepository
+}
+
+func NewTaskTemplateService(templateRepo *repository.TaskTemplateRepository, taskService *TaskService, projectSvc *ProjectService, auditLogRepo *repository.AuditLogRepository) *TaskTemplateService {
+	return &TaskTemplateService{
+		templateRepo: templateRepo,
+		taskService:  taskService,
+		projectSvc:   projectSvc,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// templatePlaceholders lists the distinct placeholder names in a template in
+// order of first appearance.
+func templatePlaceholders(tmpl *models.TaskTemplate) []string {
+	texts := []string{tmpl.Title, tmpl.Description}
+	for _, st := range tmpl.Subtasks {
+		texts = append(texts, st.Title, st.Description)
+	}
+	names := []string{}
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+func expandPlaceholders(text string, vars map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return vars[placeholderPattern.FindStringSubmatch(m)[1]]
+	})
+}
+
+func parseTemplateAssignee(raw *string) (*uuid.UUID, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid assigned_to UUID: %w", err)
+	}
+	return &id, nil
+}
+
+func parseTemplateLabels(raw []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	seen := make(map[uuid.UUID]bool, len(raw))
+	for _, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label_ids UUID: %w", err)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func buildTemplateSubtasks(reqs []models.TemplateSubtaskRequest) ([]models.TemplateSubtask, error) {
+	subtasks := make([]models.TemplateSubtask, 0, len(reqs))
+	for i, req := range reqs {
+		st := models.TemplateSubtask{
+			Title:            strings.TrimSpace(req.Title),
+			Description:      req.Description,
+			Priority:         req.Priority,
+			DueOffsetMinutes: req.DueOffsetMinutes,
+		}
+		if st.Title == "" {
+			return nil, fmt.Errorf("subtask %d: title is required", i+1)
+		}
+		if st.Priority == "" {
+			st.Priority = "medium"
+		}
+		var err error
+		if st.AssignedTo, err = parseTemplateAssignee(req.AssignedTo); err != nil {
+			return nil, fmt.Errorf("subtask %d: %w", i+1, err)
+		}
+		subtasks = append(subtasks, st)
+	}
+	return subtasks, nil
+}
+
+// validateTemplate checks fields the database cannot, since subtasks are
+// stored as JSON.
+func validateTemplate(tmpl *models.TaskTemplate) error {
+	if strings.TrimSpace(tmpl.Title) == "" {
+		return fmt.Errorf("title is required")
+	}
+	if !contains(templatePriorities, tmpl.Priority) {
+		return fmt.Errorf("invalid priority: %s", tmpl.Priority)
+	}
+	if tmpl.DueOffsetMinutes != nil && *tmpl.DueOffsetMinutes < 0 {
+		return fmt.Errorf("due_offset_minutes must not be negative")
+	}
+	for i, st := range tmpl.Subtasks {
+		if !contains(templatePriorities, st.Priority) {
+			return fmt.Errorf("subtask %d: invalid priority: %s", i+1, st.Priority)
+		}
+		if st.DueOffsetMinutes != nil && *st.DueOffsetMinutes < 0 {
+			return fmt.Errorf("subtask %d: due_offset_minutes must not be negative", i+1)
+		}
+	}
+	return nil
+}
+
+func (s *TaskTemplateService) Create(orgID, createdBy uuid.UUID, req *models.CreateTaskTemplateRequest) (*models.TaskTemplate, error) {
+	tmpl := &models.TaskTemplate{
+		ID:               uuid.New(),
+		OrgID:            orgID,
+		Name:             strings.TrimSpace(req.Name),
+		Title:            req.Title,
+		Description:      req.Description,
+		Priority:         req.Priority,
+		DueOffsetMinutes: req.DueOffsetMinutes,
+		CreatedBy:        createdBy,
+	}
+	if tmpl.Priority == "" {
+		tmpl.Priority = "medium"
+	}
+	if tmpl.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	var err error
+	if tmpl.AssignedTo, err = parseTemplateAssignee(req.AssignedTo); err != nil {
+		return nil, err
+	}
+	if tmpl.ProjectID, err = s.projectSvc.ResolveProject(orgID, req.ProjectID); err != nil {
+		return nil, err
+	}
+	if tmpl.Subtasks, err = buildTemplateSubtasks(req.Subtasks); err != nil {
+		return nil, err
+	}
+	if err := validateTemplate(tmpl); err != nil {
+		return nil, err
+	}
+	labelIDs, err := parseTemplateLabels(req.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(tmpl, labelIDs); err != nil {
+		return nil, fmt.Errorf("failed to create task template: %w", err)
+	}
+
+	s.audit(orgID, createdBy, "create", tmpl.ID, map[string]interface{}{
+		"name": tmpl.Name,
+	})
+
+	return s.Get(orgID, tmpl.ID)
+}
+
+func (s *TaskTemplateService) Get(orgID, id uuid.UUID) (*models.TaskTemplate, error) {
+	tmpl, err := s.templateRepo.GetByID(orgID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task template: %w", err)
+	}
+	if tmpl == nil {
+		return nil, fmt.Errorf("task template not found")
+	}
+	tmpl.Placeholders = templatePlaceholders(tmpl)
+	return tmpl, nil
+}
+
+func (s *TaskTemplateService) List(orgID uuid.UUID) ([]models.TaskTemplate, error) {
+	items, err := s.templateRepo.List(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task templates: %w", err)
+	}
+	for i := range items {
+		items[i].Placeholders = templatePlaceholders(&items[i])
+	}
+	return items, nil
+}
+
+func (s *TaskTemplateService) Update(orgID, id, userID uuid.UUID, req *models.UpdateTaskTemplateRequest) (*models.TaskTemplate, error) {
+	tmpl, err := s.Get(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		tmpl.Name = strings.TrimSpace(*req.Name)
+		if tmpl.Name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+	}
+	if req.Title != nil {
+		tmpl.Title = *req.Title
+	}
+	if req.Description != nil {
+		tmpl.Description = *req.Description
+	}
+	if req.Priority != nil {
+		tmpl.Priority = *req.Priority
+	}
+	if req.AssignedTo != nil {
+		if tmpl.AssignedTo, err = parseTemplateAssignee(req.AssignedTo); err != nil {
+			return nil, err
+		}
+	}
+	if req.DueOffsetMinutes != nil {
+		tmpl.DueOffsetMinutes = req.DueOffsetMinutes
+	}
+	if req.ProjectID != nil {
+		if tmpl.ProjectID, err = s.projectSvc.ResolveProject(orgID, req.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	if req.Subtasks != nil {
+		if tmpl.Subtasks, err = buildTemplateSubtasks(*req.Subtasks); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateTemplate(tmpl); err != nil {
+		return nil, err
+	}
+
+	labelIDs := make([]uuid.UUID, 0, len(tmpl.Labels))
+	for _, l := range tmpl.Labels {
+		labelIDs = append(labelIDs, l.ID)
+	}
+	if req.LabelIDs != nil {
+		if labelIDs, err = parseTemplateLabels(*req.LabelIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.templateRepo.Update(tmpl, labelIDs); err != nil {
+		return nil, fmt.Errorf("failed to update task template: %w", err)
+	}
+
+	s.audit(orgID, userID, "update", tmpl.ID, map[string]interface{}{
+		"name": tmpl.Name,
+	})
+
+	return s.Get(orgID, tmpl.ID)
+}
+
+func (s *TaskTemplateService) Delete(orgID, id, userID uuid.UUID) error {
+	tmpl, err := s.Get(orgID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.templateRepo.Delete(orgID, id); err != nil {
+		return fmt.Errorf("failed to delete task template: %w", err)
+	}
+
+	s.audit(orgID, userID, "delete", id, map[string]interface{}{
+		"name": tmpl.Name,
+	})
+	return nil
+}
+
+// Instantiate expands a template into a task and its subtasks. Every
+// placeholder must have a value. Tasks are created through
+// TaskService.CreateTask, so they are indexed and audited like any other;
+// if a subtask fails, the tasks already created are deleted for good rather
+// than left in the trash.
+func (s *TaskTemplateService) Instantiate(orgID, id, userID uuid.UUID, req *models.InstantiateTemplateRequest) (*models.TemplateInstance, error) {
+	tmpl, err := s.Get(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []string{}
+	for _, name := range tmpl.Placeholders {
+		if _, ok := req.Variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing values for template variables: %s", strings.Join(missing, ", "))
+	}
+
+	now := time.Now()
+	dueDate := func(offset *int) *string {
+		if offset == nil {
+			return nil
+		}
+		due := now.Add(time.Duration(*offset) * time.Minute).Format(time.RFC3339)
+		return &due
+	}
+
+	assignedTo := req.AssignedTo
+	if assignedTo == nil && tmpl.AssignedTo != nil {
+		raw := tmpl.AssignedTo.String()
+		assignedTo = &raw
+	}
+	projectID := req.ProjectID
+	if projectID == nil && tmpl.ProjectID != nil {
+		raw := tmpl.ProjectID.String()
+		projectID = &raw
+	}
+	labelIDs := make([]string, 0, len(tmpl.Labels))
+	for _, l := range tmpl.Labels {
+		labelIDs = append(labelIDs, l.ID.String())
+	}
+
+	parent := &models.CreateTaskRequest{
+		Title:       strings.TrimSpace(expandPlaceholders(tmpl.Title, req.Variables)),
+		Description: expandPlaceholders(tmpl.Description, req.Variables),
+		Priority:    tmpl.Priority,
+		AssignedTo:  assignedTo,
+		DueDate:     dueDate(tmpl.DueOffsetMinutes),
+		ProjectID:   projectID,
+		LabelIDs:    labelIDs,
+		CreatedFrom: fmt.Sprintf("template %q", tmpl.Name),
+	}
+	subtasks := make([]*models.CreateTaskRequest, 0, len(tmpl.Subtasks))
+	for _, st := range tmpl.Subtasks {
+		// Subtasks without their own assignee go to the task's assignee.
+		subAssignee := assignedTo
+		if st.AssignedTo != nil {
+			raw := st.AssignedTo.String()
+			subAssignee = &raw
+		}
+		subtasks = append(subtasks, &models.CreateTaskRequest{
+			Title:       strings.TrimSpace(expandPlaceholders(st.Title, req.Variables)),
+			Description: expandPlaceholders(st.Description, req.Variables),
+			Priority:    st.Priority,
+			AssignedTo:  subAssignee,
+			DueDate:     dueDate(st.DueOffsetMinutes),
+			CreatedFrom: fmt.Sprintf("template %q", tmpl.Name),
+		})
+	}
+	for _, r := range append([]*models.CreateTaskRequest{parent}, subtasks...) {
+		if r.Title == "" {
+			return nil, fmt.Errorf("expanded title must not be empty")
+		}
+	}
+
+	task, err := s.taskService.CreateTask(orgID, userID, parent)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.TemplateInstance{Task: task, Subtasks: []models.Task{}}
+
+	parentID := task.ID.String()
+	for i, sub := range subtasks {
+		sub.ParentID = &parentID
+		child, err := s.taskService.CreateTask(orgID, userID, sub)
+		if err != nil {
+			created := []uuid.UUID{task.ID}
+			for _, st := range result.Subtasks {
+				created = append(created, st.ID)
+			}
+			if discardErr := s.taskService.discardTasks(orgID, created); discardErr != nil {
+				log.Printf("Warning: failed to discard tasks of template %s: %v", tmpl.ID, discardErr)
+			}
+			return nil, fmt.Errorf("subtask %d: %w", i+1, err)
+		}
+		result.Subtasks = append(result.Subtasks, *child)
+	}
+
+	subtaskIDs := make([]string, 0, len(result.Subtasks))
+	for _, st := range result.Subtasks {
+		subtaskIDs = append(subtaskIDs, st.ID.String())
+	}
+	s.audit(orgID, userID, "instantiate", tmpl.ID, map[string]interface{}{
+		"name":        tmpl.Name,
+		"task_id":     task.ID.String(),
+		"subtask_ids": subtaskIDs,
+		"variables":   req.Variables,
+	})
+
+	return result, nil
+}
+
+func (s *TaskTemplateService) audit(orgID, userID uuid.UUID, action string, id uuid.UUID, details map[string]interface{}) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "task_template",
+		EntityID:   &id,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// TrashService lists and restores soft-deleted tasks, issues and documents,
+// and purges them once the retention period has passed.
+type TrashService struct {
+	trashRepo    *repository.TrashRepository
+	taskService  *TaskService
+	issueService *IssueService
+	documentSvc  *DocumentService
+	auditLogRepo *repository.AuditLogRepository
+	retention    time.Duration
+}
+
+func NewTrashService(trashRepo *repository.TrashRepository, taskService *TaskService, issueService *IssueService, documentSvc *DocumentService, auditLogRepo *repository.AuditLogRepository, retention time.Duration) *TrashService {
+	return &TrashService{
+		trashRepo:    trashRepo,
+		taskService:  taskService,
+		issueService: issueService,
+		documentSvc:  documentSvc,
+		auditLogRepo: auditLogRepo,
+		retention:    retention,
+	}
+}
+
+// ListForRole returns the organization's trash to admins. entityType may be
+// task, issue, document or empty for all three.
+func (s *TrashService) ListForRole(orgID uuid.UUID, role, entityType string) ([]models.TrashItem, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	switch entityType {
+	case "", models.TrashTask, models.TrashIssue, models.TrashDocument:
+	default:
+		return nil, fmt.Errorf("invalid type: %s", entityType)
+	}
+
+	items, err := s.trashRepo.List(orgID, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(s.retention)
+	}
+	return items, nil
+}
+
+func (s *TrashService) RestoreTaskForRole(orgID, taskID, userID uuid.UUID, role string) (*models.Task, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	return s.taskService.RestoreTask(orgID, taskID, userID)
+}
+
+func (s *TrashService) RestoreIssueForRole(orgID, issueID, userID uuid.UUID, role string) (*models.Issue, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	return s.issueService.RestoreIssue(orgID, issueID, userID)
+}
+
+func (s *TrashService) RestoreDocumentForRole(ctx context.Context, orgID, documentID, userID uuid.UUID, role string) (*models.Document, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("insufficient permissions")
+	}
+	return s.documentSvc.Restore(ctx, orgID, documentID, userID)
+}
+
+// RunPurger purges expired trash every interval until ctx is done.
+func (s *TrashService) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.PurgeExpired(time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired permanently removes everything deleted more than the retention
+// period before now, along with the files of purged documents.
+func (s *TrashService) PurgeExpired(now time.Time) {
+	purged, err := s.trashRepo.Purge(now.Add(-s.retention))
+	if err != nil {
+		log.Printf("Warning: failed to purge trash: %v", err)
+		return
+	}
+
+	for _, item := range purged {
+		if item.StoragePath != "" {
+			if err := os.Remove(item.StoragePath); err != nil && !os.IsNotExist(err) {
+				log.Printf("Warning: failed to remove file of purged document %s: %v", item.ID, err)
+			}
+		}
+
+		id := item.ID
+		auditLog := &models.AuditLog{
+			ID:         uuid.New(),
+			OrgID:      item.OrgID,
+			Action:     "purge",
+			EntityType: item.EntityType,
+			EntityID:   &id,
+		}
+		_ = s.auditLogRepo.Create(auditLog)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// mentionPattern matches @user@example.com mentions at the start of a comment
+// or after whitespace.
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// mentionedEmails returns the distinct, lower-cased email addresses
+// @mentioned in text.
+func mentionedEmails(text string) []string {
+	emails := []string{}
+	seen := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		email := strings.ToLower(strings.TrimRight(m[1], "."))
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// watcherIDs drops nil and duplicate users.
+func watcherIDs(users ...*uuid.UUID) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(users))
+	seen := make(map[uuid.UUID]bool, len(users))
+	for _, u := range users {
+		if u != nil && !seen[*u] {
+			seen[*u] = true
+			ids = append(ids, *u)
+		}
+	}
+	return ids
+}
+
+// AddTaskWatchers subscribes users to a task. Auto-watching is best effort: a
+// failure is logged rather than failing the change that triggered it.
+func (s *TaskService) AddTaskWatchers(taskID uuid.UUID, users ...*uuid.UUID) {
+	if err := s.watcherRepo.WatchTask(taskID, watcherIDs(users...)...); err != nil {
+		log.Printf("failed to add watchers to task %s: %v", taskID, err)
+	}
+}
+
+func (s *TaskService) WatchTaskForRole(orgID, taskID, userID uuid.UUID, role string) error {
+	if _, err := s.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return err
+	}
+	if err := s.watcherRepo.WatchTask(taskID, userID); err != nil {
+		return fmt.Errorf("failed to watch task: %w", err)
+	}
+	s.auditWatch(orgID, userID, "watch", taskID)
+	return nil
+}
+
+func (s *TaskService) UnwatchTaskForRole(orgID, taskID, userID uuid.UUID, role string) error {
+	if _, err := s.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return err
+	}
+	if err := s.watcherRepo.UnwatchTask(taskID, userID); err != nil {
+		return fmt.Errorf("failed to unwatch task: %w", err)
+	}
+	s.auditWatch(orgID, userID, "unwatch", taskID)
+	return nil
+}
+
+func (s *TaskService) ListTaskWatchersForRole(orgID, taskID, userID uuid.UUID, role string) ([]models.Watcher, error) {
+	if _, err := s.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return nil, err
+	}
+	watchers, err := s.watcherRepo.ListTaskWatchers(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchers: %w", err)
+	}
+	return watchers, nil
+}
+
+func (s *TaskService) auditWatch(orgID, userID uuid.UUID, action string, taskID uuid.UUID) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "task",
+		EntityID:   &taskID,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
+
+// AddIssueWatchers subscribes users to an issue, like AddTaskWatchers.
+func (s *IssueService) AddIssueWatchers(issueID uuid.UUID, users ...*uuid.UUID) {
+	if err := s.watcherRepo.WatchIssue(issueID, watcherIDs(users...)...); err != nil {
+		log.Printf("failed to add watchers to issue %s: %v", issueID, err)
+	}
+}
+
+func (s *IssueService) WatchIssueForRole(orgID, issueID, userID uuid.UUID, role string) error {
+	if _, err := s.GetIssueForRole(orgID, issueID, userID, role); err != nil {
+		return err
+	}
+	if err := s.watcherRepo.WatchIssue(issueID, userID); err != nil {
+		return fmt.Errorf("failed to watch issue: %w", err)
+	}
+	s.auditWatch(orgID, userID, "watch", issueID)
+	return nil
+}
+
+func (s *IssueService) UnwatchIssueForRole(orgID, issueID, userID uuid.UUID, role string) error {
+	if _, err := s.GetIssueForRole(orgID, issueID, userID, role); err != nil {
+		return err
+	}
+	if err := s.watcherRepo.UnwatchIssue(issueID, userID); err != nil {
+		return fmt.Errorf("failed to unwatch issue: %w", err)
+	}
+	s.auditWatch(orgID, userID, "unwatch", issueID)
+	return nil
+}
+
+func (s *IssueService) ListIssueWatchersForRole(orgID, issueID, userID uuid.UUID, role string) ([]models.Watcher, error) {
+	if _, err := s.GetIssueForRole(orgID, issueID, userID, role); err != nil {
+		return nil, err
+	}
+	watchers, err := s.watcherRepo.ListIssueWatchers(issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchers: %w", err)
+	}
+	return watchers, nil
+}
+
+func (s *IssueService) auditWatch(orgID, userID uuid.UUID, action string, issueID uuid.UUID) {
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "issue",
+		EntityID:   &issueID,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
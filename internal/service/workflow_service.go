@@ -0,0 +1,331 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// AssigneeRole is the pseudo-role that lets a task's assignee perform a transition.
+const AssigneeRole = "assignee"
+
+// BlockedStatus is set and cleared automatically by task dependencies, so every
+// workflow must define it.
+const BlockedStatus = "blocked"
+
+// DefaultWorkflow returns the built-in todo -> in_progress -> done -> verified -> approved flow.
+func DefaultWorkflow() *models.Workflow {
+	return &models.Workflow{
+		IsDefault: true,
+		Statuses: []models.WorkflowStatus{
+			{Key: "todo", Name: "To Do", Position: 0, IsInitial: true, Category: models.CategoryTodo},
+			{Key: "in_progress", Name: "In Progress", Position: 1, Category: models.CategoryInProgress},
+			{Key: "done", Name: "Done", Position: 2, Category: models.CategoryCompleted, ReviewStep: models.ReviewSubmit},
+			{Key: "verified", Name: "Verified", Position: 3, Category: models.CategoryCompleted, ReviewStep: models.ReviewVerify},
+			{Key: "approved", Name: "Approved", Position: 4, Category: models.CategoryCompleted, ReviewStep: models.ReviewApprove},
+			{Key: BlockedStatus, Name: "Blocked", Position: 5, Category: models.CategoryBlocked},
+		},
+		Transitions: []models.WorkflowTransition{
+			{FromStatus: "todo", ToStatus: "in_progress", AllowedRoles: []string{AssigneeRole, "manager", "admin"}},
+			{FromStatus: "in_progress", ToStatus: "todo", AllowedRoles: []string{AssigneeRole, "manager", "admin"}},
+			{FromStatus: "todo", ToStatus: "done", AllowedRoles: []string{AssigneeRole}},
+			{FromStatus: "in_progress", ToStatus: "done", AllowedRoles: []string{AssigneeRole}},
+			{FromStatus: "done", ToStatus: "verified", AllowedRoles: []string{"manager", "admin"}},
+			{FromStatus: "done", ToStatus: "in_progress", AllowedRoles: []string{"manager", "admin"}},
+			{FromStatus: "verified", ToStatus: "approved", AllowedRoles: []string{"admin"}},
+			{FromStatus: "verified", ToStatus: "in_progress", AllowedRoles: []string{"manager", "admin"}},
+			{FromStatus: "todo", ToStatus: BlockedStatus, AllowedRoles: []string{"manager", "admin"}},
+			{FromStatus: "in_progress", ToStatus: BlockedStatus, AllowedRoles: []string{"manager", "admin"}},
+			{FromStatus: BlockedStatus, ToStatus: "todo", AllowedRoles: []string{"manager", "admin"}},
+			{FromStatus: BlockedStatus, ToStatus: "in_progress", AllowedRoles: []string{"manager", "admin"}},
+		},
+	}
+}
+
+type WorkflowService struct {
+	workflowRepo *repository.WorkflowRepository
+	auditLogRepo *repository.AuditLogRepository
+}
+
+func NewWorkflowService(workflowRepo *repository.WorkflowRepository, auditLogRepo *repository.AuditLogRepository) *WorkflowService {
+	return &WorkflowService{
+		workflowRepo: workflowRepo,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// GetWorkflow returns the organization's workflow, falling back to the default.
+func (s *WorkflowService) GetWorkflow(orgID uuid.UUID) (*models.Workflow, error) {
+	wf, err := s.workflowRepo.Get(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	if wf == nil {
+		wf = DefaultWorkflow()
+		wf.OrgID = orgID
+	}
+	return wf, nil
+}
+
+// SeedDefault stores the default workflow for a newly created organization.
+func (s *WorkflowService) SeedDefault(orgID uuid.UUID) error {
+	return s.workflowRepo.Replace(orgID, DefaultWorkflow())
+}
+
+func (s *WorkflowService) UpdateWorkflow(orgID, userID uuid.UUID, req *models.UpdateWorkflowRequest) (*models.Workflow, error) {
+	wf := &models.Workflow{
+		OrgID:       orgID,
+		Statuses:    req.Statuses,
+		Transitions: req.Transitions,
+	}
+	if err := validateWorkflow(wf); err != nil {
+		return nil, err
+	}
+
+	// Every status still held by a task must survive the change.
+	inUse, err := s.workflowRepo.ListStatusesInUse(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check statuses in use: %w", err)
+	}
+	defined := make(map[string]bool, len(wf.Statuses))
+	for _, st := range wf.Statuses {
+		defined[st.Key] = true
+	}
+	for _, st := range inUse {
+		if !defined[st] {
+			return nil, fmt.Errorf("status %q is still used by existing tasks", st)
+		}
+	}
+
+	if err := s.workflowRepo.Replace(orgID, wf); err != nil {
+		return nil, fmt.Errorf("failed to update workflow: %w", err)
+	}
+
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     "update",
+		EntityType: "workflow",
+		Details: map[string]interface{}{
+			"statuses":    len(wf.Statuses),
+			"transitions": len(wf.Transitions),
+		},
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+
+	return wf, nil
+}
+
+func validateWorkflow(wf *models.Workflow) error {
+	if len(wf.Statuses) == 0 {
+		return fmt.Errorf("workflow must define at least one status")
+	}
+
+	keys := make(map[string]bool, len(wf.Statuses))
+	steps := make(map[string]bool)
+	initial := 0
+	for i := range wf.Statuses {
+		st := &wf.Statuses[i]
+		st.Key = strings.TrimSpace(st.Key)
+		if st.Key == "" || len(st.Key) > 50 {
+			return fmt.Errorf("status keys must be between 1 and 50 characters")
+		}
+		if keys[st.Key] {
+			return fmt.Errorf("duplicate status %q", st.Key)
+		}
+		keys[st.Key] = true
+		if st.IsInitial {
+			initial++
+		}
+		if st.WIPLimit != nil && *st.WIPLimit < 1 {
+			return fmt.Errorf("status %q must have a positive WIP limit", st.Key)
+		}
+		if st.Category == "" {
+			switch {
+			case st.IsInitial:
+				st.Category = models.CategoryTodo
+			case st.Key == BlockedStatus:
+				st.Category = models.CategoryBlocked
+			default:
+				st.Category = models.CategoryInProgress
+			}
+		}
+		if (st.Key == BlockedStatus) != (st.Category == models.CategoryBlocked) {
+			return fmt.Errorf("only the %q status may use the blocked category", BlockedStatus)
+		}
+		if st.IsInitial && st.Category == models.CategoryCompleted {
+			return fmt.Errorf("the initial status cannot be completed")
+		}
+		if st.ReviewStep != "" {
+			if st.Category != models.CategoryCompleted {
+				return fmt.Errorf("status %q must be completed to have a review step", st.Key)
+			}
+			if steps[st.ReviewStep] {
+				return fmt.Errorf("review step %q is used by more than one status", st.ReviewStep)
+			}
+			steps[st.ReviewStep] = true
+		}
+	}
+	if initial != 1 {
+		return fmt.Errorf("workflow must have exactly one initial status")
+	}
+	if !keys[BlockedStatus] {
+		return fmt.Errorf("workflow must define the %q status", BlockedStatus)
+	}
+
+	validRoles := map[string]bool{"admin": true, "manager": true, "member": true, AssigneeRole: true}
+	seen := make(map[string]bool, len(wf.Transitions))
+	for _, tr := range wf.Transitions {
+		if !keys[tr.FromStatus] || !keys[tr.ToStatus] {
+			return fmt.Errorf("transition %s -> %s references an unknown status", tr.FromStatus, tr.ToStatus)
+		}
+		if tr.FromStatus == tr.ToStatus {
+			return fmt.Errorf("transition %s -> %s must change status", tr.FromStatus, tr.ToStatus)
+		}
+		pair := tr.FromStatus + "->" + tr.ToStatus
+		if seen[pair] {
+			return fmt.Errorf("duplicate transition %s -> %s", tr.FromStatus, tr.ToStatus)
+		}
+		seen[pair] = true
+		if len(tr.AllowedRoles) == 0 {
+			return fmt.Errorf("transition %s -> %s must allow at least one role", tr.FromStatus, tr.ToStatus)
+		}
+		for _, role := range tr.AllowedRoles {
+			if !validRoles[role] {
+				return fmt.Errorf("invalid role %q on transition %s -> %s", role, tr.FromStatus, tr.ToStatus)
+			}
+		}
+	}
+	return nil
+}
+
+// InitialStatus returns the status new tasks start in.
+func (s *WorkflowService) InitialStatus(orgID uuid.UUID) (string, error) {
+	wf, err := s.GetWorkflow(orgID)
+	if err != nil {
+		return "", err
+	}
+	for _, st := range wf.Statuses {
+		if st.IsInitial {
+			return st.Key, nil
+		}
+	}
+	return wf.Statuses[0].Key, nil
+}
+
+// AuthorizeTransition is the single gate every user-driven task status change
+// goes through. isAssignee reports whether the caller is assigned to the task.
+func (s *WorkflowService) AuthorizeTransition(orgID uuid.UUID, from, to, role string, isAssignee bool) error {
+	tr, err := s.findTransition(orgID, from, to)
+	if err != nil {
+		return err
+	}
+	for _, allowed := range tr.AllowedRoles {
+		if allowed == role || (allowed == AssigneeRole && isAssignee) {
+			return nil
+		}
+	}
+	return fmt.Errorf("insufficient permissions")
+}
+
+// ValidateTransition checks that the workflow has a transition from one
+// status to another, without checking who may take it. It is used when the
+// task itself designates who may act.
+func (s *WorkflowService) ValidateTransition(orgID uuid.UUID, from, to string) error {
+	_, err := s.findTransition(orgID, from, to)
+	return err
+}
+
+func (s *WorkflowService) findTransition(orgID uuid.UUID, from, to string) (*models.WorkflowTransition, error) {
+	wf, err := s.GetWorkflow(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := false
+	for _, st := range wf.Statuses {
+		if st.Key == to {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown status %q", to)
+	}
+
+	for i := range wf.Transitions {
+		if wf.Transitions[i].FromStatus == from && wf.Transitions[i].ToStatus == to {
+			return &wf.Transitions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("status transition from %q to %q is not allowed", from, to)
+}
+
+// workflowStatus returns the status with the given key, or nil.
+func workflowStatus(wf *models.Workflow, key string) *models.WorkflowStatus {
+	for i := range wf.Statuses {
+		if wf.Statuses[i].Key == key {
+			return &wf.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// isCompletedStatus reports whether key is in the completed category.
+func isCompletedStatus(wf *models.Workflow, key string) bool {
+	st := workflowStatus(wf, key)
+	return st != nil && st.Category == models.CategoryCompleted
+}
+
+// reviewStatus returns the status reached by a review step, or "" if the
+// workflow has no such step.
+func reviewStatus(wf *models.Workflow, step string) string {
+	for _, st := range wf.Statuses {
+		if st.ReviewStep == step {
+			return st.Key
+		}
+	}
+	return ""
+}
+
+// reviewStepOf returns the review step that reaches key, or "".
+func reviewStepOf(wf *models.Workflow, key string) string {
+	if st := workflowStatus(wf, key); st != nil {
+		return st.ReviewStep
+	}
+	return ""
+}
+
+// isSignedOff reports whether key is the workflow's final sign-off: the
+// approve step, or any completed status when the workflow has no approve step.
+func isSignedOff(wf *models.Workflow, key string) bool {
+	if approved := reviewStatus(wf, models.ReviewApprove); approved != "" {
+		return key == approved
+	}
+	return isCompletedStatus(wf, key)
+}
+
+// reworkStatus returns where a rejected task goes back to: the first
+// in-progress status, or the initial status when there is none.
+func reworkStatus(wf *models.Workflow) string {
+	var rework *models.WorkflowStatus
+	initial := wf.Statuses[0].Key
+	for i := range wf.Statuses {
+		st := &wf.Statuses[i]
+		if st.IsInitial {
+			initial = st.Key
+		}
+		if st.Category == models.CategoryInProgress && (rework == nil || st.Position < rework.Position) {
+			rework = st
+		}
+	}
+	if rework != nil {
+		return rework.Key
+	}
+	return initial
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"saas-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// customWorkflow has no verify step, a second in-progress status placed
+// before the first, and a plain completed status next to the approve step.
+func customWorkflow() *models.Workflow {
+	return &models.Workflow{
+		Statuses: []models.WorkflowStatus{
+			{Key: "backlog", Position: 0, IsInitial: true, Category: models.CategoryTodo},
+			{Key: "doing", Position: 3, Category: models.CategoryInProgress},
+			{Key: "triage", Position: 1, Category: models.CategoryInProgress},
+			{Key: "shipped", Position: 4, Category: models.CategoryCompleted, ReviewStep: models.ReviewSubmit},
+			{Key: "archived", Position: 5, Category: models.CategoryCompleted},
+			{Key: "signed", Position: 6, Category: models.CategoryCompleted, ReviewStep: models.ReviewApprove},
+			{Key: BlockedStatus, Position: 7, Category: models.CategoryBlocked},
+		},
+	}
+}
+
+func TestDefaultWorkflowIsValid(t *testing.T) {
+	if err := validateWorkflow(DefaultWorkflow()); err != nil {
+		t.Fatalf("default workflow is invalid: %v", err)
+	}
+}
+
+func TestValidateWorkflowDefaultsCategories(t *testing.T) {
+	wf := &models.Workflow{Statuses: []models.WorkflowStatus{
+		{Key: "new", IsInitial: true},
+		{Key: "working"},
+		{Key: BlockedStatus},
+	}}
+	if err := validateWorkflow(wf); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{models.CategoryTodo, models.CategoryInProgress, models.CategoryBlocked}
+	for i, st := range wf.Statuses {
+		if st.Category != want[i] {
+			t.Errorf("status %q category = %q, want %q", st.Key, st.Category, want[i])
+		}
+	}
+}
+
+func TestValidateWorkflowErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(wf *models.Workflow)
+		want   string
+	}{
+		{"no statuses", func(wf *models.Workflow) { wf.Statuses = nil }, "at least one status"},
+		{"duplicate key", func(wf *models.Workflow) { wf.Statuses[1].Key = "todo" }, `duplicate status "todo"`},
+		{"two initial statuses", func(wf *models.Workflow) { wf.Statuses[1].IsInitial = true }, "exactly one initial status"},
+		{"missing blocked", func(wf *models.Workflow) { wf.Statuses = wf.Statuses[:5] }, `must define the "blocked" status`},
+		{"blocked category elsewhere", func(wf *models.Workflow) { wf.Statuses[1].Category = models.CategoryBlocked }, "blocked category"},
+		{"completed initial", func(wf *models.Workflow) { wf.Statuses[0].Category = models.CategoryCompleted }, "initial status cannot be completed"},
+		{"review step outside completed", func(wf *models.Workflow) { wf.Statuses[1].ReviewStep = models.ReviewVerify }, "must be completed to have a review step"},
+		{"duplicate review step", func(wf *models.Workflow) { wf.Statuses[3].ReviewStep = models.ReviewSubmit }, "used by more than one status"},
+		{"non-positive WIP limit", func(wf *models.Workflow) { zero := 0; wf.Statuses[1].WIPLimit = &zero }, "positive WIP limit"},
+		{"unknown transition status", func(wf *models.Workflow) { wf.Transitions[0].ToStatus = "nowhere" }, "references an unknown status"},
+		{"self transition", func(wf *models.Workflow) { wf.Transitions[0].ToStatus = wf.Transitions[0].FromStatus }, "must change status"},
+		{"duplicate transition", func(wf *models.Workflow) { wf.Transitions[1] = wf.Transitions[0] }, "duplicate transition"},
+		{"invalid role", func(wf *models.Workflow) { wf.Transitions[0].AllowedRoles = []string{"owner"} }, `invalid role "owner"`},
+	}
+	for _, tt := range tests {
+		wf := DefaultWorkflow()
+		tt.modify(wf)
+		err := validateWorkflow(wf)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestReviewHelpers(t *testing.T) {
+	def, custom := DefaultWorkflow(), customWorkflow()
+
+	if got := reviewStatus(custom, models.ReviewVerify); got != "" {
+		t.Errorf("reviewStatus(verify) = %q on a workflow without a verify step", got)
+	}
+	if got := reviewStepOf(custom, "signed"); got != models.ReviewApprove {
+		t.Errorf("reviewStepOf(signed) = %q", got)
+	}
+	if !isCompletedStatus(custom, "archived") || isCompletedStatus(custom, "doing") || isCompletedStatus(custom, "gone") {
+		t.Error("isCompletedStatus does not follow categories")
+	}
+
+	if !isSignedOff(def, "approved") || isSignedOff(def, "verified") {
+		t.Error("only the approve step signs off the default workflow")
+	}
+	if isSignedOff(custom, "archived") {
+		t.Error("a completed status is not a sign-off when an approve step exists")
+	}
+	noApprove := customWorkflow()
+	noApprove.Statuses[5].ReviewStep = ""
+	if !isSignedOff(noApprove, "archived") || !isSignedOff(noApprove, "shipped") {
+		t.Error("every completed status signs off a workflow without an approve step")
+	}
+
+	if got := reworkStatus(def); got != "in_progress" {
+		t.Errorf("reworkStatus(default) = %q", got)
+	}
+	if got := reworkStatus(custom); got != "triage" {
+		t.Errorf("reworkStatus(custom) = %q, want the in-progress status with the lowest position", got)
+	}
+	todoOnly := &models.Workflow{Statuses: []models.WorkflowStatus{
+		{Key: BlockedStatus, Category: models.CategoryBlocked},
+		{Key: "open", IsInitial: true, Category: models.CategoryTodo},
+	}}
+	if got := reworkStatus(todoOnly); got != "open" {
+		t.Errorf("reworkStatus without in-progress statuses = %q, want the initial status", got)
+	}
+
+	if got := pendingStep(def, "done"); got != models.ReviewVerify {
+		t.Errorf("pendingStep(done) = %q", got)
+	}
+	if got := pendingStep(def, "verified"); got != models.ReviewApprove {
+		t.Errorf("pendingStep(verified) = %q", got)
+	}
+	if got := pendingStep(custom, "shipped"); got != models.ReviewApprove {
+		t.Errorf("pendingStep(shipped) = %q, want approve when there is no verify step", got)
+	}
+	if got := pendingStep(def, "approved"); got != "" {
+		t.Errorf("pendingStep(approved) = %q", got)
+	}
+
+	if got := reviewActionFor(def, "verified", "approved"); got != models.ReviewApprove {
+		t.Errorf("reviewActionFor(verified, approved) = %q", got)
+	}
+	if got := reviewActionFor(def, "approved", "approved"); got != "" {
+		t.Errorf("reviewActionFor without a change = %q", got)
+	}
+}
+
+func TestApplyReviewStamps(t *testing.T) {
+	wf := DefaultWorkflow()
+	actor := uuid.New()
+
+	task := &models.Task{Status: "verified"}
+	applyReviewStamps(wf, task, actor, "done")
+	if task.VerifiedBy == nil || *task.VerifiedBy != actor || task.VerifiedAt == nil || task.ApprovedBy != nil {
+		t.Fatalf("verify stamps = %v %v %v", task.VerifiedBy, task.VerifiedAt, task.ApprovedBy)
+	}
+
+	task.Status = "approved"
+	applyReviewStamps(wf, task, actor, "verified")
+	if task.ApprovedBy == nil || task.ApprovedAt == nil || task.VerifiedBy == nil {
+		t.Fatal("approving should keep the verify stamps and add the approve stamps")
+	}
+
+	applyReviewStamps(wf, task, uuid.New(), "approved")
+	if *task.ApprovedBy != actor {
+		t.Fatal("stamps must not change when the status does not")
+	}
+
+	task.Status = "in_progress"
+	applyReviewStamps(wf, task, actor, "approved")
+	if task.VerifiedBy != nil || task.VerifiedAt != nil || task.ApprovedBy != nil || task.ApprovedAt != nil {
+		t.Fatal("leaving the completed statuses should clear all stamps")
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"saas-backend/internal/models"
+	"saas-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const worklogDateLayout = "2006-01-02"
+
+type WorklogService struct {
+	worklogRepo  *repository.WorklogRepository
+	taskService  *TaskService
+	auditLogRepo *repository.AuditLogRepository
+}
+
+func NewWorklogService(worklogRepo *repository.WorklogRepository, taskService *TaskService, auditLogRepo *repository.AuditLogRepository) *WorklogService {
+	return &WorklogService{
+		worklogRepo:  worklogRepo,
+		taskService:  taskService,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+func (s *WorklogService) ListForTask(orgID, taskID, userID uuid.UUID, role string) ([]models.Worklog, error) {
+	if _, err := s.taskService.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return nil, err
+	}
+	worklogs, err := s.worklogRepo.ListByTask(orgID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worklogs: %w", err)
+	}
+	return worklogs, nil
+}
+
+// LogTime records a manual entry. The work date defaults to today.
+func (s *WorklogService) LogTime(orgID, taskID, userID uuid.UUID, role string, req *models.CreateWorklogRequest) (*models.Worklog, error) {
+	if _, err := s.taskService.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return nil, err
+	}
+
+	workDate := time.Now().UTC().Truncate(24 * time.Hour)
+	if raw := strings.TrimSpace(req.WorkDate); raw != "" {
+		d, err := time.Parse(worklogDateLayout, raw)
+		if err != nil {
+			return nil, fmt.Errorf("work_date must be a date (YYYY-MM-DD)")
+		}
+		if d.After(time.Now()) {
+			return nil, fmt.Errorf("work_date cannot be in the future")
+		}
+		workDate = d
+	}
+
+	worklog := &models.Worklog{
+		ID:              uuid.New(),
+		OrgID:           orgID,
+		TaskID:          taskID,
+		UserID:          userID,
+		WorkDate:        workDate,
+		DurationSeconds: int64(req.DurationMinutes) * 60,
+		Note:            strings.TrimSpace(req.Note),
+	}
+	if err := s.worklogRepo.Create(worklog); err != nil {
+		return nil, fmt.Errorf("failed to log time: %w", err)
+	}
+
+	s.audit(orgID, userID, "log_time", worklog, map[string]interface{}{
+		"duration_seconds": worklog.DurationSeconds,
+		"work_date":        worklog.WorkDate.Format(worklogDateLayout),
+	})
+	return worklog, nil
+}
+
+// StartTimer starts a running timer on the task. A user may only have one
+// running timer at a time across all tasks.
+func (s *WorklogService) StartTimer(orgID, taskID, userID uuid.UUID, role string, req *models.StartTimerRequest) (*models.Worklog, error) {
+	if _, err := s.taskService.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	worklog := &models.Worklog{
+		ID:        uuid.New(),
+		OrgID:     orgID,
+		TaskID:    taskID,
+		UserID:    userID,
+		WorkDate:  now.Truncate(24 * time.Hour),
+		StartedAt: &now,
+		IsRunning: true,
+		Note:      strings.TrimSpace(req.Note),
+	}
+	if err := s.worklogRepo.Create(worklog); err != nil {
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	s.audit(orgID, userID, "start_timer", worklog, nil)
+	return worklog, nil
+}
+
+// StopTimer stops the caller's running timer on the task and records the
+// elapsed time. Only the timer's owner can find it, so the task itself is not
+// checked: a user who lost access to the task, or whose task was trashed,
+// must still be able to stop the timer before starting another.
+func (s *WorklogService) StopTimer(orgID, taskID, userID uuid.UUID) (*models.Worklog, error) {
+	worklog, err := s.worklogRepo.GetRunning(orgID, taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timer: %w", err)
+	}
+	if worklog == nil {
+		return nil, fmt.Errorf("no running timer on this task")
+	}
+	if err := s.worklogRepo.Stop(worklog, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	s.audit(orgID, userID, "stop_timer", worklog, map[string]interface{}{
+		"duration_seconds": worklog.DurationSeconds,
+	})
+	return worklog, nil
+}
+
+// DeleteWorklog removes an entry. Users delete their own entries; admins and
+// managers may delete any.
+func (s *WorklogService) DeleteWorklog(orgID, taskID, worklogID, userID uuid.UUID, role string) error {
+	if _, err := s.taskService.GetTaskForRole(orgID, taskID, userID, role); err != nil {
+		return err
+	}
+
+	worklog, err := s.worklogRepo.GetByID(orgID, worklogID)
+	if err != nil {
+		return fmt.Errorf("failed to get worklog: %w", err)
+	}
+	if worklog == nil || worklog.TaskID != taskID {
+		return fmt.Errorf("worklog not found")
+	}
+	if worklog.UserID != userID && role != "admin" && role != "manager" {
+		return fmt.Errorf("insufficient permissions")
+	}
+
+	if err := s.worklogRepo.Delete(orgID, worklogID); err != nil {
+		return fmt.Errorf("failed to delete worklog: %w", err)
+	}
+
+	s.audit(orgID, userID, "delete", worklog, map[string]interface{}{
+		"duration_seconds": worklog.DurationSeconds,
+	})
+	return nil
+}
+
+func (s *WorklogService) audit(orgID, userID uuid.UUID, action string, worklog *models.Worklog, details map[string]interface{}) {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["task_id"] = worklog.TaskID.String()
+	auditLog := &models.AuditLog{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "worklog",
+		EntityID:   &worklog.ID,
+		Details:    details,
+	}
+	_ = s.auditLogRepo.Create(auditLog)
+}
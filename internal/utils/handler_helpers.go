@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"saas-backend/internal/models"
+	"saas-backend/internal/query"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -22,6 +26,40 @@ func ParseUUID(c *gin.Context, paramName string, errorLabel string) (uuid.UUID,
 	return id, true
 }
 
+// ParseOptionalUUIDQuery parses an optional UUID query parameter. It returns
+// nil when the parameter is absent and an error response if it is invalid.
+func ParseOptionalUUIDQuery(c *gin.Context, paramName string, errorLabel string) (*uuid.UUID, bool) {
+	raw := c.Query(paramName)
+	if raw == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid " + errorLabel,
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+	return &id, true
+}
+
+// ParsePageRequest reads the cursor, limit, sort and order query parameters
+// shared by paginated listings. Invalid limits fall back to the default.
+func ParsePageRequest(c *gin.Context) models.PageRequest {
+	page := models.PageRequest{
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+		Order:  c.Query("order"),
+	}
+	if raw := c.Query("limit"); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil {
+			page.Limit = v
+		}
+	}
+	return page
+}
+
 // BindJSON binds JSON request body and returns an error response if invalid
 func BindJSON(c *gin.Context, obj interface{}) bool {
 	if err := c.ShouldBindJSON(obj); err != nil {
@@ -45,6 +83,49 @@ func HandlePermissionError(c *gin.Context, err error, defaultMsg string) {
 	c.JSON(status, models.ErrorResponse{Error: errMsg, Message: err.Error()})
 }
 
+// HandleListError reports a malformed q= filter expression as a 400 that
+// names the offending token, and handles any other error like
+// HandlePermissionError.
+func HandleListError(c *gin.Context, err error, defaultMsg string) {
+	var qerr *query.Error
+	if errors.As(err, &qerr) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid query", Message: qerr.Error()})
+		return
+	}
+	HandlePermissionError(c, err, defaultMsg)
+}
+
+// HandleUpdateError reports a version conflict as 412 Precondition Failed and
+// handles any other error like HandlePermissionError.
+func HandleUpdateError(c *gin.Context, err error, defaultMsg string) {
+	if errors.Is(err, models.ErrVersionConflict) {
+		c.JSON(http.StatusPreconditionFailed, models.ErrorResponse{Error: "version conflict", Message: models.ErrVersionConflict.Error()})
+		return
+	}
+	HandlePermissionError(c, err, defaultMsg)
+}
+
+// SetETag sends a record's version as its ETag.
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// ParseIfMatch reads the version from an If-Match header. It returns nil when
+// the header is absent or "*". A value that is not an ETag issued by SetETag
+// can never match, so it is answered with 412 and ParseIfMatch returns false.
+func ParseIfMatch(c *gin.Context) (*int, bool) {
+	raw := strings.TrimSpace(c.GetHeader("If-Match"))
+	if raw == "" || raw == "*" {
+		return nil, true
+	}
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(raw, "W/"), `"`))
+	if err != nil {
+		c.JSON(http.StatusPreconditionFailed, models.ErrorResponse{Error: "version conflict", Message: "If-Match does not match the current version"})
+		return nil, false
+	}
+	return &version, true
+}
+
 // RespondWithError sends a JSON error response
 func RespondWithError(c *gin.Context, status int, error string, message string) {
 	c.JSON(status, models.ErrorResponse{
@@ -58,6 +139,16 @@ func RespondWithSuccess(c *gin.Context, status int, data interface{}) {
 	c.JSON(status, data)
 }
 
+// RespondWithPage sends one page of a listing in the pagination envelope. An
+// empty nextCursor marks the last page.
+func RespondWithPage(c *gin.Context, data interface{}, nextCursor string) {
+	page := models.Page{Data: data}
+	if nextCursor != "" {
+		page.NextCursor = &nextCursor
+	}
+	c.JSON(http.StatusOK, page)
+}
+
 // RespondWithMessage sends a JSON message response
 func RespondWithMessage(c *gin.Context, status int, message string) {
 	c.JSON(status, models.SuccessResponse{